@@ -30,7 +30,7 @@ func (server *Server) createAccount(ctx *gin.Context) {
 		Balance:  0,
 	}
 
-	account, err := server.store.CreateAccount(ctx, arg)
+	account, err := server.store.CreateAccountTx(ctx, arg)
 	if err != nil {
 		switch db.ErrorCode(err) {
 		case db.UniqueViolation, db.ForeignKeyViolation:
@@ -7,8 +7,11 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/require"
+	mockdb "github.com/yelaco/simple-bank/db/mock"
 	db "github.com/yelaco/simple-bank/db/sqlc"
+	"github.com/yelaco/simple-bank/fx"
 	"github.com/yelaco/simple-bank/util"
+	"go.uber.org/mock/gomock"
 )
 
 func newTestServer(t *testing.T, store db.Store) *Server {
@@ -17,7 +20,20 @@ func newTestServer(t *testing.T, store db.Store) *Server {
 		AccessTokenDuration: time.Minute,
 	}
 
-	server, err := NewServer(config, store)
+	rateProvider, err := fx.NewStaticRateProvider(map[string]float64{
+		util.USD + "/" + util.EUR: 0.9,
+	})
+	require.NoError(t, err)
+
+	// access tokens are never revoked unless a test expects otherwise
+	if mockStore, ok := store.(*mockdb.MockStore); ok {
+		mockStore.EXPECT().
+			GetUserTokenState(gomock.Any(), gomock.Any()).
+			AnyTimes().
+			Return(db.GetUserTokenStateRow{}, nil)
+	}
+
+	server, err := NewServer(config, store, rateProvider)
 	require.NoError(t, err)
 
 	return server
@@ -8,20 +8,24 @@ import (
 
 	"github.com/gin-gonic/gin"
 	db "github.com/yelaco/simple-bank/db/sqlc"
+	"github.com/yelaco/simple-bank/fx"
 	"github.com/yelaco/simple-bank/token"
+	"github.com/yelaco/simple-bank/userstate"
 	"github.com/yelaco/simple-bank/util"
 )
 
 // Server serves HTTP requests for our banking service
 type Server struct {
-	config     util.Config
-	store      db.Store
-	tokenMaker token.Maker
-	router     *gin.Engine
+	config       util.Config
+	store        db.Store
+	tokenMaker   token.Maker
+	rateProvider fx.RateProvider
+	userState    *userstate.Cache
+	router       *gin.Engine
 }
 
 // NewServer creates a new HTTP server and setup routing.
-func NewServer(config util.Config, store db.Store) (*Server, error) {
+func NewServer(config util.Config, store db.Store, rateProvider fx.RateProvider) (*Server, error) {
 	tokenMaker, err := token.NewPasetoMaker(config.TokenSymmetricKey)
 	// tokenMaker, err := token.NewJwtMaker(config.TokenSymmetricKey)
 	if err != nil {
@@ -29,9 +33,11 @@ func NewServer(config util.Config, store db.Store) (*Server, error) {
 	}
 
 	server := &Server{
-		config:     config,
-		store:      store,
-		tokenMaker: tokenMaker,
+		config:       config,
+		store:        store,
+		tokenMaker:   tokenMaker,
+		rateProvider: rateProvider,
+		userState:    userstate.NewCache(store, config.UserStateCacheDuration),
 	}
 
 	server.setupRouter()
@@ -47,7 +53,7 @@ func (server *Server) setupRouter() {
 	router.POST("/users/login", server.loginUser)
 	router.POST("/tokens/renew_access", server.renewAccessToken)
 
-	authRoutes := router.Group("/").Use(authMiddleware(server.tokenMaker))
+	authRoutes := router.Group("/").Use(authMiddleware(server.tokenMaker, server.userState))
 	{
 		// account routes
 		authRoutes.POST("/accounts", server.createAccount)
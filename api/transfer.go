@@ -7,6 +7,8 @@ import (
 
 	"github.com/gin-gonic/gin"
 	db "github.com/yelaco/simple-bank/db/sqlc"
+	"github.com/yelaco/simple-bank/fx"
+	"github.com/yelaco/simple-bank/metrics"
 	"github.com/yelaco/simple-bank/token"
 )
 
@@ -40,7 +42,8 @@ func (server *Server) createTransfer(ctx *gin.Context) {
 		return
 	}
 
-	_, err = server.validateAccount(ctx, req.ToAccountID, req.Currency)
+	// the receiving account may hold another currency, the amount is then converted at the current rate
+	toAccount, err := server.loadAccount(ctx, req.ToAccountID)
 	if err != nil {
 		ctx.Errors = append(ctx.Errors, &gin.Error{
 			Err:  fmt.Errorf("api.Server.createTransfer: invalid 'to' account: %w", err),
@@ -53,26 +56,57 @@ func (server *Server) createTransfer(ctx *gin.Context) {
 		FromAccountID: req.FromAccountID,
 		ToAccountID:   req.ToAccountID,
 		Amount:        req.Amount,
+		Audit: db.AuditContext{
+			Actor:     authPayload.Username,
+			ClientIP:  ctx.ClientIP(),
+			UserAgent: ctx.Request.UserAgent(),
+			RequestID: ctx.GetHeader("X-Request-Id"),
+		},
+	}
+
+	if toAccount.Currency != fromAccount.Currency {
+		rate, err := server.rateProvider.GetRate(ctx, fromAccount.Currency, toAccount.Currency)
+		if err != nil {
+			if errors.Is(err, fx.ErrRateNotFound) {
+				ctx.JSON(http.StatusUnprocessableEntity, errorResponse(
+					fmt.Errorf("api.Server.createTransfer: %w", err),
+				))
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		arg.ToAmount = fx.Convert(req.Amount, rate)
+		arg.ExchangeRate = rate
+
+		if arg.ToAmount <= 0 {
+			err := fmt.Errorf("api.Server.createTransfer: amount is too small to convert from %s to %s", fromAccount.Currency, toAccount.Currency)
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
 	}
 
 	result, err := server.store.TransferTx(ctx, arg)
 	if err != nil {
+		if errors.Is(err, db.ErrInsufficientFunds) || errors.Is(err, db.ErrAccountClosed) {
+			ctx.JSON(http.StatusUnprocessableEntity, errorResponse(
+				fmt.Errorf("api.Server.createTransfer: %w", err),
+			))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
+	metrics.ObserveTransfer(result.FromAccount.Currency, result.Transfer.Amount)
+
 	ctx.JSON(http.StatusOK, result)
 }
 
 func (server *Server) validateAccount(ctx *gin.Context, accountID int64, currency string) (db.Account, error) {
-	account, err := server.store.GetAccount(ctx, accountID)
+	account, err := server.loadAccount(ctx, accountID)
 	if err != nil {
-		if errors.Is(err, db.ErrRecordNotFound) {
-			ctx.JSON(http.StatusNotFound, errorResponse(err))
-			return db.Account{}, fmt.Errorf("api.Server.validateAccount: account not found: %w", err)
-		}
-
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return db.Account{}, err
 	}
 
@@ -84,3 +118,18 @@ func (server *Server) validateAccount(ctx *gin.Context, accountID int64, currenc
 
 	return account, nil
 }
+
+func (server *Server) loadAccount(ctx *gin.Context, accountID int64) (db.Account, error) {
+	account, err := server.store.GetAccount(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return db.Account{}, fmt.Errorf("api.Server.loadAccount: account not found: %w", err)
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return db.Account{}, err
+	}
+
+	return account, nil
+}
@@ -116,6 +116,13 @@ func (server *Server) loginUser(ctx *gin.Context) {
 		return
 	}
 
+	// The second login step is only served by the gRPC API
+	if user.IsTotpEnabled {
+		err := errors.New("two-factor authentication required")
+		ctx.JSON(http.StatusForbidden, errorResponse(err))
+		return
+	}
+
 	accessToken, accessPayload, err := server.tokenMaker.CreateToken(
 		user.Username,
 		user.Role,
@@ -138,6 +145,7 @@ func (server *Server) loginUser(ctx *gin.Context) {
 
 	session, err := server.store.CreateSession(ctx, db.CreateSessionParams{
 		ID:           refreshPayload.ID,
+		FamilyID:     refreshPayload.ID,
 		Username:     user.Username,
 		RefreshToken: refreshToken,
 		UserAgent:    ctx.Request.UserAgent(),
@@ -0,0 +1,57 @@
+// Command verify-audit-log checks the hash chain of the audit log, so that
+// any event altered or removed outside of the application is detected.
+// It exits with a non-zero status when the chain is broken.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"os"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	db "github.com/yelaco/simple-bank/db/sqlc"
+	"github.com/yelaco/simple-bank/util"
+)
+
+func main() {
+	configPath := flag.String("config", ".", "directory of the app.env file")
+	batchSize := flag.Int("batch-size", 1000, "number of events read at once")
+	flag.Parse()
+
+	config, err := util.LoadConfig(*configPath)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot load config")
+	}
+
+	if config.Environment == "development" {
+		log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr})
+	}
+
+	ctx := context.Background()
+
+	conn, err := pgxpool.New(ctx, config.DBSource)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot connect to db")
+	}
+	defer conn.Close()
+
+	count, err := db.VerifyAuditLog(ctx, db.New(conn), int32(*batchSize))
+
+	var verificationErr *db.AuditVerificationError
+	if errors.As(err, &verificationErr) {
+		log.Error().
+			Err(verificationErr.Err).
+			Int64("event_id", verificationErr.EventID).
+			Int64("verified_events", count).
+			Msg("audit log has been tampered with")
+		os.Exit(1)
+	}
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot verify audit log")
+	}
+
+	log.Info().Int64("verified_events", count).Msg("audit log is intact")
+}
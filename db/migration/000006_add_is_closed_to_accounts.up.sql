@@ -0,0 +1 @@
+ALTER TABLE "accounts" ADD COLUMN "is_closed" bool NOT NULL DEFAULT false;
@@ -0,0 +1 @@
+ALTER TABLE "transfers" DROP COLUMN "reversal_of";
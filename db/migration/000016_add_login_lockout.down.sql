@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS "failed_logins";
+
+ALTER TABLE "users" DROP COLUMN "locked_until";
+
+ALTER TABLE "users" DROP COLUMN "lockout_count";
+
+ALTER TABLE "users" DROP COLUMN "failed_login_attempts";
@@ -0,0 +1,20 @@
+ALTER TABLE "users" ADD COLUMN "failed_login_attempts" int NOT NULL DEFAULT 0;
+
+ALTER TABLE "users" ADD COLUMN "lockout_count" int NOT NULL DEFAULT 0;
+
+ALTER TABLE "users" ADD COLUMN "locked_until" timestamptz;
+
+CREATE TABLE "failed_logins" (
+  "id" bigserial PRIMARY KEY,
+  "username" varchar NOT NULL,
+  "client_ip" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "failed_logins" ("username", "created_at");
+
+CREATE INDEX ON "failed_logins" ("client_ip", "created_at");
+
+COMMENT ON COLUMN "users"."failed_login_attempts" IS 'consecutive failed logins since the last lockout';
+
+COMMENT ON COLUMN "users"."lockout_count" IS 'consecutive lockouts, used for exponential backoff';
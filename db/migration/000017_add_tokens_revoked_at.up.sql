@@ -0,0 +1,3 @@
+ALTER TABLE "users" ADD COLUMN "tokens_revoked_at" timestamptz NOT NULL DEFAULT '0001-01-01';
+
+COMMENT ON COLUMN "users"."tokens_revoked_at" IS 'access tokens issued before this time are rejected';
@@ -0,0 +1,56 @@
+CREATE TABLE "roles" (
+  "name" varchar PRIMARY KEY,
+  "description" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "role_permissions" (
+  "role" varchar NOT NULL,
+  "permission" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("role", "permission")
+);
+
+COMMENT ON COLUMN "role_permissions"."permission" IS '<resource>:<action>:<own|any>';
+
+ALTER TABLE "role_permissions" ADD FOREIGN KEY ("role") REFERENCES "roles" ("name");
+
+INSERT INTO "roles" ("name", "description") VALUES
+  ('depositor', 'Customer managing their own accounts'),
+  ('banker', 'Bank employee managing the accounts of every customer');
+
+INSERT INTO "role_permissions" ("role", "permission") VALUES
+  ('depositor', 'users:update:own'),
+  ('depositor', 'accounts:create:own'),
+  ('depositor', 'accounts:read:own'),
+  ('depositor', 'accounts:close:own'),
+  ('depositor', 'statements:send:own'),
+  ('depositor', 'transfers:create:own'),
+  ('depositor', 'scheduled_transfers:create:own'),
+  ('depositor', 'scheduled_transfers:cancel:own'),
+  ('depositor', 'holds:create:own'),
+  ('depositor', 'holds:capture:own'),
+  ('depositor', 'holds:void:own'),
+  ('depositor', 'mfa:manage:own'),
+  ('depositor', 'sessions:read:own'),
+  ('depositor', 'sessions:revoke:own'),
+  ('banker', 'users:update:any'),
+  ('banker', 'users:unlock:any'),
+  ('banker', 'roles:assign:any'),
+  ('banker', 'accounts:create:any'),
+  ('banker', 'accounts:read:any'),
+  ('banker', 'accounts:close:any'),
+  ('banker', 'accounts:update_overdraft:any'),
+  ('banker', 'statements:send:any'),
+  ('banker', 'transfers:create:own'),
+  ('banker', 'transfers:reverse:any'),
+  ('banker', 'scheduled_transfers:create:own'),
+  ('banker', 'scheduled_transfers:cancel:any'),
+  ('banker', 'holds:create:own'),
+  ('banker', 'holds:capture:any'),
+  ('banker', 'holds:void:any'),
+  ('banker', 'mfa:manage:own'),
+  ('banker', 'sessions:read:own'),
+  ('banker', 'sessions:revoke:own');
+
+ALTER TABLE "users" ADD FOREIGN KEY ("role") REFERENCES "roles" ("name");
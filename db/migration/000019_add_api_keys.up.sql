@@ -0,0 +1,21 @@
+CREATE TABLE "api_keys" (
+  "id" uuid PRIMARY KEY,
+  "username" varchar NOT NULL,
+  "name" varchar NOT NULL,
+  "hashed_key" varchar NOT NULL,
+  "scopes" varchar[] NOT NULL,
+  "expires_at" timestamptz NOT NULL,
+  "last_used_at" timestamptz,
+  "revoked_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX ON "api_keys" ("username", "name");
+
+COMMENT ON COLUMN "api_keys"."scopes" IS 'permissions granted to the key, limited by the role of the user';
+
+ALTER TABLE "api_keys" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+INSERT INTO "role_permissions" ("role", "permission") VALUES
+  ('depositor', 'api_keys:manage:own'),
+  ('banker', 'api_keys:manage:own');
@@ -0,0 +1,7 @@
+DELETE FROM "role_permissions" WHERE "permission" = 'oauth_clients:manage:own';
+
+DROP TABLE IF EXISTS "oauth_tokens";
+
+DROP TABLE IF EXISTS "oauth_authorization_codes";
+
+DROP TABLE IF EXISTS "oauth_clients";
@@ -0,0 +1,36 @@
+CREATE TABLE "audit_events" (
+  "id" bigserial PRIMARY KEY,
+  "actor" varchar NOT NULL,
+  "action" varchar NOT NULL,
+  "target" varchar NOT NULL,
+  "details" json NOT NULL DEFAULT '{}',
+  "client_ip" varchar NOT NULL DEFAULT '',
+  "user_agent" varchar NOT NULL DEFAULT '',
+  "request_id" varchar NOT NULL DEFAULT '',
+  "prev_hash" varchar NOT NULL,
+  "hash" varchar UNIQUE NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "audit_events" ("actor");
+
+CREATE INDEX ON "audit_events" ("target");
+
+COMMENT ON COLUMN "audit_events"."details" IS 'stored as json rather than jsonb so that the hashed text is kept as is';
+
+COMMENT ON COLUMN "audit_events"."prev_hash" IS 'hash of the previous event, zeros for the first one';
+
+COMMENT ON COLUMN "audit_events"."hash" IS 'sha256 of prev_hash and the fields of the event';
+
+CREATE FUNCTION audit_events_append_only() RETURNS trigger AS $$
+BEGIN
+  RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_events_append_only
+BEFORE UPDATE OR DELETE OR TRUNCATE ON "audit_events"
+FOR EACH STATEMENT EXECUTE FUNCTION audit_events_append_only();
+
+INSERT INTO "role_permissions" ("role", "permission") VALUES
+  ('banker', 'audit:read:any');
@@ -0,0 +1,20 @@
+CREATE TABLE "outbox" (
+  "id" bigserial PRIMARY KEY,
+  "task_type" varchar NOT NULL,
+  "payload" bytea NOT NULL,
+  "queue" varchar NOT NULL,
+  "max_retry" int NOT NULL,
+  "process_at" timestamptz NOT NULL,
+  "attempts" int NOT NULL DEFAULT 0,
+  "last_error" varchar,
+  "sent_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "outbox" ("id") WHERE "sent_at" IS NULL;
+
+COMMENT ON COLUMN "outbox"."payload" IS 'task payload, enqueued as is';
+
+COMMENT ON COLUMN "outbox"."process_at" IS 'the task is not processed before this time';
+
+COMMENT ON COLUMN "outbox"."sent_at" IS 'set once the task is enqueued, pending until then';
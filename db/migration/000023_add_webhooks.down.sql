@@ -0,0 +1,5 @@
+DELETE FROM "role_permissions" WHERE "permission" = 'webhooks:manage:own';
+
+DROP TABLE IF EXISTS "webhook_deliveries";
+
+DROP TABLE IF EXISTS "webhook_endpoints";
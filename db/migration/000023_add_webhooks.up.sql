@@ -0,0 +1,45 @@
+CREATE TABLE "webhook_endpoints" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "url" varchar NOT NULL,
+  "secret" varchar NOT NULL,
+  "event_types" varchar[] NOT NULL,
+  "disabled_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "webhook_deliveries" (
+  "id" bigserial PRIMARY KEY,
+  "endpoint_id" bigint NOT NULL,
+  "event_id" uuid NOT NULL,
+  "event_type" varchar NOT NULL,
+  "payload" json NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "attempts" int NOT NULL DEFAULT 0,
+  "response_status" int,
+  "last_error" varchar,
+  "delivered_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "webhook_endpoints" ("owner");
+
+CREATE INDEX ON "webhook_deliveries" ("endpoint_id", "id");
+
+COMMENT ON COLUMN "webhook_endpoints"."secret" IS 'signs the deliveries, it cannot be hashed as it is needed to sign';
+
+COMMENT ON COLUMN "webhook_endpoints"."event_types" IS 'events delivered to the endpoint';
+
+COMMENT ON COLUMN "webhook_deliveries"."event_id" IS 'same for the deliveries of an event and its replays';
+
+COMMENT ON COLUMN "webhook_deliveries"."payload" IS 'stored as json rather than jsonb so that the signed body is kept as is';
+
+COMMENT ON COLUMN "webhook_deliveries"."status" IS 'pending, succeeded or failed';
+
+ALTER TABLE "webhook_endpoints" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "webhook_deliveries" ADD FOREIGN KEY ("endpoint_id") REFERENCES "webhook_endpoints" ("id");
+
+INSERT INTO "role_permissions" ("role", "permission") VALUES
+  ('depositor', 'webhooks:manage:own'),
+  ('banker', 'webhooks:manage:own');
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS "outbox_id_idx";
+
+ALTER TABLE "outbox" DROP COLUMN IF EXISTS "failed_at";
+
+ALTER TABLE "outbox" DROP COLUMN IF EXISTS "retry_at";
+
+CREATE INDEX ON "outbox" ("id") WHERE "sent_at" IS NULL;
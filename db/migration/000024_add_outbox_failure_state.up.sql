@@ -0,0 +1,11 @@
+ALTER TABLE "outbox" ADD COLUMN "retry_at" timestamptz;
+
+ALTER TABLE "outbox" ADD COLUMN "failed_at" timestamptz;
+
+DROP INDEX IF EXISTS "outbox_id_idx";
+
+CREATE INDEX ON "outbox" ("id") WHERE "sent_at" IS NULL AND "failed_at" IS NULL;
+
+COMMENT ON COLUMN "outbox"."retry_at" IS 'a message that failed to be enqueued is not published again before this time';
+
+COMMENT ON COLUMN "outbox"."failed_at" IS 'set once the task failed to be enqueued too many times, it is not published again';
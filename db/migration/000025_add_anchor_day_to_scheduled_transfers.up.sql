@@ -0,0 +1,7 @@
+ALTER TABLE "scheduled_transfers" ADD COLUMN "anchor_day" int;
+
+UPDATE "scheduled_transfers" SET "anchor_day" = EXTRACT(DAY FROM "next_run_at" AT TIME ZONE 'UTC');
+
+ALTER TABLE "scheduled_transfers" ALTER COLUMN "anchor_day" SET NOT NULL;
+
+COMMENT ON COLUMN "scheduled_transfers"."anchor_day" IS 'day of the month of monthly runs in UTC, clamped to the last day of shorter months';
@@ -32,3 +32,27 @@ RETURNING *;
 -- name: DeleteAccount :exec
 DELETE FROM accounts
 WHERE id = $1;
+-- name: ListAllAccounts :many
+SELECT *
+FROM accounts
+ORDER BY id
+LIMIT $1 OFFSET $2;
+-- name: CloseAccount :one
+-- Only accounts with a zero balance and no pending holds are closed, checking
+-- both in the update keeps concurrent transfers and holds from slipping in
+UPDATE accounts
+SET is_closed = TRUE
+WHERE id = $1
+  AND balance = 0
+  AND held_amount = 0
+RETURNING *;
+-- name: UpdateAccountOverdraftLimit :one
+UPDATE accounts
+SET overdraft_limit = sqlc.arg(overdraft_limit)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+-- name: AddAccountHeldAmount :one
+UPDATE accounts
+SET held_amount = held_amount + sqlc.arg(amount)
+WHERE id = sqlc.arg(id)
+RETURNING *;
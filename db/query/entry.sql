@@ -13,3 +13,15 @@ FROM entries
 WHERE account_id = $1
 ORDER BY id
 LIMIT $2 OFFSET $3;
+-- name: ListEntriesBetween :many
+SELECT *
+FROM entries
+WHERE account_id = sqlc.arg(account_id)
+    AND created_at >= sqlc.arg(from_time)
+    AND created_at < sqlc.arg(to_time)
+ORDER BY id;
+-- name: GetEntriesTotalSince :one
+SELECT COALESCE(SUM(amount), 0)::bigint AS total
+FROM entries
+WHERE account_id = sqlc.arg(account_id)
+    AND created_at >= sqlc.arg(since);
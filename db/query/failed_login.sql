@@ -0,0 +1,12 @@
+-- name: CreateFailedLogin :one
+INSERT INTO failed_logins (
+  username,
+  client_ip
+) VALUES (
+  $1, $2
+) RETURNING *;
+
+-- name: CountFailedLoginsByClientIP :one
+SELECT count(*) FROM failed_logins
+WHERE client_ip = $1
+  AND created_at > sqlc.arg(since);
@@ -0,0 +1,33 @@
+-- name: CreateHold :one
+INSERT INTO holds (
+  account_id,
+  to_account_id,
+  amount,
+  expires_at
+) VALUES (
+  $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetHold :one
+SELECT * FROM holds
+WHERE id = $1 LIMIT 1;
+
+-- name: GetHoldForUpdate :one
+SELECT * FROM holds
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: UpdateHoldStatus :one
+UPDATE holds
+SET
+  status = sqlc.arg(status),
+  transfer_id = COALESCE(sqlc.narg(transfer_id), transfer_id)
+WHERE
+  id = sqlc.arg(id)
+RETURNING *;
+
+-- name: ListHolds :many
+SELECT * FROM holds
+WHERE account_id = $1
+ORDER BY id
+LIMIT $2 OFFSET $3;
@@ -0,0 +1,24 @@
+-- name: CreateIdempotencyKey :one
+INSERT INTO idempotency_keys (
+  username,
+  key,
+  from_account_id,
+  to_account_id,
+  amount
+) VALUES (
+  $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetIdempotencyKey :one
+SELECT * FROM idempotency_keys
+WHERE username = $1 AND key = $2 LIMIT 1;
+
+-- name: UpdateIdempotencyKeyResult :one
+UPDATE idempotency_keys
+SET
+  transfer_id = sqlc.arg(transfer_id),
+  result = sqlc.arg(result)
+WHERE
+  username = sqlc.arg(username)
+  AND key = sqlc.arg(key)
+RETURNING *;
@@ -0,0 +1,55 @@
+-- name: CreateOAuthClient :one
+INSERT INTO oauth_clients (
+  id,
+  owner,
+  name,
+  hashed_secret,
+  redirect_uris,
+  scopes
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetOAuthClient :one
+SELECT * FROM oauth_clients
+WHERE id = $1 LIMIT 1;
+
+-- name: CreateOAuthAuthorizationCode :one
+INSERT INTO oauth_authorization_codes (
+  hashed_code,
+  client_id,
+  username,
+  redirect_uri,
+  scopes,
+  code_challenge,
+  expires_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7
+) RETURNING *;
+
+-- name: ConsumeOAuthAuthorizationCode :one
+DELETE FROM oauth_authorization_codes
+WHERE hashed_code = $1
+RETURNING *;
+
+-- name: CreateOAuthToken :one
+INSERT INTO oauth_tokens (
+  id,
+  client_id,
+  username,
+  scopes,
+  expires_at
+) VALUES (
+  $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetOAuthToken :one
+SELECT * FROM oauth_tokens
+WHERE id = $1 LIMIT 1;
+
+-- name: RevokeOAuthToken :execrows
+UPDATE oauth_tokens
+SET revoked_at = now()
+WHERE id = $1
+  AND client_id = $2
+  AND revoked_at IS NULL;
@@ -0,0 +1,34 @@
+-- name: CreateOutboxMessage :one
+INSERT INTO outbox (
+  task_type,
+  payload,
+  queue,
+  max_retry,
+  process_at
+) VALUES (
+  $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: ListPendingOutboxMessagesForUpdate :many
+SELECT * FROM outbox
+WHERE sent_at IS NULL
+  AND failed_at IS NULL
+  AND (retry_at IS NULL OR retry_at <= now())
+ORDER BY id
+LIMIT $1
+FOR UPDATE SKIP LOCKED;
+
+-- name: MarkOutboxMessageSent :exec
+UPDATE outbox
+SET sent_at = now()
+WHERE id = $1;
+
+-- name: RecordOutboxMessageFailure :one
+UPDATE outbox
+SET
+  attempts = attempts + 1,
+  last_error = sqlc.arg(last_error)::varchar,
+  retry_at = sqlc.arg(retry_at),
+  failed_at = CASE WHEN sqlc.arg(failed)::bool THEN now() END
+WHERE id = sqlc.arg(id)
+RETURNING *;
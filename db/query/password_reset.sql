@@ -0,0 +1,29 @@
+-- name: CreatePasswordReset :one
+INSERT INTO password_resets (
+  username,
+  email,
+  secret_code
+) VALUES (
+  $1, $2, $3
+) RETURNING *;
+
+-- name: GetPasswordReset :one
+SELECT * FROM password_resets
+WHERE id = $1 LIMIT 1;
+
+-- name: GetLatestPasswordReset :one
+SELECT * FROM password_resets
+WHERE username = $1
+ORDER BY id DESC
+LIMIT 1;
+
+-- name: UpdatePasswordReset :one
+UPDATE password_resets
+SET
+	is_used = TRUE
+WHERE
+	id = @id
+	AND secret_code = @secret_code
+	AND is_used = FALSE
+	AND expired_at > now()
+RETURNING *;
@@ -0,0 +1,8 @@
+-- name: GetRole :one
+SELECT * FROM roles
+WHERE name = $1 LIMIT 1;
+
+-- name: ListRolePermissions :many
+SELECT permission FROM role_permissions
+WHERE role = $1
+ORDER BY permission;
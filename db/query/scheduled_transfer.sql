@@ -0,0 +1,46 @@
+-- name: CreateScheduledTransfer :one
+INSERT INTO scheduled_transfers (
+  owner,
+  from_account_id,
+  to_account_id,
+  amount,
+  frequency,
+  next_run_at,
+  anchor_day
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7
+) RETURNING *;
+
+-- name: GetScheduledTransfer :one
+SELECT * FROM scheduled_transfers
+WHERE id = $1 LIMIT 1;
+
+-- name: GetScheduledTransferForUpdate :one
+SELECT * FROM scheduled_transfers
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListDueScheduledTransfers :many
+SELECT * FROM scheduled_transfers
+WHERE status = 'active' AND next_run_at <= sqlc.arg(now)
+ORDER BY next_run_at
+LIMIT sqlc.arg(limit_count);
+
+-- name: UpdateScheduledTransferRun :one
+UPDATE scheduled_transfers
+SET
+  status = sqlc.arg(status),
+  next_run_at = sqlc.arg(next_run_at),
+  last_run_at = sqlc.arg(last_run_at),
+  last_transfer_id = sqlc.narg(last_transfer_id),
+  last_error = sqlc.narg(last_error),
+  failure_count = sqlc.arg(failure_count)
+WHERE
+  id = sqlc.arg(id)
+RETURNING *;
+
+-- name: CancelScheduledTransfer :one
+UPDATE scheduled_transfers
+SET status = 'cancelled'
+WHERE id = $1 AND status = 'active'
+RETURNING *;
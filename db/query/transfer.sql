@@ -2,9 +2,11 @@
 INSERT INTO transfers (
         from_account_id,
         to_account_id,
-        amount
+        amount,
+        to_amount,
+        exchange_rate
     )
-VALUES ($1, $2, $3)
+VALUES ($1, $2, $3, $4, $5)
 RETURNING *;
 -- name: GetTransfer :one
 SELECT *
@@ -18,3 +20,35 @@ WHERE from_account_id = $1
     OR to_account_id = $2
 ORDER BY id
 LIMIT $3 OFFSET $4;
+-- name: GetTransferForUpdate :one
+SELECT *
+FROM transfers
+WHERE id = $1
+LIMIT 1 FOR NO KEY
+UPDATE;
+-- name: CreateReversalTransfer :one
+INSERT INTO transfers (
+        from_account_id,
+        to_account_id,
+        amount,
+        to_amount,
+        exchange_rate,
+        reversal_of
+    )
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+-- name: GetReversalTotals :one
+SELECT COALESCE(SUM(to_amount), 0)::bigint AS reversed_amount,
+    COALESCE(SUM(amount), 0)::bigint AS debited_amount
+FROM transfers
+WHERE reversal_of = $1;
+-- name: ListTransfersBetween :many
+SELECT *
+FROM transfers
+WHERE (
+        from_account_id = sqlc.arg(account_id)
+        OR to_account_id = sqlc.arg(account_id)
+    )
+    AND created_at >= sqlc.arg(from_time)
+    AND created_at < sqlc.arg(to_time)
+ORDER BY id;
@@ -12,6 +12,33 @@ INSERT INTO users (
 SELECT * FROM users 
 WHERE username = $1 LIMIT 1;
 
+-- name: GetUserForUpdate :one
+SELECT * FROM users
+WHERE username = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: GetUserTokenState :one
+SELECT password_changed_at, tokens_revoked_at FROM users
+WHERE username = $1 LIMIT 1;
+
+-- name: RevokeUserTokens :one
+UPDATE users
+SET tokens_revoked_at = now()
+WHERE username = $1
+RETURNING *;
+
+-- name: UpdateUserRole :one
+UPDATE users
+SET
+  role = $2,
+  tokens_revoked_at = now()
+WHERE username = $1
+RETURNING *;
+
+-- name: GetUserByEmail :one
+SELECT * FROM users
+WHERE email = $1 LIMIT 1;
+
 -- name: UpdateUser :one
 UPDATE users
 SET
@@ -23,3 +50,57 @@ SET
 WHERE
   username = sqlc.arg(username)
 RETURNING *;
+
+-- name: UpdateUserTOTPSecret :one
+UPDATE users
+SET totp_secret = $2
+WHERE username = $1
+  AND is_totp_enabled = false
+RETURNING *;
+
+-- name: EnableUserTOTP :one
+UPDATE users
+SET is_totp_enabled = true
+WHERE username = $1
+  AND totp_secret IS NOT NULL
+  AND is_totp_enabled = false
+RETURNING *;
+
+-- name: DisableUserTOTP :one
+UPDATE users
+SET
+  totp_secret = NULL,
+  is_totp_enabled = false
+WHERE username = $1
+RETURNING *;
+
+-- name: IncrementFailedLoginAttempts :one
+UPDATE users
+SET failed_login_attempts = failed_login_attempts + 1
+WHERE username = $1
+RETURNING *;
+
+-- name: LockUser :one
+UPDATE users
+SET
+  failed_login_attempts = 0,
+  lockout_count = lockout_count + 1,
+  locked_until = $2
+WHERE username = $1
+RETURNING *;
+
+-- name: ResetFailedLoginAttempts :exec
+UPDATE users
+SET
+  failed_login_attempts = 0,
+  lockout_count = 0
+WHERE username = $1;
+
+-- name: UnlockUser :one
+UPDATE users
+SET
+  failed_login_attempts = 0,
+  lockout_count = 0,
+  locked_until = NULL
+WHERE username = $1
+RETURNING *;
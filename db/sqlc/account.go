@@ -0,0 +1,11 @@
+package db
+
+// AvailableBalance returns the ledger balance minus the amount reserved by pending holds
+func (account Account) AvailableBalance() int64 {
+	return account.Balance - account.HeldAmount
+}
+
+// canSpend reports whether the available balance is still within the overdraft limit
+func (account Account) canSpend() bool {
+	return account.AvailableBalance() >= -account.OverdraftLimit
+}
@@ -13,7 +13,7 @@ const addAccountBalance = `-- name: AddAccountBalance :one
 UPDATE accounts
 SET balance = balance + $1
 WHERE id = $2
-RETURNING id, owner, balance, currency, create_at
+RETURNING id, owner, balance, currency, create_at, is_closed, overdraft_limit, held_amount
 `
 
 type AddAccountBalanceParams struct {
@@ -30,6 +30,64 @@ func (q *Queries) AddAccountBalance(ctx context.Context, arg AddAccountBalancePa
 		&i.Balance,
 		&i.Currency,
 		&i.CreateAt,
+		&i.IsClosed,
+		&i.OverdraftLimit,
+		&i.HeldAmount,
+	)
+	return i, err
+}
+
+const addAccountHeldAmount = `-- name: AddAccountHeldAmount :one
+UPDATE accounts
+SET held_amount = held_amount + $1
+WHERE id = $2
+RETURNING id, owner, balance, currency, create_at, is_closed, overdraft_limit, held_amount
+`
+
+type AddAccountHeldAmountParams struct {
+	Amount int64 `json:"amount"`
+	ID     int64 `json:"id"`
+}
+
+func (q *Queries) AddAccountHeldAmount(ctx context.Context, arg AddAccountHeldAmountParams) (Account, error) {
+	row := q.db.QueryRow(ctx, addAccountHeldAmount, arg.Amount, arg.ID)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreateAt,
+		&i.IsClosed,
+		&i.OverdraftLimit,
+		&i.HeldAmount,
+	)
+	return i, err
+}
+
+const closeAccount = `-- name: CloseAccount :one
+UPDATE accounts
+SET is_closed = TRUE
+WHERE id = $1
+  AND balance = 0
+  AND held_amount = 0
+RETURNING id, owner, balance, currency, create_at, is_closed, overdraft_limit, held_amount
+`
+
+// Only accounts with a zero balance and no pending holds are closed, checking
+// both in the update keeps concurrent transfers and holds from slipping in
+func (q *Queries) CloseAccount(ctx context.Context, id int64) (Account, error) {
+	row := q.db.QueryRow(ctx, closeAccount, id)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreateAt,
+		&i.IsClosed,
+		&i.OverdraftLimit,
+		&i.HeldAmount,
 	)
 	return i, err
 }
@@ -37,7 +95,7 @@ func (q *Queries) AddAccountBalance(ctx context.Context, arg AddAccountBalancePa
 const createAccount = `-- name: CreateAccount :one
 INSERT INTO accounts (owner, balance, currency)
 VALUES ($1, $2, $3)
-RETURNING id, owner, balance, currency, create_at
+RETURNING id, owner, balance, currency, create_at, is_closed, overdraft_limit, held_amount
 `
 
 type CreateAccountParams struct {
@@ -55,6 +113,9 @@ func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (A
 		&i.Balance,
 		&i.Currency,
 		&i.CreateAt,
+		&i.IsClosed,
+		&i.OverdraftLimit,
+		&i.HeldAmount,
 	)
 	return i, err
 }
@@ -70,7 +131,7 @@ func (q *Queries) DeleteAccount(ctx context.Context, id int64) error {
 }
 
 const getAccount = `-- name: GetAccount :one
-SELECT id, owner, balance, currency, create_at
+SELECT id, owner, balance, currency, create_at, is_closed, overdraft_limit, held_amount
 FROM accounts
 WHERE id = $1
 LIMIT 1
@@ -85,12 +146,15 @@ func (q *Queries) GetAccount(ctx context.Context, id int64) (Account, error) {
 		&i.Balance,
 		&i.Currency,
 		&i.CreateAt,
+		&i.IsClosed,
+		&i.OverdraftLimit,
+		&i.HeldAmount,
 	)
 	return i, err
 }
 
 const getAccountForUpdate = `-- name: GetAccountForUpdate :one
-SELECT id, owner, balance, currency, create_at
+SELECT id, owner, balance, currency, create_at, is_closed, overdraft_limit, held_amount
 FROM accounts
 WHERE id = $1
 LIMIT 1 FOR NO KEY
@@ -106,12 +170,15 @@ func (q *Queries) GetAccountForUpdate(ctx context.Context, id int64) (Account, e
 		&i.Balance,
 		&i.Currency,
 		&i.CreateAt,
+		&i.IsClosed,
+		&i.OverdraftLimit,
+		&i.HeldAmount,
 	)
 	return i, err
 }
 
 const listAccounts = `-- name: ListAccounts :many
-SELECT id, owner, balance, currency, create_at
+SELECT id, owner, balance, currency, create_at, is_closed, overdraft_limit, held_amount
 FROM accounts
 WHERE owner = $1
 ORDER BY id
@@ -139,6 +206,50 @@ func (q *Queries) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]A
 			&i.Balance,
 			&i.Currency,
 			&i.CreateAt,
+			&i.IsClosed,
+			&i.OverdraftLimit,
+			&i.HeldAmount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAllAccounts = `-- name: ListAllAccounts :many
+SELECT id, owner, balance, currency, create_at, is_closed, overdraft_limit, held_amount
+FROM accounts
+ORDER BY id
+LIMIT $1 OFFSET $2
+`
+
+type ListAllAccountsParams struct {
+	Limit  int32 `json:"limit"`
+	Offset int32 `json:"offset"`
+}
+
+func (q *Queries) ListAllAccounts(ctx context.Context, arg ListAllAccountsParams) ([]Account, error) {
+	rows, err := q.db.Query(ctx, listAllAccounts, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Account{}
+	for rows.Next() {
+		var i Account
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.Balance,
+			&i.Currency,
+			&i.CreateAt,
+			&i.IsClosed,
+			&i.OverdraftLimit,
+			&i.HeldAmount,
 		); err != nil {
 			return nil, err
 		}
@@ -154,7 +265,7 @@ const updateAccount = `-- name: UpdateAccount :one
 UPDATE accounts
 SET balance = $2
 WHERE id = $1
-RETURNING id, owner, balance, currency, create_at
+RETURNING id, owner, balance, currency, create_at, is_closed, overdraft_limit, held_amount
 `
 
 type UpdateAccountParams struct {
@@ -171,6 +282,37 @@ func (q *Queries) UpdateAccount(ctx context.Context, arg UpdateAccountParams) (A
 		&i.Balance,
 		&i.Currency,
 		&i.CreateAt,
+		&i.IsClosed,
+		&i.OverdraftLimit,
+		&i.HeldAmount,
+	)
+	return i, err
+}
+
+const updateAccountOverdraftLimit = `-- name: UpdateAccountOverdraftLimit :one
+UPDATE accounts
+SET overdraft_limit = $1
+WHERE id = $2
+RETURNING id, owner, balance, currency, create_at, is_closed, overdraft_limit, held_amount
+`
+
+type UpdateAccountOverdraftLimitParams struct {
+	OverdraftLimit int64 `json:"overdraft_limit"`
+	ID             int64 `json:"id"`
+}
+
+func (q *Queries) UpdateAccountOverdraftLimit(ctx context.Context, arg UpdateAccountOverdraftLimitParams) (Account, error) {
+	row := q.db.QueryRow(ctx, updateAccountOverdraftLimit, arg.OverdraftLimit, arg.ID)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreateAt,
+		&i.IsClosed,
+		&i.OverdraftLimit,
+		&i.HeldAmount,
 	)
 	return i, err
 }
@@ -16,7 +16,7 @@ func createRandomAccount(t *testing.T) Account {
 
 	arg := CreateAccountParams{
 		Owner:    user.Username,
-		Balance:  util.RandomMoney(),
+		Balance:  util.RandomInt(100, 1000),
 		Currency: util.RandomCurrency(),
 	}
 
@@ -101,3 +101,72 @@ func TestListAccounts(t *testing.T) {
 		require.NotEmpty(t, account)
 	}
 }
+
+func TestListAllAccounts(t *testing.T) {
+	for range 5 {
+		createRandomAccount(t)
+	}
+
+	arg := ListAllAccountsParams{
+		Limit:  5,
+		Offset: 0,
+	}
+
+	accounts, err := testStore.ListAllAccounts(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, accounts, 5)
+
+	for _, account := range accounts {
+		require.NotEmpty(t, account)
+	}
+}
+
+// closeRandomAccount empties the account and closes it
+func closeRandomAccount(t *testing.T, account Account) Account {
+	_, err := testStore.UpdateAccount(context.Background(), UpdateAccountParams{
+		ID:      account.ID,
+		Balance: 0,
+	})
+	require.NoError(t, err)
+
+	closedAccount, err := testStore.CloseAccount(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.True(t, closedAccount.IsClosed)
+
+	return closedAccount
+}
+
+func TestCloseAccount(t *testing.T) {
+	account1 := createRandomAccount(t)
+	require.False(t, account1.IsClosed)
+
+	// an account with money left is not closed
+	_, err := testStore.CloseAccount(context.Background(), account1.ID)
+	require.ErrorIs(t, err, ErrRecordNotFound)
+
+	account2 := closeRandomAccount(t, account1)
+	require.NotEmpty(t, account2)
+
+	require.Equal(t, account1.ID, account2.ID)
+	require.Zero(t, account2.Balance)
+	require.True(t, account2.IsClosed)
+}
+
+func TestUpdateAccountOverdraftLimit(t *testing.T) {
+	account1 := createRandomAccount(t)
+	require.Zero(t, account1.OverdraftLimit)
+
+	arg := UpdateAccountOverdraftLimitParams{
+		ID:             account1.ID,
+		OverdraftLimit: util.RandomMoney() + 1,
+	}
+
+	account2, err := testStore.UpdateAccountOverdraftLimit(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, account1.ID, account2.ID)
+	require.Equal(t, arg.OverdraftLimit, account2.OverdraftLimit)
+
+	arg.OverdraftLimit = -1
+	_, err = testStore.UpdateAccountOverdraftLimit(context.Background(), arg)
+	require.Error(t, err)
+}
@@ -0,0 +1,91 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/yelaco/simple-bank/util"
+)
+
+func createRandomAPIKey(t *testing.T, user User) APIKey {
+	arg := CreateAPIKeyParams{
+		ID:        uuid.New(),
+		Username:  user.Username,
+		Name:      util.RandomString(8),
+		HashedKey: util.RandomString(64),
+		Scopes:    []string{"accounts:read:own"},
+		ExpiresAt: time.Now().Add(24 * time.Hour),
+	}
+
+	apiKey, err := testStore.CreateAPIKey(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.ID, apiKey.ID)
+	require.Equal(t, arg.Username, apiKey.Username)
+	require.Equal(t, arg.Name, apiKey.Name)
+	require.Equal(t, arg.HashedKey, apiKey.HashedKey)
+	require.Equal(t, arg.Scopes, apiKey.Scopes)
+	require.WithinDuration(t, arg.ExpiresAt, apiKey.ExpiresAt, time.Second)
+	require.False(t, apiKey.LastUsedAt.Valid)
+	require.False(t, apiKey.RevokedAt.Valid)
+
+	return apiKey
+}
+
+func TestCreateAPIKey(t *testing.T) {
+	user := createRandomUser(t)
+	apiKey := createRandomAPIKey(t, user)
+
+	_, err := testStore.CreateAPIKey(context.Background(), CreateAPIKeyParams{
+		ID:        uuid.New(),
+		Username:  user.Username,
+		Name:      apiKey.Name,
+		HashedKey: util.RandomString(64),
+		Scopes:    apiKey.Scopes,
+		ExpiresAt: apiKey.ExpiresAt,
+	})
+	require.Equal(t, UniqueViolation, ErrorCode(err))
+}
+
+func TestGetAPIKey(t *testing.T) {
+	user := createRandomUser(t)
+	apiKey := createRandomAPIKey(t, user)
+
+	row, err := testStore.GetAPIKey(context.Background(), apiKey.ID)
+	require.NoError(t, err)
+	require.Equal(t, apiKey.ID, row.APIKey.ID)
+	require.Equal(t, user.Role, row.Role)
+
+	err = testStore.TouchAPIKey(context.Background(), apiKey.ID)
+	require.NoError(t, err)
+
+	row, err = testStore.GetAPIKey(context.Background(), apiKey.ID)
+	require.NoError(t, err)
+	require.True(t, row.APIKey.LastUsedAt.Valid)
+}
+
+func TestRevokeAPIKey(t *testing.T) {
+	user := createRandomUser(t)
+	apiKey1 := createRandomAPIKey(t, user)
+	apiKey2 := createRandomAPIKey(t, user)
+
+	_, err := testStore.RevokeAPIKey(context.Background(), RevokeAPIKeyParams{
+		ID:       apiKey1.ID,
+		Username: util.RandomOwner(),
+	})
+	require.ErrorIs(t, err, ErrRecordNotFound)
+
+	revoked, err := testStore.RevokeAPIKey(context.Background(), RevokeAPIKeyParams{
+		ID:       apiKey1.ID,
+		Username: user.Username,
+	})
+	require.NoError(t, err)
+	require.True(t, revoked.RevokedAt.Valid)
+
+	apiKeys, err := testStore.ListAPIKeys(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Len(t, apiKeys, 1)
+	require.Equal(t, apiKey2.ID, apiKeys[0].ID)
+}
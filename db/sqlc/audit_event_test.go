@@ -0,0 +1,112 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	"github.com/yelaco/simple-bank/util"
+)
+
+func createRandomAuditEvent(t *testing.T) AuditEvent {
+	arg := CreateAuditEventTxParams{
+		AuditContext: AuditContext{
+			Actor:     util.RandomOwner(),
+			ClientIP:  "127.0.0.1",
+			UserAgent: util.RandomString(10),
+			RequestID: util.RandomString(16),
+		},
+		Action:  AuditActionUserUpdate,
+		Target:  AuditTarget("user", util.RandomOwner()),
+		Details: map[string]any{"fields": []string{"email"}},
+	}
+
+	event, err := testStore.CreateAuditEventTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, event.ID)
+	require.Equal(t, arg.Actor, event.Actor)
+	require.Equal(t, arg.Action, event.Action)
+	require.Equal(t, arg.Target, event.Target)
+	require.JSONEq(t, `{"fields":["email"]}`, string(event.Details))
+	require.Equal(t, arg.ClientIP, event.ClientIp)
+	require.Equal(t, arg.UserAgent, event.UserAgent)
+	require.Equal(t, arg.RequestID, event.RequestID)
+	require.Len(t, event.Hash, len(AuditGenesisHash))
+	require.WithinDuration(t, time.Now(), event.CreatedAt, time.Second)
+
+	return event
+}
+
+func TestCreateAuditEventTx(t *testing.T) {
+	event1 := createRandomAuditEvent(t)
+	event2 := createRandomAuditEvent(t)
+
+	events, err := testStore.ListAuditEventsAfter(context.Background(), ListAuditEventsAfterParams{
+		ID:    event1.ID - 1,
+		Limit: 1,
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, event1.Hash, events[0].Hash)
+
+	// Events read back must hash to the same value as when they were written
+	require.Equal(t, event2.Hash, event2.ComputeHash())
+	require.Greater(t, event2.ID, event1.ID)
+}
+
+func TestAuditEventsChainConcurrently(t *testing.T) {
+	n := 10
+	errs := make(chan error)
+
+	for range n {
+		go func() {
+			_, err := testStore.CreateAuditEventTx(context.Background(), CreateAuditEventTxParams{
+				AuditContext: AuditContext{Actor: util.RandomOwner()},
+				Action:       AuditActionLogin,
+				Target:       AuditTarget("user", util.RandomOwner()),
+			})
+			errs <- err
+		}()
+	}
+
+	for range n {
+		require.NoError(t, <-errs)
+	}
+
+	count, err := VerifyAuditLog(context.Background(), testStore, 5)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, count, int64(n))
+}
+
+func TestListAuditEvents(t *testing.T) {
+	event := createRandomAuditEvent(t)
+
+	events, err := testStore.ListAuditEvents(context.Background(), ListAuditEventsParams{
+		Actor:  pgtype.Text{String: event.Actor, Valid: true},
+		Limit:  5,
+		Offset: 0,
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, event.ID, events[0].ID)
+	require.Equal(t, event.Hash, events[0].Hash)
+}
+
+func TestAuditEventVerify(t *testing.T) {
+	event := createRandomAuditEvent(t)
+	require.NoError(t, event.Verify(event.PrevHash))
+
+	require.ErrorIs(t, event.Verify(util.RandomString(64)), ErrAuditChainBroken)
+
+	tampered := event
+	tampered.Target = AuditTarget("user", util.RandomOwner())
+	require.ErrorIs(t, tampered.Verify(event.PrevHash), ErrAuditHashMismatch)
+
+	// Moving bytes from one field to the next must change the hash
+	tampered = event
+	tampered.Actor = event.Actor + event.Action[:1]
+	tampered.Action = event.Action[1:]
+	require.ErrorIs(t, tampered.Verify(event.PrevHash), ErrAuditHashMismatch)
+}
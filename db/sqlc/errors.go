@@ -14,6 +14,13 @@ const (
 
 var ErrRecordNotFound = pgx.ErrNoRows
 
+// ErrInsufficientFunds is returned when a transfer or a hold would push the
+// available balance of the source account below its overdraft limit
+var ErrInsufficientFunds = errors.New("insufficient funds")
+
+// ErrAccountClosed is returned when money would be moved from or to a closed account
+var ErrAccountClosed = errors.New("account is closed")
+
 var ErrUniqueViolation = &pgconn.PgError{
 	Code: UniqueViolation,
 }
@@ -25,3 +32,12 @@ func ErrorCode(err error) string {
 	}
 	return ""
 }
+
+// ConstraintName returns the name of the constraint violated by a query, if any
+func ConstraintName(err error) string {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.ConstraintName
+	}
+	return ""
+}
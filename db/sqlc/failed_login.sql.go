@@ -0,0 +1,55 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: failed_login.sql
+
+package db
+
+import (
+	"context"
+	"time"
+)
+
+const countFailedLoginsByClientIP = `-- name: CountFailedLoginsByClientIP :one
+SELECT count(*) FROM failed_logins
+WHERE client_ip = $1
+  AND created_at > $2
+`
+
+type CountFailedLoginsByClientIPParams struct {
+	ClientIp string    `json:"client_ip"`
+	Since    time.Time `json:"since"`
+}
+
+func (q *Queries) CountFailedLoginsByClientIP(ctx context.Context, arg CountFailedLoginsByClientIPParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countFailedLoginsByClientIP, arg.ClientIp, arg.Since)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createFailedLogin = `-- name: CreateFailedLogin :one
+INSERT INTO failed_logins (
+  username,
+  client_ip
+) VALUES (
+  $1, $2
+) RETURNING id, username, client_ip, created_at
+`
+
+type CreateFailedLoginParams struct {
+	Username string `json:"username"`
+	ClientIp string `json:"client_ip"`
+}
+
+func (q *Queries) CreateFailedLogin(ctx context.Context, arg CreateFailedLoginParams) (FailedLogin, error) {
+	row := q.db.QueryRow(ctx, createFailedLogin, arg.Username, arg.ClientIp)
+	var i FailedLogin
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.ClientIp,
+		&i.CreatedAt,
+	)
+	return i, err
+}
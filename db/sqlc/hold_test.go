@@ -0,0 +1,221 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomHold(t *testing.T, account1, account2 Account, amount int64, expiresAt time.Time) HoldTxResult {
+	result, err := testStore.HoldTx(context.Background(), HoldTxParams{
+		AccountID:   account1.ID,
+		ToAccountID: account2.ID,
+		Amount:      amount,
+		ExpiresAt:   expiresAt,
+	})
+	require.NoError(t, err)
+
+	hold := result.Hold
+	require.NotZero(t, hold.ID)
+	require.Equal(t, account1.ID, hold.AccountID)
+	require.Equal(t, account2.ID, hold.ToAccountID)
+	require.Equal(t, amount, hold.Amount)
+	require.Equal(t, HoldStatusPending, hold.Status)
+	require.False(t, hold.TransferID.Valid)
+	require.WithinDuration(t, expiresAt, hold.ExpiresAt, time.Second)
+
+	// the ledger balance is untouched, only the available balance goes down
+	require.Equal(t, account1.Balance, result.Account.Balance)
+	require.Equal(t, account1.HeldAmount+amount, result.Account.HeldAmount)
+	require.Equal(t, account1.AvailableBalance()-amount, result.Account.AvailableBalance())
+
+	return result
+}
+
+func TestHoldTx(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+
+	createRandomHold(t, account1, account2, 10, time.Now().Add(time.Hour))
+}
+
+func TestHoldTxInsufficientFunds(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+
+	_, err := testStore.HoldTx(context.Background(), HoldTxParams{
+		AccountID:   account1.ID,
+		ToAccountID: account2.ID,
+		Amount:      account1.Balance + 1,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	// held money cannot be transferred either
+	createRandomHold(t, account1, account2, account1.Balance, time.Now().Add(time.Hour))
+
+	_, err = testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        1,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+}
+
+func TestCaptureHoldTx(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+
+	holdAmount := int64(50)
+	captureAmount := int64(30)
+	holdResult := createRandomHold(t, account1, account2, holdAmount, time.Now().Add(time.Hour))
+
+	result, err := testStore.CaptureHoldTx(context.Background(), CaptureHoldTxParams{
+		HoldID: holdResult.Hold.ID,
+		Amount: captureAmount,
+		Audit:  AuditContext{Actor: account2.Owner},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, HoldStatusCaptured, result.Hold.Status)
+	require.True(t, result.Hold.TransferID.Valid)
+	require.Equal(t, result.Transfer.ID, result.Hold.TransferID.Int64)
+	require.Equal(t, captureAmount, result.Transfer.Amount)
+	require.Equal(t, -captureAmount, result.FromEntry.Amount)
+	require.Equal(t, captureAmount, result.ToEntry.Amount)
+
+	// the whole hold is released and only the captured amount is moved
+	require.Equal(t, account1.Balance-captureAmount, result.FromAccount.Balance)
+	require.Equal(t, account1.HeldAmount, result.FromAccount.HeldAmount)
+	require.Equal(t, account2.Balance+captureAmount, result.ToAccount.Balance)
+
+	// the captured transfer is in the audit log
+	events, err := testStore.ListAuditEvents(context.Background(), ListAuditEventsParams{
+		Target: pgtype.Text{String: AuditTarget("transfer", result.Transfer.ID), Valid: true},
+		Limit:  5,
+		Offset: 0,
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, AuditActionTransfer, events[0].Action)
+	require.Equal(t, account2.Owner, events[0].Actor)
+
+	_, err = testStore.CaptureHoldTx(context.Background(), CaptureHoldTxParams{
+		HoldID: holdResult.Hold.ID,
+		Amount: captureAmount,
+	})
+	require.ErrorIs(t, err, ErrHoldNotPending)
+}
+
+func TestHoldTxClosedAccount(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+
+	closeRandomAccount(t, account2)
+
+	// money cannot be held for a closed account
+	_, err := testStore.HoldTx(context.Background(), HoldTxParams{
+		AccountID:   account1.ID,
+		ToAccountID: account2.ID,
+		Amount:      1,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+	require.ErrorIs(t, err, ErrAccountClosed)
+
+	// nor held on a closed account
+	_, err = testStore.HoldTx(context.Background(), HoldTxParams{
+		AccountID:   account2.ID,
+		ToAccountID: account1.ID,
+		Amount:      1,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+	require.ErrorIs(t, err, ErrAccountClosed)
+}
+
+func TestCloseAccountWithPendingHold(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+
+	createRandomHold(t, account1, account2, 10, time.Now().Add(time.Hour))
+
+	_, err := testStore.UpdateAccount(context.Background(), UpdateAccountParams{
+		ID:      account1.ID,
+		Balance: 0,
+	})
+	require.NoError(t, err)
+
+	_, err = testStore.CloseAccount(context.Background(), account1.ID)
+	require.ErrorIs(t, err, ErrRecordNotFound)
+}
+
+func TestCaptureHoldTxClosedAccount(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+
+	holdResult := createRandomHold(t, account1, account2, 10, time.Now().Add(time.Hour))
+
+	closeRandomAccount(t, account2)
+
+	_, err := testStore.CaptureHoldTx(context.Background(), CaptureHoldTxParams{
+		HoldID: holdResult.Hold.ID,
+		Amount: 10,
+	})
+	require.ErrorIs(t, err, ErrAccountClosed)
+
+	hold, err := testStore.GetHold(context.Background(), holdResult.Hold.ID)
+	require.NoError(t, err)
+	require.Equal(t, HoldStatusPending, hold.Status)
+}
+
+func TestCaptureHoldTxInvalidAmount(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+
+	holdResult := createRandomHold(t, account1, account2, 10, time.Now().Add(time.Hour))
+
+	_, err := testStore.CaptureHoldTx(context.Background(), CaptureHoldTxParams{
+		HoldID: holdResult.Hold.ID,
+		Amount: 11,
+	})
+	require.ErrorIs(t, err, ErrInvalidCaptureAmount)
+}
+
+func TestVoidHoldTx(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+
+	holdResult := createRandomHold(t, account1, account2, 10, time.Now().Add(time.Hour))
+
+	result, err := testStore.VoidHoldTx(context.Background(), holdResult.Hold.ID)
+	require.NoError(t, err)
+	require.Equal(t, HoldStatusVoided, result.Hold.Status)
+	require.Equal(t, account1.Balance, result.Account.Balance)
+	require.Equal(t, account1.HeldAmount, result.Account.HeldAmount)
+
+	_, err = testStore.VoidHoldTx(context.Background(), holdResult.Hold.ID)
+	require.ErrorIs(t, err, ErrHoldNotPending)
+}
+
+func TestExpireHoldTx(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+
+	pendingHold := createRandomHold(t, account1, account2, 10, time.Now().Add(time.Hour))
+	_, err := testStore.ExpireHoldTx(context.Background(), pendingHold.Hold.ID)
+	require.ErrorIs(t, err, ErrHoldNotExpired)
+
+	expiredHold := createRandomHold(t, account1, account2, 10, time.Now().Add(-time.Minute))
+	_, err = testStore.CaptureHoldTx(context.Background(), CaptureHoldTxParams{
+		HoldID: expiredHold.Hold.ID,
+		Amount: 10,
+	})
+	require.ErrorIs(t, err, ErrHoldExpired)
+
+	result, err := testStore.ExpireHoldTx(context.Background(), expiredHold.Hold.ID)
+	require.NoError(t, err)
+	require.Equal(t, HoldStatusExpired, result.Hold.Status)
+	require.Equal(t, int64(10), result.Account.HeldAmount)
+}
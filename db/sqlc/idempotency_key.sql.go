@@ -0,0 +1,119 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: idempotency_key.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createIdempotencyKey = `-- name: CreateIdempotencyKey :one
+INSERT INTO idempotency_keys (
+  username,
+  key,
+  from_account_id,
+  to_account_id,
+  amount
+) VALUES (
+  $1, $2, $3, $4, $5
+) RETURNING username, key, from_account_id, to_account_id, amount, transfer_id, result, created_at
+`
+
+type CreateIdempotencyKeyParams struct {
+	Username      string `json:"username"`
+	Key           string `json:"key"`
+	FromAccountID int64  `json:"from_account_id"`
+	ToAccountID   int64  `json:"to_account_id"`
+	Amount        int64  `json:"amount"`
+}
+
+func (q *Queries) CreateIdempotencyKey(ctx context.Context, arg CreateIdempotencyKeyParams) (IdempotencyKey, error) {
+	row := q.db.QueryRow(ctx, createIdempotencyKey,
+		arg.Username,
+		arg.Key,
+		arg.FromAccountID,
+		arg.ToAccountID,
+		arg.Amount,
+	)
+	var i IdempotencyKey
+	err := row.Scan(
+		&i.Username,
+		&i.Key,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.TransferID,
+		&i.Result,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getIdempotencyKey = `-- name: GetIdempotencyKey :one
+SELECT username, key, from_account_id, to_account_id, amount, transfer_id, result, created_at FROM idempotency_keys
+WHERE username = $1 AND key = $2 LIMIT 1
+`
+
+type GetIdempotencyKeyParams struct {
+	Username string `json:"username"`
+	Key      string `json:"key"`
+}
+
+func (q *Queries) GetIdempotencyKey(ctx context.Context, arg GetIdempotencyKeyParams) (IdempotencyKey, error) {
+	row := q.db.QueryRow(ctx, getIdempotencyKey, arg.Username, arg.Key)
+	var i IdempotencyKey
+	err := row.Scan(
+		&i.Username,
+		&i.Key,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.TransferID,
+		&i.Result,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const updateIdempotencyKeyResult = `-- name: UpdateIdempotencyKeyResult :one
+UPDATE idempotency_keys
+SET
+  transfer_id = $1,
+  result = $2
+WHERE
+  username = $3
+  AND key = $4
+RETURNING username, key, from_account_id, to_account_id, amount, transfer_id, result, created_at
+`
+
+type UpdateIdempotencyKeyResultParams struct {
+	TransferID pgtype.Int8 `json:"transfer_id"`
+	Result     []byte      `json:"result"`
+	Username   string      `json:"username"`
+	Key        string      `json:"key"`
+}
+
+func (q *Queries) UpdateIdempotencyKeyResult(ctx context.Context, arg UpdateIdempotencyKeyResultParams) (IdempotencyKey, error) {
+	row := q.db.QueryRow(ctx, updateIdempotencyKeyResult,
+		arg.TransferID,
+		arg.Result,
+		arg.Username,
+		arg.Key,
+	)
+	var i IdempotencyKey
+	err := row.Scan(
+		&i.Username,
+		&i.Key,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.TransferID,
+		&i.Result,
+		&i.CreatedAt,
+	)
+	return i, err
+}
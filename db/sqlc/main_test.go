@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"encoding/json"
 	"log"
 	"os"
 	"testing"
@@ -12,6 +13,23 @@ import (
 
 var testStore Store
 
+// testTaskDeliverWebhook stands in for the task of the worker package
+const testTaskDeliverWebhook = "test:deliver_webhook"
+
+func newTestWebhookDeliveryTask(ctx context.Context, delivery WebhookDelivery) (OutboxTask, error) {
+	payload, err := json.Marshal(map[string]int64{"delivery_id": delivery.ID})
+	if err != nil {
+		return OutboxTask{}, err
+	}
+
+	return OutboxTask{
+		TaskType: testTaskDeliverWebhook,
+		Payload:  payload,
+		Queue:    "default",
+		MaxRetry: 10,
+	}, nil
+}
+
 func TestMain(m *testing.M) {
 	config, err := util.LoadConfig("../..")
 	if err != nil {
@@ -23,7 +41,7 @@ func TestMain(m *testing.M) {
 		log.Fatal("cannot create connection pool: ", err)
 	}
 
-	testStore = NewStore(connPool)
+	testStore = NewStore(connPool, newTestWebhookDeliveryTask)
 
 	os.Exit(m.Run())
 }
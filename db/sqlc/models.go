@@ -8,14 +8,48 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type APIKey struct {
+	ID        uuid.UUID `json:"id"`
+	Username  string    `json:"username"`
+	Name      string    `json:"name"`
+	HashedKey string    `json:"hashed_key"`
+	// permissions granted to the key, limited by the role of the user
+	Scopes     []string           `json:"scopes"`
+	ExpiresAt  time.Time          `json:"expires_at"`
+	LastUsedAt pgtype.Timestamptz `json:"last_used_at"`
+	RevokedAt  pgtype.Timestamptz `json:"revoked_at"`
+	CreatedAt  time.Time          `json:"created_at"`
+}
+
 type Account struct {
-	ID       int64     `json:"id"`
-	Owner    string    `json:"owner"`
-	Balance  int64     `json:"balance"`
-	Currency string    `json:"currency"`
-	CreateAt time.Time `json:"create_at"`
+	ID             int64     `json:"id"`
+	Owner          string    `json:"owner"`
+	Balance        int64     `json:"balance"`
+	Currency       string    `json:"currency"`
+	CreateAt       time.Time `json:"create_at"`
+	IsClosed       bool      `json:"is_closed"`
+	OverdraftLimit int64     `json:"overdraft_limit"`
+	HeldAmount     int64     `json:"held_amount"`
+}
+
+type AuditEvent struct {
+	ID     int64  `json:"id"`
+	Actor  string `json:"actor"`
+	Action string `json:"action"`
+	Target string `json:"target"`
+	// stored as json rather than jsonb so that the hashed text is kept as is
+	Details   []byte `json:"details"`
+	ClientIp  string `json:"client_ip"`
+	UserAgent string `json:"user_agent"`
+	RequestID string `json:"request_id"`
+	// hash of the previous event, zeros for the first one
+	PrevHash string `json:"prev_hash"`
+	// sha256 of prev_hash and the fields of the event
+	Hash      string    `json:"hash"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
 type Entry struct {
@@ -26,15 +60,152 @@ type Entry struct {
 	CreatedAt time.Time `json:"created_at"`
 }
 
-type Session struct {
-	ID           uuid.UUID `json:"id"`
-	Username     string    `json:"username"`
-	RefreshToken string    `json:"refresh_token"`
-	UserAgent    string    `json:"user_agent"`
-	ClientIp     string    `json:"client_ip"`
-	IsBlocked    bool      `json:"is_blocked"`
-	ExpiresAt    time.Time `json:"expires_at"`
+type FailedLogin struct {
+	ID        int64     `json:"id"`
+	Username  string    `json:"username"`
+	ClientIp  string    `json:"client_ip"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Hold struct {
+	ID          int64 `json:"id"`
+	AccountID   int64 `json:"account_id"`
+	ToAccountID int64 `json:"to_account_id"`
+	// must be positive
+	Amount     int64       `json:"amount"`
+	Status     string      `json:"status"`
+	TransferID pgtype.Int8 `json:"transfer_id"`
+	ExpiresAt  time.Time   `json:"expires_at"`
+	CreatedAt  time.Time   `json:"created_at"`
+}
+
+type IdempotencyKey struct {
+	Username      string      `json:"username"`
+	Key           string      `json:"key"`
+	FromAccountID int64       `json:"from_account_id"`
+	ToAccountID   int64       `json:"to_account_id"`
+	Amount        int64       `json:"amount"`
+	TransferID    pgtype.Int8 `json:"transfer_id"`
+	Result        []byte      `json:"result"`
+	CreatedAt     time.Time   `json:"created_at"`
+}
+
+type OAuthAuthorizationCode struct {
+	HashedCode  string   `json:"hashed_code"`
+	ClientID    string   `json:"client_id"`
+	Username    string   `json:"username"`
+	RedirectUri string   `json:"redirect_uri"`
+	Scopes      []string `json:"scopes"`
+	// PKCE S256 challenge
+	CodeChallenge string    `json:"code_challenge"`
+	ExpiresAt     time.Time `json:"expires_at"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type OAuthClient struct {
+	ID           string   `json:"id"`
+	Owner        string   `json:"owner"`
+	Name         string   `json:"name"`
+	HashedSecret string   `json:"hashed_secret"`
+	RedirectUris []string `json:"redirect_uris"`
+	// scopes the client is allowed to request
+	Scopes    []string  `json:"scopes"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type OAuthToken struct {
+	// id of the access token payload
+	ID        uuid.UUID          `json:"id"`
+	ClientID  string             `json:"client_id"`
+	Username  string             `json:"username"`
+	Scopes    []string           `json:"scopes"`
+	ExpiresAt time.Time          `json:"expires_at"`
+	RevokedAt pgtype.Timestamptz `json:"revoked_at"`
+	CreatedAt time.Time          `json:"created_at"`
+}
+
+type OutboxMessage struct {
+	ID       int64  `json:"id"`
+	TaskType string `json:"task_type"`
+	// task payload, enqueued as is
+	Payload  []byte `json:"payload"`
+	Queue    string `json:"queue"`
+	MaxRetry int32  `json:"max_retry"`
+	// the task is not processed before this time
+	ProcessAt time.Time   `json:"process_at"`
+	Attempts  int32       `json:"attempts"`
+	LastError pgtype.Text `json:"last_error"`
+	// set once the task is enqueued, pending until then
+	SentAt    pgtype.Timestamptz `json:"sent_at"`
+	CreatedAt time.Time          `json:"created_at"`
+	// a message that failed to be enqueued is not published again before this time
+	RetryAt pgtype.Timestamptz `json:"retry_at"`
+	// set once the task failed to be enqueued too many times, it is not published again
+	FailedAt pgtype.Timestamptz `json:"failed_at"`
+}
+
+type PasswordReset struct {
+	ID         int64     `json:"id"`
+	Username   string    `json:"username"`
+	Email      string    `json:"email"`
+	SecretCode string    `json:"secret_code"`
+	IsUsed     bool      `json:"is_used"`
+	CreatedAt  time.Time `json:"created_at"`
+	ExpiredAt  time.Time `json:"expired_at"`
+}
+
+type RecoveryCode struct {
+	ID         int64              `json:"id"`
+	Username   string             `json:"username"`
+	HashedCode string             `json:"hashed_code"`
+	UsedAt     pgtype.Timestamptz `json:"used_at"`
+	CreatedAt  time.Time          `json:"created_at"`
+}
+
+type Role struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type RolePermission struct {
+	Role string `json:"role"`
+	// <resource>:<action>:<own|any>
+	Permission string    `json:"permission"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type ScheduledTransfer struct {
+	ID            int64  `json:"id"`
+	Owner         string `json:"owner"`
+	FromAccountID int64  `json:"from_account_id"`
+	ToAccountID   int64  `json:"to_account_id"`
+	// must be positive
+	Amount         int64              `json:"amount"`
+	Frequency      string             `json:"frequency"`
+	Status         string             `json:"status"`
+	NextRunAt      time.Time          `json:"next_run_at"`
+	LastRunAt      pgtype.Timestamptz `json:"last_run_at"`
+	LastTransferID pgtype.Int8        `json:"last_transfer_id"`
+	LastError      pgtype.Text        `json:"last_error"`
+	// consecutive failed runs
+	FailureCount int32     `json:"failure_count"`
 	CreatedAt    time.Time `json:"created_at"`
+	// day of the month of monthly runs in UTC, clamped to the last day of shorter months
+	AnchorDay int32 `json:"anchor_day"`
+}
+
+type Session struct {
+	ID           uuid.UUID          `json:"id"`
+	Username     string             `json:"username"`
+	RefreshToken string             `json:"refresh_token"`
+	UserAgent    string             `json:"user_agent"`
+	ClientIp     string             `json:"client_ip"`
+	IsBlocked    bool               `json:"is_blocked"`
+	ExpiresAt    time.Time          `json:"expires_at"`
+	CreatedAt    time.Time          `json:"created_at"`
+	FamilyID     uuid.UUID          `json:"family_id"`
+	RotatedAt    pgtype.Timestamptz `json:"rotated_at"`
 }
 
 type Transfer struct {
@@ -42,19 +213,31 @@ type Transfer struct {
 	FromAccountID int64 `json:"from_account_id"`
 	ToAccountID   int64 `json:"to_account_id"`
 	// must be positive
-	Amount    int64     `json:"amount"`
-	CreatedAt time.Time `json:"created_at"`
+	Amount       int64       `json:"amount"`
+	CreatedAt    time.Time   `json:"created_at"`
+	ReversalOf   pgtype.Int8 `json:"reversal_of"`
+	ToAmount     int64       `json:"to_amount"`
+	ExchangeRate float64     `json:"exchange_rate"`
 }
 
 type User struct {
-	Username          string    `json:"username"`
-	HashedPassword    string    `json:"hashed_password"`
-	FullName          string    `json:"full_name"`
-	Email             string    `json:"email"`
-	PasswordChangedAt time.Time `json:"password_changed_at"`
-	CreatedAt         time.Time `json:"created_at"`
-	IsEmailVerified   bool      `json:"is_email_verified"`
-	Role              string    `json:"role"`
+	Username          string      `json:"username"`
+	HashedPassword    string      `json:"hashed_password"`
+	FullName          string      `json:"full_name"`
+	Email             string      `json:"email"`
+	PasswordChangedAt time.Time   `json:"password_changed_at"`
+	CreatedAt         time.Time   `json:"created_at"`
+	IsEmailVerified   bool        `json:"is_email_verified"`
+	Role              string      `json:"role"`
+	TotpSecret        pgtype.Text `json:"totp_secret"`
+	IsTotpEnabled     bool        `json:"is_totp_enabled"`
+	// consecutive failed logins since the last lockout
+	FailedLoginAttempts int32 `json:"failed_login_attempts"`
+	// consecutive lockouts, used for exponential backoff
+	LockoutCount int32              `json:"lockout_count"`
+	LockedUntil  pgtype.Timestamptz `json:"locked_until"`
+	// access tokens issued before this time are rejected
+	TokensRevokedAt time.Time `json:"tokens_revoked_at"`
 }
 
 type VerifyEmail struct {
@@ -66,3 +249,32 @@ type VerifyEmail struct {
 	CreatedAt  time.Time `json:"created_at"`
 	ExpiredAt  time.Time `json:"expired_at"`
 }
+
+type WebhookDelivery struct {
+	ID         int64 `json:"id"`
+	EndpointID int64 `json:"endpoint_id"`
+	// same for the deliveries of an event and its replays
+	EventID   uuid.UUID `json:"event_id"`
+	EventType string    `json:"event_type"`
+	// stored as json rather than jsonb so that the signed body is kept as is
+	Payload []byte `json:"payload"`
+	// pending, succeeded or failed
+	Status         string             `json:"status"`
+	Attempts       int32              `json:"attempts"`
+	ResponseStatus pgtype.Int4        `json:"response_status"`
+	LastError      pgtype.Text        `json:"last_error"`
+	DeliveredAt    pgtype.Timestamptz `json:"delivered_at"`
+	CreatedAt      time.Time          `json:"created_at"`
+}
+
+type WebhookEndpoint struct {
+	ID    int64  `json:"id"`
+	Owner string `json:"owner"`
+	Url   string `json:"url"`
+	// signs the deliveries, it cannot be hashed as it is needed to sign
+	Secret string `json:"secret"`
+	// events delivered to the endpoint
+	EventTypes []string           `json:"event_types"`
+	DisabledAt pgtype.Timestamptz `json:"disabled_at"`
+	CreatedAt  time.Time          `json:"created_at"`
+}
@@ -0,0 +1,229 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: oauth.sql
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const consumeOAuthAuthorizationCode = `-- name: ConsumeOAuthAuthorizationCode :one
+DELETE FROM oauth_authorization_codes
+WHERE hashed_code = $1
+RETURNING hashed_code, client_id, username, redirect_uri, scopes, code_challenge, expires_at, created_at
+`
+
+func (q *Queries) ConsumeOAuthAuthorizationCode(ctx context.Context, hashedCode string) (OAuthAuthorizationCode, error) {
+	row := q.db.QueryRow(ctx, consumeOAuthAuthorizationCode, hashedCode)
+	var i OAuthAuthorizationCode
+	err := row.Scan(
+		&i.HashedCode,
+		&i.ClientID,
+		&i.Username,
+		&i.RedirectUri,
+		&i.Scopes,
+		&i.CodeChallenge,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createOAuthAuthorizationCode = `-- name: CreateOAuthAuthorizationCode :one
+INSERT INTO oauth_authorization_codes (
+  hashed_code,
+  client_id,
+  username,
+  redirect_uri,
+  scopes,
+  code_challenge,
+  expires_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7
+) RETURNING hashed_code, client_id, username, redirect_uri, scopes, code_challenge, expires_at, created_at
+`
+
+type CreateOAuthAuthorizationCodeParams struct {
+	HashedCode    string    `json:"hashed_code"`
+	ClientID      string    `json:"client_id"`
+	Username      string    `json:"username"`
+	RedirectUri   string    `json:"redirect_uri"`
+	Scopes        []string  `json:"scopes"`
+	CodeChallenge string    `json:"code_challenge"`
+	ExpiresAt     time.Time `json:"expires_at"`
+}
+
+func (q *Queries) CreateOAuthAuthorizationCode(ctx context.Context, arg CreateOAuthAuthorizationCodeParams) (OAuthAuthorizationCode, error) {
+	row := q.db.QueryRow(ctx, createOAuthAuthorizationCode,
+		arg.HashedCode,
+		arg.ClientID,
+		arg.Username,
+		arg.RedirectUri,
+		arg.Scopes,
+		arg.CodeChallenge,
+		arg.ExpiresAt,
+	)
+	var i OAuthAuthorizationCode
+	err := row.Scan(
+		&i.HashedCode,
+		&i.ClientID,
+		&i.Username,
+		&i.RedirectUri,
+		&i.Scopes,
+		&i.CodeChallenge,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createOAuthClient = `-- name: CreateOAuthClient :one
+INSERT INTO oauth_clients (
+  id,
+  owner,
+  name,
+  hashed_secret,
+  redirect_uris,
+  scopes
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+) RETURNING id, owner, name, hashed_secret, redirect_uris, scopes, created_at
+`
+
+type CreateOAuthClientParams struct {
+	ID           string   `json:"id"`
+	Owner        string   `json:"owner"`
+	Name         string   `json:"name"`
+	HashedSecret string   `json:"hashed_secret"`
+	RedirectUris []string `json:"redirect_uris"`
+	Scopes       []string `json:"scopes"`
+}
+
+func (q *Queries) CreateOAuthClient(ctx context.Context, arg CreateOAuthClientParams) (OAuthClient, error) {
+	row := q.db.QueryRow(ctx, createOAuthClient,
+		arg.ID,
+		arg.Owner,
+		arg.Name,
+		arg.HashedSecret,
+		arg.RedirectUris,
+		arg.Scopes,
+	)
+	var i OAuthClient
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Name,
+		&i.HashedSecret,
+		&i.RedirectUris,
+		&i.Scopes,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createOAuthToken = `-- name: CreateOAuthToken :one
+INSERT INTO oauth_tokens (
+  id,
+  client_id,
+  username,
+  scopes,
+  expires_at
+) VALUES (
+  $1, $2, $3, $4, $5
+) RETURNING id, client_id, username, scopes, expires_at, revoked_at, created_at
+`
+
+type CreateOAuthTokenParams struct {
+	ID        uuid.UUID `json:"id"`
+	ClientID  string    `json:"client_id"`
+	Username  string    `json:"username"`
+	Scopes    []string  `json:"scopes"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func (q *Queries) CreateOAuthToken(ctx context.Context, arg CreateOAuthTokenParams) (OAuthToken, error) {
+	row := q.db.QueryRow(ctx, createOAuthToken,
+		arg.ID,
+		arg.ClientID,
+		arg.Username,
+		arg.Scopes,
+		arg.ExpiresAt,
+	)
+	var i OAuthToken
+	err := row.Scan(
+		&i.ID,
+		&i.ClientID,
+		&i.Username,
+		&i.Scopes,
+		&i.ExpiresAt,
+		&i.RevokedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getOAuthClient = `-- name: GetOAuthClient :one
+SELECT id, owner, name, hashed_secret, redirect_uris, scopes, created_at FROM oauth_clients
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetOAuthClient(ctx context.Context, id string) (OAuthClient, error) {
+	row := q.db.QueryRow(ctx, getOAuthClient, id)
+	var i OAuthClient
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Name,
+		&i.HashedSecret,
+		&i.RedirectUris,
+		&i.Scopes,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getOAuthToken = `-- name: GetOAuthToken :one
+SELECT id, client_id, username, scopes, expires_at, revoked_at, created_at FROM oauth_tokens
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetOAuthToken(ctx context.Context, id uuid.UUID) (OAuthToken, error) {
+	row := q.db.QueryRow(ctx, getOAuthToken, id)
+	var i OAuthToken
+	err := row.Scan(
+		&i.ID,
+		&i.ClientID,
+		&i.Username,
+		&i.Scopes,
+		&i.ExpiresAt,
+		&i.RevokedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const revokeOAuthToken = `-- name: RevokeOAuthToken :execrows
+UPDATE oauth_tokens
+SET revoked_at = now()
+WHERE id = $1
+  AND client_id = $2
+  AND revoked_at IS NULL
+`
+
+type RevokeOAuthTokenParams struct {
+	ID       uuid.UUID `json:"id"`
+	ClientID string    `json:"client_id"`
+}
+
+func (q *Queries) RevokeOAuthToken(ctx context.Context, arg RevokeOAuthTokenParams) (int64, error) {
+	result, err := q.db.Exec(ctx, revokeOAuthToken, arg.ID, arg.ClientID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
@@ -0,0 +1,88 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/yelaco/simple-bank/util"
+)
+
+func createRandomOAuthClient(t *testing.T, owner User) OAuthClient {
+	arg := CreateOAuthClientParams{
+		ID:           "sbc_" + util.RandomString(24),
+		Owner:        owner.Username,
+		Name:         util.RandomString(8),
+		HashedSecret: util.RandomString(64),
+		RedirectUris: []string{"https://example.com/callback"},
+		Scopes:       []string{"accounts:read:own"},
+	}
+
+	client, err := testStore.CreateOAuthClient(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.ID, client.ID)
+	require.Equal(t, arg.Owner, client.Owner)
+	require.Equal(t, arg.RedirectUris, client.RedirectUris)
+	require.Equal(t, arg.Scopes, client.Scopes)
+
+	return client
+}
+
+func TestConsumeOAuthAuthorizationCode(t *testing.T) {
+	user := createRandomUser(t)
+	client := createRandomOAuthClient(t, user)
+
+	code, err := testStore.CreateOAuthAuthorizationCode(context.Background(), CreateOAuthAuthorizationCodeParams{
+		HashedCode:    util.RandomString(64),
+		ClientID:      client.ID,
+		Username:      user.Username,
+		RedirectUri:   client.RedirectUris[0],
+		Scopes:        client.Scopes,
+		CodeChallenge: util.RandomString(43),
+		ExpiresAt:     time.Now().Add(time.Minute),
+	})
+	require.NoError(t, err)
+
+	consumed, err := testStore.ConsumeOAuthAuthorizationCode(context.Background(), code.HashedCode)
+	require.NoError(t, err)
+	require.Equal(t, code.Username, consumed.Username)
+
+	_, err = testStore.ConsumeOAuthAuthorizationCode(context.Background(), code.HashedCode)
+	require.ErrorIs(t, err, ErrRecordNotFound)
+}
+
+func TestRevokeOAuthToken(t *testing.T) {
+	user := createRandomUser(t)
+	client := createRandomOAuthClient(t, user)
+	otherClient := createRandomOAuthClient(t, user)
+
+	oauthToken, err := testStore.CreateOAuthToken(context.Background(), CreateOAuthTokenParams{
+		ID:        uuid.New(),
+		ClientID:  client.ID,
+		Username:  user.Username,
+		Scopes:    client.Scopes,
+		ExpiresAt: time.Now().Add(time.Minute),
+	})
+	require.NoError(t, err)
+	require.False(t, oauthToken.RevokedAt.Valid)
+
+	rows, err := testStore.RevokeOAuthToken(context.Background(), RevokeOAuthTokenParams{
+		ID:       oauthToken.ID,
+		ClientID: otherClient.ID,
+	})
+	require.NoError(t, err)
+	require.Zero(t, rows)
+
+	rows, err = testStore.RevokeOAuthToken(context.Background(), RevokeOAuthTokenParams{
+		ID:       oauthToken.ID,
+		ClientID: client.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+
+	oauthToken, err = testStore.GetOAuthToken(context.Background(), oauthToken.ID)
+	require.NoError(t, err)
+	require.True(t, oauthToken.RevokedAt.Valid)
+}
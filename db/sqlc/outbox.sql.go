@@ -0,0 +1,156 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: outbox.sql
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createOutboxMessage = `-- name: CreateOutboxMessage :one
+INSERT INTO outbox (
+  task_type,
+  payload,
+  queue,
+  max_retry,
+  process_at
+) VALUES (
+  $1, $2, $3, $4, $5
+) RETURNING id, task_type, payload, queue, max_retry, process_at, attempts, last_error, sent_at, created_at, retry_at, failed_at
+`
+
+type CreateOutboxMessageParams struct {
+	TaskType  string    `json:"task_type"`
+	Payload   []byte    `json:"payload"`
+	Queue     string    `json:"queue"`
+	MaxRetry  int32     `json:"max_retry"`
+	ProcessAt time.Time `json:"process_at"`
+}
+
+func (q *Queries) CreateOutboxMessage(ctx context.Context, arg CreateOutboxMessageParams) (OutboxMessage, error) {
+	row := q.db.QueryRow(ctx, createOutboxMessage,
+		arg.TaskType,
+		arg.Payload,
+		arg.Queue,
+		arg.MaxRetry,
+		arg.ProcessAt,
+	)
+	var i OutboxMessage
+	err := row.Scan(
+		&i.ID,
+		&i.TaskType,
+		&i.Payload,
+		&i.Queue,
+		&i.MaxRetry,
+		&i.ProcessAt,
+		&i.Attempts,
+		&i.LastError,
+		&i.SentAt,
+		&i.CreatedAt,
+		&i.RetryAt,
+		&i.FailedAt,
+	)
+	return i, err
+}
+
+const listPendingOutboxMessagesForUpdate = `-- name: ListPendingOutboxMessagesForUpdate :many
+SELECT id, task_type, payload, queue, max_retry, process_at, attempts, last_error, sent_at, created_at, retry_at, failed_at FROM outbox
+WHERE sent_at IS NULL
+  AND failed_at IS NULL
+  AND (retry_at IS NULL OR retry_at <= now())
+ORDER BY id
+LIMIT $1
+FOR UPDATE SKIP LOCKED
+`
+
+func (q *Queries) ListPendingOutboxMessagesForUpdate(ctx context.Context, limit int32) ([]OutboxMessage, error) {
+	rows, err := q.db.Query(ctx, listPendingOutboxMessagesForUpdate, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []OutboxMessage{}
+	for rows.Next() {
+		var i OutboxMessage
+		if err := rows.Scan(
+			&i.ID,
+			&i.TaskType,
+			&i.Payload,
+			&i.Queue,
+			&i.MaxRetry,
+			&i.ProcessAt,
+			&i.Attempts,
+			&i.LastError,
+			&i.SentAt,
+			&i.CreatedAt,
+			&i.RetryAt,
+			&i.FailedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markOutboxMessageSent = `-- name: MarkOutboxMessageSent :exec
+UPDATE outbox
+SET sent_at = now()
+WHERE id = $1
+`
+
+func (q *Queries) MarkOutboxMessageSent(ctx context.Context, id int64) error {
+	_, err := q.db.Exec(ctx, markOutboxMessageSent, id)
+	return err
+}
+
+const recordOutboxMessageFailure = `-- name: RecordOutboxMessageFailure :one
+UPDATE outbox
+SET
+  attempts = attempts + 1,
+  last_error = $1::varchar,
+  retry_at = $2,
+  failed_at = CASE WHEN $3::bool THEN now() END
+WHERE id = $4
+RETURNING id, task_type, payload, queue, max_retry, process_at, attempts, last_error, sent_at, created_at, retry_at, failed_at
+`
+
+type RecordOutboxMessageFailureParams struct {
+	LastError string             `json:"last_error"`
+	RetryAt   pgtype.Timestamptz `json:"retry_at"`
+	Failed    bool               `json:"failed"`
+	ID        int64              `json:"id"`
+}
+
+func (q *Queries) RecordOutboxMessageFailure(ctx context.Context, arg RecordOutboxMessageFailureParams) (OutboxMessage, error) {
+	row := q.db.QueryRow(ctx, recordOutboxMessageFailure,
+		arg.LastError,
+		arg.RetryAt,
+		arg.Failed,
+		arg.ID,
+	)
+	var i OutboxMessage
+	err := row.Scan(
+		&i.ID,
+		&i.TaskType,
+		&i.Payload,
+		&i.Queue,
+		&i.MaxRetry,
+		&i.ProcessAt,
+		&i.Attempts,
+		&i.LastError,
+		&i.SentAt,
+		&i.CreatedAt,
+		&i.RetryAt,
+		&i.FailedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,194 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/yelaco/simple-bank/util"
+)
+
+func randomCreateUserParams() CreateUserParams {
+	return CreateUserParams{
+		Username:       util.RandomOwner(),
+		HashedPassword: "secret",
+		FullName:       util.RandomOwner(),
+		Email:          util.RandomEmail(),
+	}
+}
+
+// relayUntil relays pending messages until the message with the given id is
+// sent or failed, as other tests write to the outbox too
+func relayUntil(t *testing.T, id int64, publish func(message OutboxMessage) error) OutboxMessage {
+	var relayed OutboxMessage
+
+	for range 100 {
+		_, err := testStore.RelayOutboxTx(context.Background(), RelayOutboxTxParams{
+			Limit: 100,
+			Publish: func(message OutboxMessage) error {
+				if message.ID != id {
+					return nil
+				}
+				relayed = message
+				return publish(message)
+			},
+		})
+		require.NoError(t, err)
+
+		if relayed.ID == id {
+			return relayed
+		}
+	}
+
+	require.FailNow(t, "outbox message was not relayed")
+	return relayed
+}
+
+func TestCreateUserTxWritesOutbox(t *testing.T) {
+	arg := CreateUserTxParams{
+		CreateUserParams: randomCreateUserParams(),
+		AfterCreate: func(user User) ([]OutboxTask, error) {
+			return []OutboxTask{{
+				TaskType: "task:test",
+				Payload:  []byte(user.Username),
+				Queue:    "critical",
+				MaxRetry: 10,
+			}}, nil
+		},
+	}
+
+	result, err := testStore.CreateUserTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	var published []OutboxMessage
+	for range 100 {
+		_, err := testStore.RelayOutboxTx(context.Background(), RelayOutboxTxParams{
+			Limit: 100,
+			Publish: func(message OutboxMessage) error {
+				if string(message.Payload) == result.User.Username {
+					published = append(published, message)
+				}
+				return nil
+			},
+		})
+		require.NoError(t, err)
+		if len(published) > 0 {
+			break
+		}
+	}
+
+	require.Len(t, published, 1)
+	message := published[0]
+	require.Equal(t, "task:test", message.TaskType)
+	require.Equal(t, "critical", message.Queue)
+	require.Equal(t, int32(10), message.MaxRetry)
+	require.WithinDuration(t, time.Now(), message.ProcessAt, time.Second)
+}
+
+func TestCreateUserTxRollsBackOutbox(t *testing.T) {
+	arg := CreateUserTxParams{
+		CreateUserParams: randomCreateUserParams(),
+		AfterCreate: func(user User) ([]OutboxTask, error) {
+			return nil, errors.New("cannot prepare task")
+		},
+	}
+
+	_, err := testStore.CreateUserTx(context.Background(), arg)
+	require.Error(t, err)
+
+	_, err = testStore.GetUser(context.Background(), arg.Username)
+	require.ErrorIs(t, err, ErrRecordNotFound)
+}
+
+func TestRelayOutboxTx(t *testing.T) {
+	message, err := testStore.CreateOutboxMessage(context.Background(), CreateOutboxMessageParams{
+		TaskType:  "task:test",
+		Payload:   []byte(util.RandomString(10)),
+		Queue:     "default",
+		MaxRetry:  3,
+		ProcessAt: time.Now(),
+	})
+	require.NoError(t, err)
+	require.False(t, message.SentAt.Valid)
+
+	// A failed message stays pending with its error
+	relayUntil(t, message.ID, func(message OutboxMessage) error {
+		return errors.New("redis is down")
+	})
+
+	relayed := relayUntil(t, message.ID, func(message OutboxMessage) error {
+		return nil
+	})
+	require.Equal(t, int32(1), relayed.Attempts)
+	require.Equal(t, "redis is down", relayed.LastError.String)
+
+	// A sent message is not published again
+	_, err = testStore.RelayOutboxTx(context.Background(), RelayOutboxTxParams{
+		Limit: 100,
+		Publish: func(pending OutboxMessage) error {
+			require.NotEqual(t, message.ID, pending.ID)
+			return nil
+		},
+	})
+	require.NoError(t, err)
+}
+
+func TestRelayOutboxTxGivesUp(t *testing.T) {
+	message, err := testStore.CreateOutboxMessage(context.Background(), CreateOutboxMessageParams{
+		TaskType:  "task:test",
+		Payload:   []byte(util.RandomString(10)),
+		Queue:     "default",
+		MaxRetry:  3,
+		ProcessAt: time.Now(),
+	})
+	require.NoError(t, err)
+
+	attempts := 0
+	var abandoned []OutboxMessage
+	for range 100 {
+		result, err := testStore.RelayOutboxTx(context.Background(), RelayOutboxTxParams{
+			Limit:       100,
+			MaxAttempts: 2,
+			Publish: func(pending OutboxMessage) error {
+				if pending.ID != message.ID {
+					return nil
+				}
+				attempts++
+				return errors.New("redis is down")
+			},
+		})
+		require.NoError(t, err)
+
+		abandoned = append(abandoned, result.Abandoned...)
+		if attempts == 2 {
+			break
+		}
+	}
+
+	require.Equal(t, 2, attempts)
+	require.Len(t, abandoned, 1)
+	require.Equal(t, message.ID, abandoned[0].ID)
+	require.Equal(t, int32(2), abandoned[0].Attempts)
+	require.True(t, abandoned[0].FailedAt.Valid)
+	require.False(t, abandoned[0].SentAt.Valid)
+
+	// A failed message is not published again
+	_, err = testStore.RelayOutboxTx(context.Background(), RelayOutboxTxParams{
+		Limit: 100,
+		Publish: func(pending OutboxMessage) error {
+			require.NotEqual(t, message.ID, pending.ID)
+			return nil
+		},
+	})
+	require.NoError(t, err)
+}
+
+func TestOutboxRetryDelay(t *testing.T) {
+	require.Equal(t, time.Second, outboxRetryDelay(time.Second, 1))
+	require.Equal(t, 2*time.Second, outboxRetryDelay(time.Second, 2))
+	require.Equal(t, 8*time.Second, outboxRetryDelay(time.Second, 4))
+	require.Equal(t, maxOutboxRetryDelay, outboxRetryDelay(time.Second, 30))
+	require.Zero(t, outboxRetryDelay(0, 5))
+}
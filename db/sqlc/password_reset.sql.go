@@ -0,0 +1,115 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: password_reset.sql
+
+package db
+
+import (
+	"context"
+)
+
+const createPasswordReset = `-- name: CreatePasswordReset :one
+INSERT INTO password_resets (
+  username,
+  email,
+  secret_code
+) VALUES (
+  $1, $2, $3
+) RETURNING id, username, email, secret_code, is_used, created_at, expired_at
+`
+
+type CreatePasswordResetParams struct {
+	Username   string `json:"username"`
+	Email      string `json:"email"`
+	SecretCode string `json:"secret_code"`
+}
+
+func (q *Queries) CreatePasswordReset(ctx context.Context, arg CreatePasswordResetParams) (PasswordReset, error) {
+	row := q.db.QueryRow(ctx, createPasswordReset, arg.Username, arg.Email, arg.SecretCode)
+	var i PasswordReset
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.SecretCode,
+		&i.IsUsed,
+		&i.CreatedAt,
+		&i.ExpiredAt,
+	)
+	return i, err
+}
+
+const getLatestPasswordReset = `-- name: GetLatestPasswordReset :one
+SELECT id, username, email, secret_code, is_used, created_at, expired_at FROM password_resets
+WHERE username = $1
+ORDER BY id DESC
+LIMIT 1
+`
+
+func (q *Queries) GetLatestPasswordReset(ctx context.Context, username string) (PasswordReset, error) {
+	row := q.db.QueryRow(ctx, getLatestPasswordReset, username)
+	var i PasswordReset
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.SecretCode,
+		&i.IsUsed,
+		&i.CreatedAt,
+		&i.ExpiredAt,
+	)
+	return i, err
+}
+
+const getPasswordReset = `-- name: GetPasswordReset :one
+SELECT id, username, email, secret_code, is_used, created_at, expired_at FROM password_resets
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetPasswordReset(ctx context.Context, id int64) (PasswordReset, error) {
+	row := q.db.QueryRow(ctx, getPasswordReset, id)
+	var i PasswordReset
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.SecretCode,
+		&i.IsUsed,
+		&i.CreatedAt,
+		&i.ExpiredAt,
+	)
+	return i, err
+}
+
+const updatePasswordReset = `-- name: UpdatePasswordReset :one
+UPDATE password_resets
+SET
+	is_used = TRUE
+WHERE
+	id = $1
+	AND secret_code = $2
+	AND is_used = FALSE
+	AND expired_at > now()
+RETURNING id, username, email, secret_code, is_used, created_at, expired_at
+`
+
+type UpdatePasswordResetParams struct {
+	ID         int64  `json:"id"`
+	SecretCode string `json:"secret_code"`
+}
+
+func (q *Queries) UpdatePasswordReset(ctx context.Context, arg UpdatePasswordResetParams) (PasswordReset, error) {
+	row := q.db.QueryRow(ctx, updatePasswordReset, arg.ID, arg.SecretCode)
+	var i PasswordReset
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.SecretCode,
+		&i.IsUsed,
+		&i.CreatedAt,
+		&i.ExpiredAt,
+	)
+	return i, err
+}
@@ -8,29 +8,119 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type Querier interface {
 	AddAccountBalance(ctx context.Context, arg AddAccountBalanceParams) (Account, error)
+	AddAccountHeldAmount(ctx context.Context, arg AddAccountHeldAmountParams) (Account, error)
+	BlockSession(ctx context.Context, id uuid.UUID) (Session, error)
+	BlockSessionFamily(ctx context.Context, familyID uuid.UUID) (int64, error)
+	BlockUserSessions(ctx context.Context, username string) (int64, error)
+	CancelScheduledTransfer(ctx context.Context, id int64) (ScheduledTransfer, error)
+	// Only accounts with a zero balance and no pending holds are closed, checking
+	// both in the update keeps concurrent transfers and holds from slipping in
+	CloseAccount(ctx context.Context, id int64) (Account, error)
+	ConsumeOAuthAuthorizationCode(ctx context.Context, hashedCode string) (OAuthAuthorizationCode, error)
+	CountFailedLoginsByClientIP(ctx context.Context, arg CountFailedLoginsByClientIPParams) (int64, error)
+	CreateAPIKey(ctx context.Context, arg CreateAPIKeyParams) (APIKey, error)
 	CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error)
+	CreateAuditEvent(ctx context.Context, arg CreateAuditEventParams) (AuditEvent, error)
 	CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry, error)
+	CreateFailedLogin(ctx context.Context, arg CreateFailedLoginParams) (FailedLogin, error)
+	CreateHold(ctx context.Context, arg CreateHoldParams) (Hold, error)
+	CreateIdempotencyKey(ctx context.Context, arg CreateIdempotencyKeyParams) (IdempotencyKey, error)
+	CreateOAuthAuthorizationCode(ctx context.Context, arg CreateOAuthAuthorizationCodeParams) (OAuthAuthorizationCode, error)
+	CreateOAuthClient(ctx context.Context, arg CreateOAuthClientParams) (OAuthClient, error)
+	CreateOAuthToken(ctx context.Context, arg CreateOAuthTokenParams) (OAuthToken, error)
+	CreateOutboxMessage(ctx context.Context, arg CreateOutboxMessageParams) (OutboxMessage, error)
+	CreatePasswordReset(ctx context.Context, arg CreatePasswordResetParams) (PasswordReset, error)
+	CreateRecoveryCode(ctx context.Context, arg CreateRecoveryCodeParams) (RecoveryCode, error)
+	CreateReversalTransfer(ctx context.Context, arg CreateReversalTransferParams) (Transfer, error)
+	CreateScheduledTransfer(ctx context.Context, arg CreateScheduledTransferParams) (ScheduledTransfer, error)
 	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
 	CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
 	CreateVerifyEmail(ctx context.Context, arg CreateVerifyEmailParams) (VerifyEmail, error)
+	CreateWebhookDelivery(ctx context.Context, arg CreateWebhookDeliveryParams) (WebhookDelivery, error)
+	CreateWebhookEndpoint(ctx context.Context, arg CreateWebhookEndpointParams) (WebhookEndpoint, error)
 	DeleteAccount(ctx context.Context, id int64) error
+	DeleteRecoveryCodes(ctx context.Context, username string) error
+	DisableUserTOTP(ctx context.Context, username string) (User, error)
+	DisableWebhookEndpoint(ctx context.Context, arg DisableWebhookEndpointParams) (WebhookEndpoint, error)
+	EnableUserTOTP(ctx context.Context, username string) (User, error)
+	GetAPIKey(ctx context.Context, id uuid.UUID) (GetAPIKeyRow, error)
 	GetAccount(ctx context.Context, id int64) (Account, error)
 	GetAccountForUpdate(ctx context.Context, id int64) (Account, error)
+	GetEntriesTotalSince(ctx context.Context, arg GetEntriesTotalSinceParams) (int64, error)
 	GetEntry(ctx context.Context, id int64) (Entry, error)
+	GetHold(ctx context.Context, id int64) (Hold, error)
+	GetHoldForUpdate(ctx context.Context, id int64) (Hold, error)
+	GetIdempotencyKey(ctx context.Context, arg GetIdempotencyKeyParams) (IdempotencyKey, error)
+	GetLastAuditEvent(ctx context.Context) (AuditEvent, error)
+	GetLatestPasswordReset(ctx context.Context, username string) (PasswordReset, error)
+	GetOAuthClient(ctx context.Context, id string) (OAuthClient, error)
+	GetOAuthToken(ctx context.Context, id uuid.UUID) (OAuthToken, error)
+	GetPasswordReset(ctx context.Context, id int64) (PasswordReset, error)
+	GetReversalTotals(ctx context.Context, reversalOf pgtype.Int8) (GetReversalTotalsRow, error)
+	GetRole(ctx context.Context, name string) (Role, error)
+	GetScheduledTransfer(ctx context.Context, id int64) (ScheduledTransfer, error)
+	GetScheduledTransferForUpdate(ctx context.Context, id int64) (ScheduledTransfer, error)
 	GetSession(ctx context.Context, id uuid.UUID) (Session, error)
 	GetTransfer(ctx context.Context, id int64) (Transfer, error)
+	GetTransferForUpdate(ctx context.Context, id int64) (Transfer, error)
 	GetUser(ctx context.Context, username string) (User, error)
+	GetUserByEmail(ctx context.Context, email string) (User, error)
+	GetUserForUpdate(ctx context.Context, username string) (User, error)
+	GetUserTokenState(ctx context.Context, username string) (GetUserTokenStateRow, error)
+	GetWebhookDelivery(ctx context.Context, id int64) (WebhookDelivery, error)
+	GetWebhookEndpoint(ctx context.Context, id int64) (WebhookEndpoint, error)
+	IncrementFailedLoginAttempts(ctx context.Context, username string) (User, error)
+	ListAPIKeys(ctx context.Context, username string) ([]APIKey, error)
 	ListAccounts(ctx context.Context, arg ListAccountsParams) ([]Account, error)
+	ListAllAccounts(ctx context.Context, arg ListAllAccountsParams) ([]Account, error)
+	ListAuditEvents(ctx context.Context, arg ListAuditEventsParams) ([]AuditEvent, error)
+	ListAuditEventsAfter(ctx context.Context, arg ListAuditEventsAfterParams) ([]AuditEvent, error)
+	ListDueScheduledTransfers(ctx context.Context, arg ListDueScheduledTransfersParams) ([]ScheduledTransfer, error)
 	ListEntries(ctx context.Context, arg ListEntriesParams) ([]Entry, error)
+	ListEntriesBetween(ctx context.Context, arg ListEntriesBetweenParams) ([]Entry, error)
+	ListHolds(ctx context.Context, arg ListHoldsParams) ([]Hold, error)
+	ListPendingOutboxMessagesForUpdate(ctx context.Context, limit int32) ([]OutboxMessage, error)
+	ListRolePermissions(ctx context.Context, role string) ([]string, error)
+	ListSessions(ctx context.Context, arg ListSessionsParams) ([]Session, error)
 	ListTransfers(ctx context.Context, arg ListTransfersParams) ([]Transfer, error)
+	ListTransfersBetween(ctx context.Context, arg ListTransfersBetweenParams) ([]Transfer, error)
+	ListUnusedRecoveryCodes(ctx context.Context, username string) ([]RecoveryCode, error)
+	ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) ([]WebhookDelivery, error)
+	ListWebhookEndpoints(ctx context.Context, owner string) ([]WebhookEndpoint, error)
+	ListWebhookEndpointsForEvent(ctx context.Context, arg ListWebhookEndpointsForEventParams) ([]WebhookEndpoint, error)
+	// Serializes the writers of the hash chain until the end of the transaction.
+	// There is a single chain, so every audited transaction, money movement
+	// included, commits one at a time once it takes the lock.
+	LockAuditLog(ctx context.Context) error
+	LockUser(ctx context.Context, arg LockUserParams) (User, error)
+	MarkOutboxMessageSent(ctx context.Context, id int64) error
+	RecordOutboxMessageFailure(ctx context.Context, arg RecordOutboxMessageFailureParams) (OutboxMessage, error)
+	ResetFailedLoginAttempts(ctx context.Context, username string) error
+	RevokeAPIKey(ctx context.Context, arg RevokeAPIKeyParams) (APIKey, error)
+	RevokeOAuthToken(ctx context.Context, arg RevokeOAuthTokenParams) (int64, error)
+	RevokeUserAPIKeys(ctx context.Context, username string) (int64, error)
+	RevokeUserTokens(ctx context.Context, username string) (User, error)
+	RotateSession(ctx context.Context, id uuid.UUID) (Session, error)
+	TouchAPIKey(ctx context.Context, id uuid.UUID) error
+	UnlockUser(ctx context.Context, username string) (User, error)
 	UpdateAccount(ctx context.Context, arg UpdateAccountParams) (Account, error)
+	UpdateAccountOverdraftLimit(ctx context.Context, arg UpdateAccountOverdraftLimitParams) (Account, error)
+	UpdateHoldStatus(ctx context.Context, arg UpdateHoldStatusParams) (Hold, error)
+	UpdateIdempotencyKeyResult(ctx context.Context, arg UpdateIdempotencyKeyResultParams) (IdempotencyKey, error)
+	UpdatePasswordReset(ctx context.Context, arg UpdatePasswordResetParams) (PasswordReset, error)
+	UpdateScheduledTransferRun(ctx context.Context, arg UpdateScheduledTransferRunParams) (ScheduledTransfer, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
+	UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams) (User, error)
+	UpdateUserTOTPSecret(ctx context.Context, arg UpdateUserTOTPSecretParams) (User, error)
 	UpdateVerifyEmail(ctx context.Context, arg UpdateVerifyEmailParams) (VerifyEmail, error)
+	UpdateWebhookDeliveryAttempt(ctx context.Context, arg UpdateWebhookDeliveryAttemptParams) (WebhookDelivery, error)
+	UseRecoveryCode(ctx context.Context, id int64) (RecoveryCode, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -0,0 +1,62 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	"github.com/yelaco/simple-bank/util"
+)
+
+func TestEnableAndDisableTOTPTx(t *testing.T) {
+	user := createRandomUser(t)
+
+	// cannot enable without a pending secret
+	_, err := testStore.EnableTOTPTx(context.Background(), EnableTOTPTxParams{Username: user.Username})
+	require.ErrorIs(t, err, ErrRecordNotFound)
+
+	secret := util.RandomString(32)
+	user, err = testStore.UpdateUserTOTPSecret(context.Background(), UpdateUserTOTPSecretParams{
+		Username:   user.Username,
+		TotpSecret: pgtype.Text{String: secret, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, secret, user.TotpSecret.String)
+	require.False(t, user.IsTotpEnabled)
+
+	hashedCodes := []string{util.RandomString(10), util.RandomString(10)}
+	user, err = testStore.EnableTOTPTx(context.Background(), EnableTOTPTxParams{
+		Username:            user.Username,
+		HashedRecoveryCodes: hashedCodes,
+	})
+	require.NoError(t, err)
+	require.True(t, user.IsTotpEnabled)
+
+	recoveryCodes, err := testStore.ListUnusedRecoveryCodes(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Len(t, recoveryCodes, len(hashedCodes))
+
+	used, err := testStore.UseRecoveryCode(context.Background(), recoveryCodes[0].ID)
+	require.NoError(t, err)
+	require.True(t, used.UsedAt.Valid)
+
+	_, err = testStore.UseRecoveryCode(context.Background(), recoveryCodes[0].ID)
+	require.ErrorIs(t, err, ErrRecordNotFound)
+
+	// the secret cannot be replaced while enabled
+	_, err = testStore.UpdateUserTOTPSecret(context.Background(), UpdateUserTOTPSecretParams{
+		Username:   user.Username,
+		TotpSecret: pgtype.Text{String: util.RandomString(32), Valid: true},
+	})
+	require.ErrorIs(t, err, ErrRecordNotFound)
+
+	user, err = testStore.DisableTOTPTx(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.False(t, user.IsTotpEnabled)
+	require.False(t, user.TotpSecret.Valid)
+
+	recoveryCodes, err = testStore.ListUnusedRecoveryCodes(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Empty(t, recoveryCodes)
+}
@@ -0,0 +1,48 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: role.sql
+
+package db
+
+import (
+	"context"
+)
+
+const getRole = `-- name: GetRole :one
+SELECT name, description, created_at FROM roles
+WHERE name = $1 LIMIT 1
+`
+
+func (q *Queries) GetRole(ctx context.Context, name string) (Role, error) {
+	row := q.db.QueryRow(ctx, getRole, name)
+	var i Role
+	err := row.Scan(&i.Name, &i.Description, &i.CreatedAt)
+	return i, err
+}
+
+const listRolePermissions = `-- name: ListRolePermissions :many
+SELECT permission FROM role_permissions
+WHERE role = $1
+ORDER BY permission
+`
+
+func (q *Queries) ListRolePermissions(ctx context.Context, role string) ([]string, error) {
+	rows, err := q.db.Query(ctx, listRolePermissions, role)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var permission string
+		if err := rows.Scan(&permission); err != nil {
+			return nil, err
+		}
+		items = append(items, permission)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,50 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/yelaco/simple-bank/util"
+)
+
+func TestListRolePermissions(t *testing.T) {
+	permissions, err := testStore.ListRolePermissions(context.Background(), util.DepositorRole)
+	require.NoError(t, err)
+	require.Contains(t, permissions, "accounts:read:own")
+	require.NotContains(t, permissions, "accounts:read:any")
+
+	permissions, err = testStore.ListRolePermissions(context.Background(), util.BankerRole)
+	require.NoError(t, err)
+	require.Contains(t, permissions, "accounts:read:any")
+
+	permissions, err = testStore.ListRolePermissions(context.Background(), util.RandomString(8))
+	require.NoError(t, err)
+	require.Empty(t, permissions)
+}
+
+func TestAssignRoleTx(t *testing.T) {
+	user := createRandomUser(t)
+	createRandomSession(t, user)
+
+	result, err := testStore.AssignRoleTx(context.Background(), AssignRoleTxParams{
+		Username: user.Username,
+		Role:     util.BankerRole,
+	})
+	require.NoError(t, err)
+	require.Equal(t, util.BankerRole, result.User.Role)
+	require.True(t, result.User.TokensRevokedAt.After(user.TokensRevokedAt))
+	require.Equal(t, int64(1), result.RevokedSessions)
+
+	_, err = testStore.AssignRoleTx(context.Background(), AssignRoleTxParams{
+		Username: user.Username,
+		Role:     util.RandomString(8),
+	})
+	require.ErrorIs(t, err, ErrRoleNotFound)
+
+	_, err = testStore.AssignRoleTx(context.Background(), AssignRoleTxParams{
+		Username: util.RandomOwner(),
+		Role:     util.BankerRole,
+	})
+	require.ErrorIs(t, err, ErrRecordNotFound)
+}
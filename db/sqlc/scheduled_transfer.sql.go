@@ -0,0 +1,252 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: scheduled_transfer.sql
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const cancelScheduledTransfer = `-- name: CancelScheduledTransfer :one
+UPDATE scheduled_transfers
+SET status = 'cancelled'
+WHERE id = $1 AND status = 'active'
+RETURNING id, owner, from_account_id, to_account_id, amount, frequency, status, next_run_at, last_run_at, last_transfer_id, last_error, failure_count, created_at, anchor_day
+`
+
+func (q *Queries) CancelScheduledTransfer(ctx context.Context, id int64) (ScheduledTransfer, error) {
+	row := q.db.QueryRow(ctx, cancelScheduledTransfer, id)
+	var i ScheduledTransfer
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Frequency,
+		&i.Status,
+		&i.NextRunAt,
+		&i.LastRunAt,
+		&i.LastTransferID,
+		&i.LastError,
+		&i.FailureCount,
+		&i.CreatedAt,
+		&i.AnchorDay,
+	)
+	return i, err
+}
+
+const createScheduledTransfer = `-- name: CreateScheduledTransfer :one
+INSERT INTO scheduled_transfers (
+  owner,
+  from_account_id,
+  to_account_id,
+  amount,
+  frequency,
+  next_run_at,
+  anchor_day
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7
+) RETURNING id, owner, from_account_id, to_account_id, amount, frequency, status, next_run_at, last_run_at, last_transfer_id, last_error, failure_count, created_at, anchor_day
+`
+
+type CreateScheduledTransferParams struct {
+	Owner         string    `json:"owner"`
+	FromAccountID int64     `json:"from_account_id"`
+	ToAccountID   int64     `json:"to_account_id"`
+	Amount        int64     `json:"amount"`
+	Frequency     string    `json:"frequency"`
+	NextRunAt     time.Time `json:"next_run_at"`
+	AnchorDay     int32     `json:"anchor_day"`
+}
+
+func (q *Queries) CreateScheduledTransfer(ctx context.Context, arg CreateScheduledTransferParams) (ScheduledTransfer, error) {
+	row := q.db.QueryRow(ctx, createScheduledTransfer,
+		arg.Owner,
+		arg.FromAccountID,
+		arg.ToAccountID,
+		arg.Amount,
+		arg.Frequency,
+		arg.NextRunAt,
+		arg.AnchorDay,
+	)
+	var i ScheduledTransfer
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Frequency,
+		&i.Status,
+		&i.NextRunAt,
+		&i.LastRunAt,
+		&i.LastTransferID,
+		&i.LastError,
+		&i.FailureCount,
+		&i.CreatedAt,
+		&i.AnchorDay,
+	)
+	return i, err
+}
+
+const getScheduledTransfer = `-- name: GetScheduledTransfer :one
+SELECT id, owner, from_account_id, to_account_id, amount, frequency, status, next_run_at, last_run_at, last_transfer_id, last_error, failure_count, created_at, anchor_day FROM scheduled_transfers
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetScheduledTransfer(ctx context.Context, id int64) (ScheduledTransfer, error) {
+	row := q.db.QueryRow(ctx, getScheduledTransfer, id)
+	var i ScheduledTransfer
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Frequency,
+		&i.Status,
+		&i.NextRunAt,
+		&i.LastRunAt,
+		&i.LastTransferID,
+		&i.LastError,
+		&i.FailureCount,
+		&i.CreatedAt,
+		&i.AnchorDay,
+	)
+	return i, err
+}
+
+const getScheduledTransferForUpdate = `-- name: GetScheduledTransferForUpdate :one
+SELECT id, owner, from_account_id, to_account_id, amount, frequency, status, next_run_at, last_run_at, last_transfer_id, last_error, failure_count, created_at, anchor_day FROM scheduled_transfers
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetScheduledTransferForUpdate(ctx context.Context, id int64) (ScheduledTransfer, error) {
+	row := q.db.QueryRow(ctx, getScheduledTransferForUpdate, id)
+	var i ScheduledTransfer
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Frequency,
+		&i.Status,
+		&i.NextRunAt,
+		&i.LastRunAt,
+		&i.LastTransferID,
+		&i.LastError,
+		&i.FailureCount,
+		&i.CreatedAt,
+		&i.AnchorDay,
+	)
+	return i, err
+}
+
+const listDueScheduledTransfers = `-- name: ListDueScheduledTransfers :many
+SELECT id, owner, from_account_id, to_account_id, amount, frequency, status, next_run_at, last_run_at, last_transfer_id, last_error, failure_count, created_at, anchor_day FROM scheduled_transfers
+WHERE status = 'active' AND next_run_at <= $1
+ORDER BY next_run_at
+LIMIT $2
+`
+
+type ListDueScheduledTransfersParams struct {
+	Now        time.Time `json:"now"`
+	LimitCount int32     `json:"limit_count"`
+}
+
+func (q *Queries) ListDueScheduledTransfers(ctx context.Context, arg ListDueScheduledTransfersParams) ([]ScheduledTransfer, error) {
+	rows, err := q.db.Query(ctx, listDueScheduledTransfers, arg.Now, arg.LimitCount)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ScheduledTransfer{}
+	for rows.Next() {
+		var i ScheduledTransfer
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.FromAccountID,
+			&i.ToAccountID,
+			&i.Amount,
+			&i.Frequency,
+			&i.Status,
+			&i.NextRunAt,
+			&i.LastRunAt,
+			&i.LastTransferID,
+			&i.LastError,
+			&i.FailureCount,
+			&i.CreatedAt,
+			&i.AnchorDay,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateScheduledTransferRun = `-- name: UpdateScheduledTransferRun :one
+UPDATE scheduled_transfers
+SET
+  status = $1,
+  next_run_at = $2,
+  last_run_at = $3,
+  last_transfer_id = $4,
+  last_error = $5,
+  failure_count = $6
+WHERE
+  id = $7
+RETURNING id, owner, from_account_id, to_account_id, amount, frequency, status, next_run_at, last_run_at, last_transfer_id, last_error, failure_count, created_at, anchor_day
+`
+
+type UpdateScheduledTransferRunParams struct {
+	Status         string             `json:"status"`
+	NextRunAt      time.Time          `json:"next_run_at"`
+	LastRunAt      pgtype.Timestamptz `json:"last_run_at"`
+	LastTransferID pgtype.Int8        `json:"last_transfer_id"`
+	LastError      pgtype.Text        `json:"last_error"`
+	FailureCount   int32              `json:"failure_count"`
+	ID             int64              `json:"id"`
+}
+
+func (q *Queries) UpdateScheduledTransferRun(ctx context.Context, arg UpdateScheduledTransferRunParams) (ScheduledTransfer, error) {
+	row := q.db.QueryRow(ctx, updateScheduledTransferRun,
+		arg.Status,
+		arg.NextRunAt,
+		arg.LastRunAt,
+		arg.LastTransferID,
+		arg.LastError,
+		arg.FailureCount,
+		arg.ID,
+	)
+	var i ScheduledTransfer
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Frequency,
+		&i.Status,
+		&i.NextRunAt,
+		&i.LastRunAt,
+		&i.LastTransferID,
+		&i.LastError,
+		&i.FailureCount,
+		&i.CreatedAt,
+		&i.AnchorDay,
+	)
+	return i, err
+}
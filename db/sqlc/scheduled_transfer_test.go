@@ -0,0 +1,199 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/yelaco/simple-bank/util"
+)
+
+func createRandomScheduledTransfer(t *testing.T, account1, account2 Account, frequency string, nextRunAt time.Time) ScheduledTransfer {
+	arg := CreateScheduledTransferParams{
+		Owner:         account1.Owner,
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+		Frequency:     frequency,
+		NextRunAt:     nextRunAt,
+		AnchorDay:     int32(nextRunAt.UTC().Day()),
+	}
+
+	scheduledTransfer, err := testStore.CreateScheduledTransfer(context.Background(), arg)
+	require.NoError(t, err)
+
+	require.NotZero(t, scheduledTransfer.ID)
+	require.Equal(t, arg.Owner, scheduledTransfer.Owner)
+	require.Equal(t, arg.FromAccountID, scheduledTransfer.FromAccountID)
+	require.Equal(t, arg.ToAccountID, scheduledTransfer.ToAccountID)
+	require.Equal(t, arg.Amount, scheduledTransfer.Amount)
+	require.Equal(t, arg.Frequency, scheduledTransfer.Frequency)
+	require.Equal(t, arg.AnchorDay, scheduledTransfer.AnchorDay)
+	require.Equal(t, ScheduledTransferStatusActive, scheduledTransfer.Status)
+	require.WithinDuration(t, arg.NextRunAt, scheduledTransfer.NextRunAt, time.Second)
+	require.False(t, scheduledTransfer.LastRunAt.Valid)
+	require.Zero(t, scheduledTransfer.FailureCount)
+
+	return scheduledTransfer
+}
+
+func TestExecuteScheduledTransferTx(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+
+	runAt := time.Now().Add(-time.Minute)
+	scheduledTransfer := createRandomScheduledTransfer(t, account1, account2, util.FrequencyWeekly, runAt)
+
+	result, err := testStore.ExecuteScheduledTransferTx(context.Background(), scheduledTransfer.ID)
+	require.NoError(t, err)
+
+	require.Equal(t, scheduledTransfer.Amount, result.Transfer.Amount)
+	require.Equal(t, account1.Balance-scheduledTransfer.Amount, result.FromAccount.Balance)
+	require.Equal(t, account2.Balance+scheduledTransfer.Amount, result.ToAccount.Balance)
+
+	updated := result.ScheduledTransfer
+	require.Equal(t, ScheduledTransferStatusActive, updated.Status)
+	require.WithinDuration(t, runAt.AddDate(0, 0, 7), updated.NextRunAt, time.Second)
+	require.True(t, updated.LastRunAt.Valid)
+	require.Equal(t, result.Transfer.ID, updated.LastTransferID.Int64)
+
+	// the same run cannot be executed twice
+	_, err = testStore.ExecuteScheduledTransferTx(context.Background(), scheduledTransfer.ID)
+	require.ErrorIs(t, err, ErrScheduledTransferNotDue)
+}
+
+func TestExecuteScheduledTransferTxOnce(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+
+	scheduledTransfer := createRandomScheduledTransfer(t, account1, account2, util.FrequencyOnce, time.Now())
+
+	result, err := testStore.ExecuteScheduledTransferTx(context.Background(), scheduledTransfer.ID)
+	require.NoError(t, err)
+	require.Equal(t, ScheduledTransferStatusCompleted, result.ScheduledTransfer.Status)
+
+	_, err = testStore.ExecuteScheduledTransferTx(context.Background(), scheduledTransfer.ID)
+	require.ErrorIs(t, err, ErrScheduledTransferNotDue)
+}
+
+func TestExecuteScheduledTransferTxNotDue(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+
+	scheduledTransfer := createRandomScheduledTransfer(t, account1, account2, util.FrequencyDaily, time.Now().Add(time.Hour))
+
+	_, err := testStore.ExecuteScheduledTransferTx(context.Background(), scheduledTransfer.ID)
+	require.ErrorIs(t, err, ErrScheduledTransferNotDue)
+
+	dueTransfers, err := testStore.ListDueScheduledTransfers(context.Background(), ListDueScheduledTransfersParams{
+		Now:        time.Now(),
+		LimitCount: 1000,
+	})
+	require.NoError(t, err)
+	for _, dueTransfer := range dueTransfers {
+		require.NotEqual(t, scheduledTransfer.ID, dueTransfer.ID)
+	}
+}
+
+func TestFailScheduledTransferTx(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+
+	scheduledTransfer := createRandomScheduledTransfer(t, account1, account2, util.FrequencyDaily, time.Now().Add(-time.Minute))
+
+	notified := 0
+	for i := 1; i <= MaxScheduledTransferFailures; i++ {
+		// make the next run due again
+		_, err := testStore.UpdateScheduledTransferRun(context.Background(), UpdateScheduledTransferRunParams{
+			ID:           scheduledTransfer.ID,
+			Status:       ScheduledTransferStatusActive,
+			NextRunAt:    time.Now().Add(-time.Minute),
+			FailureCount: int32(i - 1),
+		})
+		require.NoError(t, err)
+
+		failed, err := testStore.FailScheduledTransferTx(context.Background(), FailScheduledTransferTxParams{
+			ID:     scheduledTransfer.ID,
+			Reason: ErrInsufficientFunds.Error(),
+			AfterFail: func(schedule ScheduledTransfer) ([]OutboxTask, error) {
+				require.Equal(t, scheduledTransfer.ID, schedule.ID)
+				notified++
+				return nil, nil
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, i, notified)
+		require.Equal(t, int32(i), failed.FailureCount)
+		require.Equal(t, ErrInsufficientFunds.Error(), failed.LastError.String)
+
+		if i < MaxScheduledTransferFailures {
+			require.Equal(t, ScheduledTransferStatusActive, failed.Status)
+			require.True(t, failed.NextRunAt.After(time.Now()))
+		} else {
+			require.Equal(t, ScheduledTransferStatusFailed, failed.Status)
+		}
+	}
+}
+
+func TestCancelScheduledTransfer(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+
+	scheduledTransfer := createRandomScheduledTransfer(t, account1, account2, util.FrequencyMonthly, time.Now())
+
+	cancelled, err := testStore.CancelScheduledTransfer(context.Background(), scheduledTransfer.ID)
+	require.NoError(t, err)
+	require.Equal(t, ScheduledTransferStatusCancelled, cancelled.Status)
+
+	_, err = testStore.CancelScheduledTransfer(context.Background(), scheduledTransfer.ID)
+	require.ErrorIs(t, err, ErrRecordNotFound)
+
+	_, err = testStore.ExecuteScheduledTransferTx(context.Background(), scheduledTransfer.ID)
+	require.ErrorIs(t, err, ErrScheduledTransferNotDue)
+}
+
+func TestNextScheduledRun(t *testing.T) {
+	now := time.Date(2025, time.March, 10, 12, 0, 0, 0, time.UTC)
+
+	status, next := nextScheduledRun(ScheduledTransfer{Frequency: util.FrequencyOnce, NextRunAt: now}, now)
+	require.Equal(t, ScheduledTransferStatusCompleted, status)
+	require.Equal(t, now, next)
+
+	status, next = nextScheduledRun(ScheduledTransfer{Frequency: util.FrequencyDaily, NextRunAt: now}, now)
+	require.Equal(t, ScheduledTransferStatusActive, status)
+	require.Equal(t, now.AddDate(0, 0, 1), next)
+
+	status, next = nextScheduledRun(ScheduledTransfer{Frequency: util.FrequencyMonthly, NextRunAt: now, AnchorDay: 10}, now)
+	require.Equal(t, ScheduledTransferStatusActive, status)
+	require.Equal(t, now.AddDate(0, 1, 0), next)
+
+	// monthly runs anchored on the 31st are clamped to shorter months without drifting
+	schedule := ScheduledTransfer{
+		Frequency: util.FrequencyMonthly,
+		NextRunAt: time.Date(2025, time.January, 31, 9, 0, 0, 0, time.UTC),
+		AnchorDay: 31,
+	}
+	expected := []time.Time{
+		time.Date(2025, time.February, 28, 9, 0, 0, 0, time.UTC),
+		time.Date(2025, time.March, 31, 9, 0, 0, 0, time.UTC),
+		time.Date(2025, time.April, 30, 9, 0, 0, 0, time.UTC),
+		time.Date(2025, time.May, 31, 9, 0, 0, 0, time.UTC),
+	}
+	for _, want := range expected {
+		_, schedule.NextRunAt = nextScheduledRun(schedule, schedule.NextRunAt)
+		require.Equal(t, want, schedule.NextRunAt)
+	}
+
+	// and wrap to the next year
+	_, next = nextScheduledRun(ScheduledTransfer{
+		Frequency: util.FrequencyMonthly,
+		NextRunAt: time.Date(2024, time.December, 31, 9, 0, 0, 0, time.UTC),
+		AnchorDay: 31,
+	}, time.Date(2024, time.December, 31, 9, 0, 0, 0, time.UTC))
+	require.Equal(t, time.Date(2025, time.January, 31, 9, 0, 0, 0, time.UTC), next)
+
+	// missed runs are skipped
+	_, next = nextScheduledRun(ScheduledTransfer{Frequency: util.FrequencyWeekly, NextRunAt: now.AddDate(0, 0, -20)}, now)
+	require.Equal(t, now.AddDate(0, 0, 1), next)
+}
@@ -0,0 +1,161 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/yelaco/simple-bank/util"
+)
+
+func createRandomSession(t *testing.T, user User) Session {
+	arg := CreateSessionParams{
+		ID:           uuid.New(),
+		FamilyID:     uuid.New(),
+		Username:     user.Username,
+		RefreshToken: util.RandomString(32),
+		UserAgent:    util.RandomString(10),
+		ClientIp:     "127.0.0.1",
+		IsBlocked:    false,
+		ExpiresAt:    time.Now().Add(time.Hour),
+	}
+
+	session, err := testStore.CreateSession(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, session)
+
+	require.Equal(t, arg.ID, session.ID)
+	require.Equal(t, arg.Username, session.Username)
+	require.Equal(t, arg.RefreshToken, session.RefreshToken)
+	require.False(t, session.IsBlocked)
+	require.WithinDuration(t, arg.ExpiresAt, session.ExpiresAt, time.Second)
+
+	return session
+}
+
+func TestListSessions(t *testing.T) {
+	user := createRandomUser(t)
+	for range 3 {
+		createRandomSession(t, user)
+	}
+
+	sessions, err := testStore.ListSessions(context.Background(), ListSessionsParams{
+		Username: user.Username,
+		Limit:    5,
+		Offset:   0,
+	})
+	require.NoError(t, err)
+	require.Len(t, sessions, 3)
+
+	for _, session := range sessions {
+		require.Equal(t, user.Username, session.Username)
+	}
+}
+
+func TestBlockSession(t *testing.T) {
+	user := createRandomUser(t)
+	session1 := createRandomSession(t, user)
+	session2 := createRandomSession(t, user)
+
+	blocked, err := testStore.BlockSession(context.Background(), session1.ID)
+	require.NoError(t, err)
+	require.Equal(t, session1.ID, blocked.ID)
+	require.True(t, blocked.IsBlocked)
+
+	other, err := testStore.GetSession(context.Background(), session2.ID)
+	require.NoError(t, err)
+	require.False(t, other.IsBlocked)
+}
+
+func TestBlockUserSessions(t *testing.T) {
+	user := createRandomUser(t)
+	session1 := createRandomSession(t, user)
+	createRandomSession(t, user)
+	createRandomSession(t, user)
+
+	_, err := testStore.BlockSession(context.Background(), session1.ID)
+	require.NoError(t, err)
+
+	rows, err := testStore.BlockUserSessions(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), rows)
+
+	rows, err = testStore.BlockUserSessions(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Zero(t, rows)
+}
+
+func TestRotateSessionTx(t *testing.T) {
+	user := createRandomUser(t)
+	session := createRandomSession(t, user)
+
+	arg := RotateSessionTxParams{
+		SessionID: session.ID,
+		NewSession: CreateSessionParams{
+			ID:           uuid.New(),
+			Username:     user.Username,
+			RefreshToken: util.RandomString(32),
+			UserAgent:    session.UserAgent,
+			ClientIp:     session.ClientIp,
+			ExpiresAt:    session.ExpiresAt,
+		},
+	}
+
+	result, err := testStore.RotateSessionTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, session.ID, result.OldSession.ID)
+	require.True(t, result.OldSession.RotatedAt.Valid)
+	require.Equal(t, arg.NewSession.ID, result.Session.ID)
+	require.Equal(t, session.FamilyID, result.Session.FamilyID)
+	require.False(t, result.Session.RotatedAt.Valid)
+
+	// a session can only be rotated once
+	arg.NewSession.ID = uuid.New()
+	_, err = testStore.RotateSessionTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrSessionRotated)
+
+	_, err = testStore.GetSession(context.Background(), arg.NewSession.ID)
+	require.ErrorIs(t, err, ErrRecordNotFound)
+
+	rows, err := testStore.BlockSessionFamily(context.Background(), session.FamilyID)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), rows)
+}
+
+func TestRevokeRotatedSessionFamily(t *testing.T) {
+	user := createRandomUser(t)
+	session := createRandomSession(t, user)
+
+	result, err := testStore.RotateSessionTx(context.Background(), RotateSessionTxParams{
+		SessionID: session.ID,
+		NewSession: CreateSessionParams{
+			ID:           uuid.New(),
+			Username:     user.Username,
+			RefreshToken: util.RandomString(32),
+			UserAgent:    session.UserAgent,
+			ClientIp:     session.ClientIp,
+			ExpiresAt:    session.ExpiresAt,
+		},
+	})
+	require.NoError(t, err)
+
+	// rotated sessions are not listed
+	sessions, err := testStore.ListSessions(context.Background(), ListSessionsParams{
+		Username: user.Username,
+		Limit:    5,
+		Offset:   0,
+	})
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	require.Equal(t, result.Session.ID, sessions[0].ID)
+
+	// revoking the original session blocks the newest refresh token
+	_, err = testStore.BlockSessionFamily(context.Background(), session.FamilyID)
+	require.NoError(t, err)
+
+	newest, err := testStore.GetSession(context.Background(), result.Session.ID)
+	require.NoError(t, err)
+	require.True(t, newest.IsBlocked)
+}
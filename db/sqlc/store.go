@@ -4,32 +4,79 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
 )
 
+const tracerName = "github.com/yelaco/simple-bank/db"
+
 // Store provides all functions to execute db queries and transactions
 type Store interface {
 	Querier
 	TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error)
+	IdempotentTransferTx(ctx context.Context, arg IdempotentTransferTxParams) (IdempotentTransferTxResult, error)
+	ReverseTransferTx(ctx context.Context, arg ReverseTransferTxParams) (ReverseTransferTxResult, error)
+	ExecuteScheduledTransferTx(ctx context.Context, scheduledTransferID int64) (ExecuteScheduledTransferTxResult, error)
+	FailScheduledTransferTx(ctx context.Context, arg FailScheduledTransferTxParams) (ScheduledTransfer, error)
+	HoldTx(ctx context.Context, arg HoldTxParams) (HoldTxResult, error)
+	CaptureHoldTx(ctx context.Context, arg CaptureHoldTxParams) (CaptureHoldTxResult, error)
+	VoidHoldTx(ctx context.Context, holdID int64) (VoidHoldTxResult, error)
+	ExpireHoldTx(ctx context.Context, holdID int64) (VoidHoldTxResult, error)
+	StatementTx(ctx context.Context, arg StatementTxParams) (StatementTxResult, error)
+	RotateSessionTx(ctx context.Context, arg RotateSessionTxParams) (RotateSessionTxResult, error)
+	EnableTOTPTx(ctx context.Context, arg EnableTOTPTxParams) (User, error)
+	DisableTOTPTx(ctx context.Context, username string) (User, error)
 	CreateUserTx(ctx context.Context, arg CreateUserTxParams) (CreateUserTxResult, error)
 	VerifyEmailTx(ctx context.Context, arg VerifyEmailTxParams) (VerifyEmailTxResults, error)
+	RecordFailedLoginTx(ctx context.Context, arg RecordFailedLoginTxParams) (RecordFailedLoginTxResult, error)
+	RequestPasswordResetTx(ctx context.Context, arg RequestPasswordResetTxParams) (PasswordReset, error)
+	ResetPasswordTx(ctx context.Context, arg ResetPasswordTxParams) (ResetPasswordTxResult, error)
+	UpdateUserTx(ctx context.Context, arg UpdateUserParams) (UpdateUserTxResult, error)
+	LogoutAllTx(ctx context.Context, username string) (LogoutAllTxResult, error)
+	AssignRoleTx(ctx context.Context, arg AssignRoleTxParams) (AssignRoleTxResult, error)
+	CreateAuditEventTx(ctx context.Context, arg CreateAuditEventTxParams) (AuditEvent, error)
+	EnqueueOutboxTasksTx(ctx context.Context, tasks ...OutboxTask) error
+	RelayOutboxTx(ctx context.Context, arg RelayOutboxTxParams) (RelayOutboxTxResult, error)
+	CreateAccountTx(ctx context.Context, arg CreateAccountParams) (Account, error)
+	ReplayWebhookDeliveryTx(ctx context.Context, deliveryID int64) (WebhookDelivery, error)
 }
 
 type SQLStore struct {
 	connPool *pgxpool.Pool
 	*Queries
+	webhookDeliveryTask WebhookDeliveryTaskFunc
 }
 
-func NewStore(connPool *pgxpool.Pool) Store {
+// NewStore creates a Store. webhookDeliveryTask builds the task sending a
+// webhook delivery, written to the outbox by the transactions emitting events.
+func NewStore(connPool *pgxpool.Pool, webhookDeliveryTask WebhookDeliveryTaskFunc) Store {
 	return &SQLStore{
-		connPool: connPool,
-		Queries:  New(connPool),
+		connPool:            connPool,
+		Queries:             New(connPool),
+		webhookDeliveryTask: webhookDeliveryTask,
 	}
 }
 
 // execTx executes a function within a database transaction
 func (store *SQLStore) execTx(ctx context.Context, fn func(*Queries) error) error {
-	tx, err := store.connPool.Begin(ctx)
+	return store.execTxWithOptions(ctx, pgx.TxOptions{}, fn)
+}
+
+// execTxWithOptions executes a function within a database transaction started with the given options
+func (store *SQLStore) execTxWithOptions(ctx context.Context, txOptions pgx.TxOptions, fn func(*Queries) error) (err error) {
+	// The queries of the transaction are traced as children of this span
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "execTx")
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	tx, err := store.connPool.BeginTx(ctx, txOptions)
 	if err != nil {
 		return err
 	}
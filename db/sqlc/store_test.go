@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	"github.com/yelaco/simple-bank/util"
 )
 
 func TestTransferTx(t *testing.T) {
@@ -159,3 +161,341 @@ func TestTransferTxDeadlock(t *testing.T) {
 	require.Equal(t, account1.Balance, updatedAccount1.Balance)
 	require.Equal(t, account2.Balance, updatedAccount2.Balance)
 }
+
+func TestIdempotentTransferTx(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+
+	// run n concurrent retries of the same transfer
+	n := 5
+	amount := int64(10)
+	arg := IdempotentTransferTxParams{
+		TransferTxParams: TransferTxParams{
+			FromAccountID: account1.ID,
+			ToAccountID:   account2.ID,
+			Amount:        amount,
+		},
+		Currency:       account1.Currency,
+		Username:       account1.Owner,
+		IdempotencyKey: util.RandomString(32),
+	}
+
+	errs := make(chan error)
+	results := make(chan IdempotentTransferTxResult)
+
+	for i := 0; i < n; i++ {
+		go func() {
+			result, err := testStore.IdempotentTransferTx(context.Background(), arg)
+
+			errs <- err
+			results <- result
+		}()
+	}
+
+	var transferID int64
+	replayed := 0
+
+	for i := 0; i < n; i++ {
+		err := <-errs
+		require.NoError(t, err)
+
+		result := <-results
+		require.NotZero(t, result.Transfer.ID)
+		if transferID == 0 {
+			transferID = result.Transfer.ID
+		}
+		require.Equal(t, transferID, result.Transfer.ID)
+		require.Equal(t, account1.Balance-amount, result.FromAccount.Balance)
+
+		if result.Replayed {
+			replayed++
+		}
+	}
+	require.Equal(t, n-1, replayed)
+
+	// money is moved only once
+	updatedAccount1, err := testStore.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance-amount, updatedAccount1.Balance)
+
+	// reusing the key for another transfer is rejected
+	mismatch := arg
+	mismatch.Amount = amount + 1
+	_, err = testStore.IdempotentTransferTx(context.Background(), mismatch)
+	require.ErrorIs(t, err, ErrIdempotencyKeyMismatch)
+
+	mismatch = arg
+	mismatch.ToAmount = amount * 2
+	mismatch.ExchangeRate = 2
+	_, err = testStore.IdempotentTransferTx(context.Background(), mismatch)
+	require.ErrorIs(t, err, ErrIdempotencyKeyMismatch)
+
+	mismatch = arg
+	mismatch.Currency = "XXX"
+	_, err = testStore.IdempotentTransferTx(context.Background(), mismatch)
+	require.ErrorIs(t, err, ErrIdempotencyKeyMismatch)
+}
+
+func TestTransferTxInsufficientFunds(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+
+	overdraftLimit := int64(50)
+	_, err := testStore.UpdateAccountOverdraftLimit(context.Background(), UpdateAccountOverdraftLimitParams{
+		ID:             account1.ID,
+		OverdraftLimit: overdraftLimit,
+	})
+	require.NoError(t, err)
+
+	// the balance may go down to exactly -overdraft_limit
+	result, err := testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        account1.Balance + overdraftLimit,
+	})
+	require.NoError(t, err)
+	require.Equal(t, -overdraftLimit, result.FromAccount.Balance)
+
+	// but not any further
+	_, err = testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        1,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	// the rejected transfer is rolled back
+	updatedAccount1, err := testStore.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, -overdraftLimit, updatedAccount1.Balance)
+
+	updatedAccount2, err := testStore.GetAccount(context.Background(), account2.ID)
+	require.NoError(t, err)
+	require.Equal(t, account2.Balance+account1.Balance+overdraftLimit, updatedAccount2.Balance)
+}
+
+func TestTransferTxClosedAccount(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+
+	original, err := testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+
+	_, err = testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account2.ID,
+		ToAccountID:   account1.ID,
+		Amount:        account2.Balance + 10,
+	})
+	require.NoError(t, err)
+
+	_, err = testStore.CloseAccount(context.Background(), account2.ID)
+	require.NoError(t, err)
+
+	// money can move neither into nor out of the closed account
+	_, err = testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        1,
+	})
+	require.ErrorIs(t, err, ErrAccountClosed)
+
+	_, err = testStore.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: original.Transfer.ID,
+	})
+	require.ErrorIs(t, err, ErrAccountClosed)
+
+	updatedAccount2, err := testStore.GetAccount(context.Background(), account2.ID)
+	require.NoError(t, err)
+	require.Zero(t, updatedAccount2.Balance)
+}
+
+func TestReverseTransferTx(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+
+	original, err := testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        50,
+	})
+	require.NoError(t, err)
+
+	// partial refund
+	result, err := testStore.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: original.Transfer.ID,
+		Amount:     20,
+	})
+	require.NoError(t, err)
+
+	reversal := result.Transfer
+	require.NotZero(t, reversal.ID)
+	require.Equal(t, account2.ID, reversal.FromAccountID)
+	require.Equal(t, account1.ID, reversal.ToAccountID)
+	require.Equal(t, int64(20), reversal.Amount)
+	require.True(t, reversal.ReversalOf.Valid)
+	require.Equal(t, original.Transfer.ID, reversal.ReversalOf.Int64)
+	require.Equal(t, original.Transfer.ID, result.OriginalTransfer.ID)
+	require.Equal(t, int64(20), result.ReversedAmount)
+
+	require.Equal(t, account2.ID, result.FromEntry.AccountID)
+	require.Equal(t, int64(-20), result.FromEntry.Amount)
+	require.Equal(t, account1.ID, result.ToEntry.AccountID)
+	require.Equal(t, int64(20), result.ToEntry.Amount)
+
+	require.Equal(t, account1.Balance-30, result.ToAccount.Balance)
+	require.Equal(t, account2.Balance+30, result.FromAccount.Balance)
+
+	// more than what is left
+	_, err = testStore.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: original.Transfer.ID,
+		Amount:     31,
+	})
+	require.ErrorIs(t, err, ErrInvalidReversalAmount)
+
+	// a reversal cannot be reversed
+	_, err = testStore.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: reversal.ID,
+	})
+	require.ErrorIs(t, err, ErrReverseReversal)
+
+	// zero amount reverses the rest
+	result, err = testStore.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: original.Transfer.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(30), result.Transfer.Amount)
+	require.Equal(t, original.Transfer.Amount, result.ReversedAmount)
+	require.Equal(t, account1.Balance, result.ToAccount.Balance)
+	require.Equal(t, account2.Balance, result.FromAccount.Balance)
+
+	_, err = testStore.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: original.Transfer.ID,
+	})
+	require.ErrorIs(t, err, ErrTransferAlreadyReversed)
+}
+
+func TestReverseTransferTxConcurrent(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+
+	original, err := testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+
+	// only one of the concurrent full reversals may succeed
+	n := 5
+	errs := make(chan error)
+	for range n {
+		go func() {
+			_, err := testStore.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+				TransferID: original.Transfer.ID,
+			})
+			errs <- err
+		}()
+	}
+
+	succeeded := 0
+	for range n {
+		err := <-errs
+		if err == nil {
+			succeeded++
+			continue
+		}
+		require.ErrorIs(t, err, ErrTransferAlreadyReversed)
+	}
+	require.Equal(t, 1, succeeded)
+
+	updatedAccount1, err := testStore.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance, updatedAccount1.Balance)
+}
+
+func TestTransferTxCrossCurrency(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+
+	arg := TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        100,
+		ToAmount:      92,
+		ExchangeRate:  0.92,
+	}
+
+	result, err := testStore.TransferTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	require.Equal(t, arg.Amount, result.Transfer.Amount)
+	require.Equal(t, arg.ToAmount, result.Transfer.ToAmount)
+	require.Equal(t, arg.ExchangeRate, result.Transfer.ExchangeRate)
+
+	require.Equal(t, -arg.Amount, result.FromEntry.Amount)
+	require.Equal(t, arg.ToAmount, result.ToEntry.Amount)
+
+	require.Equal(t, account1.Balance-arg.Amount, result.FromAccount.Balance)
+	require.Equal(t, account2.Balance+arg.ToAmount, result.ToAccount.Balance)
+
+	// partial reversals use the original rate, and the last one takes whatever is left after rounding
+	reversal, err := testStore.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: result.Transfer.ID,
+		Amount:     33,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(30), reversal.Transfer.Amount)
+	require.Equal(t, int64(33), reversal.Transfer.ToAmount)
+
+	reversal, err = testStore.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: result.Transfer.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(62), reversal.Transfer.Amount)
+	require.Equal(t, int64(67), reversal.Transfer.ToAmount)
+
+	require.Equal(t, account2.Balance, reversal.FromAccount.Balance)
+	require.Equal(t, account1.Balance, reversal.ToAccount.Balance)
+}
+
+func TestStatementTx(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+
+	fromTime := time.Now()
+
+	transferResult, err := testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+
+	toTime := time.Now()
+
+	// booked after the period, it only changes the current balance
+	_, err = testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        5,
+	})
+	require.NoError(t, err)
+
+	result, err := testStore.StatementTx(context.Background(), StatementTxParams{
+		AccountID: account1.ID,
+		FromTime:  fromTime,
+		ToTime:    toTime,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, account1.Balance, result.OpeningBalance)
+	require.Equal(t, account1.Balance-15, result.Account.Balance)
+	require.Len(t, result.Entries, 1)
+	require.Equal(t, transferResult.FromEntry.ID, result.Entries[0].ID)
+	require.Len(t, result.Transfers, 1)
+	require.Equal(t, transferResult.Transfer.ID, result.Transfers[0].ID)
+}
@@ -7,26 +7,84 @@ package db
 
 import (
 	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const createReversalTransfer = `-- name: CreateReversalTransfer :one
+INSERT INTO transfers (
+        from_account_id,
+        to_account_id,
+        amount,
+        to_amount,
+        exchange_rate,
+        reversal_of
+    )
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, from_account_id, to_account_id, amount, created_at, reversal_of, to_amount, exchange_rate
+`
+
+type CreateReversalTransferParams struct {
+	FromAccountID int64       `json:"from_account_id"`
+	ToAccountID   int64       `json:"to_account_id"`
+	Amount        int64       `json:"amount"`
+	ToAmount      int64       `json:"to_amount"`
+	ExchangeRate  float64     `json:"exchange_rate"`
+	ReversalOf    pgtype.Int8 `json:"reversal_of"`
+}
+
+func (q *Queries) CreateReversalTransfer(ctx context.Context, arg CreateReversalTransferParams) (Transfer, error) {
+	row := q.db.QueryRow(ctx, createReversalTransfer,
+		arg.FromAccountID,
+		arg.ToAccountID,
+		arg.Amount,
+		arg.ToAmount,
+		arg.ExchangeRate,
+		arg.ReversalOf,
+	)
+	var i Transfer
+	err := row.Scan(
+		&i.ID,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.CreatedAt,
+		&i.ReversalOf,
+		&i.ToAmount,
+		&i.ExchangeRate,
+	)
+	return i, err
+}
+
 const createTransfer = `-- name: CreateTransfer :one
 INSERT INTO transfers (
         from_account_id,
         to_account_id,
-        amount
+        amount,
+        to_amount,
+        exchange_rate
     )
-VALUES ($1, $2, $3)
-RETURNING id, from_account_id, to_account_id, amount, created_at
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, from_account_id, to_account_id, amount, created_at, reversal_of, to_amount, exchange_rate
 `
 
 type CreateTransferParams struct {
-	FromAccountID int64 `json:"from_account_id"`
-	ToAccountID   int64 `json:"to_account_id"`
-	Amount        int64 `json:"amount"`
+	FromAccountID int64   `json:"from_account_id"`
+	ToAccountID   int64   `json:"to_account_id"`
+	Amount        int64   `json:"amount"`
+	ToAmount      int64   `json:"to_amount"`
+	ExchangeRate  float64 `json:"exchange_rate"`
 }
 
 func (q *Queries) CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error) {
-	row := q.db.QueryRow(ctx, createTransfer, arg.FromAccountID, arg.ToAccountID, arg.Amount)
+	row := q.db.QueryRow(ctx, createTransfer,
+		arg.FromAccountID,
+		arg.ToAccountID,
+		arg.Amount,
+		arg.ToAmount,
+		arg.ExchangeRate,
+	)
 	var i Transfer
 	err := row.Scan(
 		&i.ID,
@@ -34,12 +92,34 @@ func (q *Queries) CreateTransfer(ctx context.Context, arg CreateTransferParams)
 		&i.ToAccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.ReversalOf,
+		&i.ToAmount,
+		&i.ExchangeRate,
 	)
 	return i, err
 }
 
+const getReversalTotals = `-- name: GetReversalTotals :one
+SELECT COALESCE(SUM(to_amount), 0)::bigint AS reversed_amount,
+    COALESCE(SUM(amount), 0)::bigint AS debited_amount
+FROM transfers
+WHERE reversal_of = $1
+`
+
+type GetReversalTotalsRow struct {
+	ReversedAmount int64 `json:"reversed_amount"`
+	DebitedAmount  int64 `json:"debited_amount"`
+}
+
+func (q *Queries) GetReversalTotals(ctx context.Context, reversalOf pgtype.Int8) (GetReversalTotalsRow, error) {
+	row := q.db.QueryRow(ctx, getReversalTotals, reversalOf)
+	var i GetReversalTotalsRow
+	err := row.Scan(&i.ReversedAmount, &i.DebitedAmount)
+	return i, err
+}
+
 const getTransfer = `-- name: GetTransfer :one
-SELECT id, from_account_id, to_account_id, amount, created_at
+SELECT id, from_account_id, to_account_id, amount, created_at, reversal_of, to_amount, exchange_rate
 FROM transfers
 WHERE id = $1
 LIMIT 1
@@ -54,12 +134,39 @@ func (q *Queries) GetTransfer(ctx context.Context, id int64) (Transfer, error) {
 		&i.ToAccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.ReversalOf,
+		&i.ToAmount,
+		&i.ExchangeRate,
+	)
+	return i, err
+}
+
+const getTransferForUpdate = `-- name: GetTransferForUpdate :one
+SELECT id, from_account_id, to_account_id, amount, created_at, reversal_of, to_amount, exchange_rate
+FROM transfers
+WHERE id = $1
+LIMIT 1 FOR NO KEY
+UPDATE
+`
+
+func (q *Queries) GetTransferForUpdate(ctx context.Context, id int64) (Transfer, error) {
+	row := q.db.QueryRow(ctx, getTransferForUpdate, id)
+	var i Transfer
+	err := row.Scan(
+		&i.ID,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.CreatedAt,
+		&i.ReversalOf,
+		&i.ToAmount,
+		&i.ExchangeRate,
 	)
 	return i, err
 }
 
 const listTransfers = `-- name: ListTransfers :many
-SELECT id, from_account_id, to_account_id, amount, created_at
+SELECT id, from_account_id, to_account_id, amount, created_at, reversal_of, to_amount, exchange_rate
 FROM transfers
 WHERE from_account_id = $1
     OR to_account_id = $2
@@ -94,6 +201,56 @@ func (q *Queries) ListTransfers(ctx context.Context, arg ListTransfersParams) ([
 			&i.ToAccountID,
 			&i.Amount,
 			&i.CreatedAt,
+			&i.ReversalOf,
+			&i.ToAmount,
+			&i.ExchangeRate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTransfersBetween = `-- name: ListTransfersBetween :many
+SELECT id, from_account_id, to_account_id, amount, created_at, reversal_of, to_amount, exchange_rate
+FROM transfers
+WHERE (
+        from_account_id = $1
+        OR to_account_id = $1
+    )
+    AND created_at >= $2
+    AND created_at < $3
+ORDER BY id
+`
+
+type ListTransfersBetweenParams struct {
+	AccountID int64     `json:"account_id"`
+	FromTime  time.Time `json:"from_time"`
+	ToTime    time.Time `json:"to_time"`
+}
+
+func (q *Queries) ListTransfersBetween(ctx context.Context, arg ListTransfersBetweenParams) ([]Transfer, error) {
+	rows, err := q.db.Query(ctx, listTransfersBetween, arg.AccountID, arg.FromTime, arg.ToTime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Transfer{}
+	for rows.Next() {
+		var i Transfer
+		if err := rows.Scan(
+			&i.ID,
+			&i.FromAccountID,
+			&i.ToAccountID,
+			&i.Amount,
+			&i.CreatedAt,
+			&i.ReversalOf,
+			&i.ToAmount,
+			&i.ExchangeRate,
 		); err != nil {
 			return nil, err
 		}
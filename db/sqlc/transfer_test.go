@@ -22,10 +22,13 @@ func createRandomTransfer(t *testing.T) Transfer {
 		toAccountID = toAccount.ID
 	}
 
+	amount := util.RandomMoney()
 	arg := CreateTransferParams{
 		FromAccountID: fromAccountID,
 		ToAccountID:   toAccountID,
-		Amount:        util.RandomMoney(),
+		Amount:        amount,
+		ToAmount:      amount,
+		ExchangeRate:  1,
 	}
 
 	transfer, err := testStore.CreateTransfer(context.Background(), arg)
@@ -35,6 +38,8 @@ func createRandomTransfer(t *testing.T) Transfer {
 	require.Equal(t, arg.FromAccountID, transfer.FromAccountID)
 	require.Equal(t, arg.ToAccountID, transfer.ToAccountID)
 	require.Equal(t, arg.Amount, transfer.Amount)
+	require.Equal(t, arg.ToAmount, transfer.ToAmount)
+	require.Equal(t, arg.ExchangeRate, transfer.ExchangeRate)
 
 	require.NotZero(t, transfer.ID)
 	require.NotZero(t, transfer.CreatedAt)
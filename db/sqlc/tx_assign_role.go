@@ -0,0 +1,65 @@
+package db
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrRoleNotFound is returned when a user is assigned a role that is not defined
+var ErrRoleNotFound = errors.New("role not found")
+
+type AssignRoleTxParams struct {
+	Username string
+	Role     string
+	// Audit identifies who changed the role in the audit log
+	Audit AuditContext
+}
+
+type AssignRoleTxResult struct {
+	User            User
+	RevokedSessions int64
+}
+
+// AssignRoleTx changes the role of a user. Tokens carry the role they were
+// issued with, so every access token and session of the user is revoked.
+func (store *SQLStore) AssignRoleTx(ctx context.Context, arg AssignRoleTxParams) (AssignRoleTxResult, error) {
+	var result AssignRoleTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		_, err = q.GetRole(ctx, arg.Role)
+		if err != nil {
+			if errors.Is(err, ErrRecordNotFound) {
+				return ErrRoleNotFound
+			}
+			return err
+		}
+
+		result.User, err = q.UpdateUserRole(ctx, UpdateUserRoleParams{
+			Username: arg.Username,
+			Role:     arg.Role,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.RevokedSessions, err = q.BlockUserSessions(ctx, arg.Username)
+		if err != nil {
+			return err
+		}
+
+		_, err = appendAuditEvent(ctx, q, CreateAuditEventTxParams{
+			AuditContext: arg.Audit,
+			Action:       AuditActionRoleChange,
+			Target:       AuditTarget("user", arg.Username),
+			Details: map[string]any{
+				"role":             arg.Role,
+				"revoked_sessions": result.RevokedSessions,
+			},
+		})
+		return err
+	})
+
+	return result, err
+}
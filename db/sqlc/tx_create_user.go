@@ -6,7 +6,9 @@ import (
 
 type CreateUserTxParams struct {
 	CreateUserParams
-	AfterCreate func(user User) error
+	// AfterCreate returns the tasks to enqueue for the new user, they are
+	// written to the outbox in the same transaction
+	AfterCreate func(user User) ([]OutboxTask, error)
 }
 
 type CreateUserTxResult struct {
@@ -24,7 +26,12 @@ func (store *SQLStore) CreateUserTx(ctx context.Context, arg CreateUserTxParams)
 			return err
 		}
 
-		return arg.AfterCreate(result.User)
+		tasks, err := arg.AfterCreate(result.User)
+		if err != nil {
+			return err
+		}
+
+		return enqueueOutboxTasks(ctx, q, tasks...)
 	})
 
 	return result, err
@@ -0,0 +1,94 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type RecordFailedLoginTxParams struct {
+	Username string
+	ClientIP string
+	// MaxAttempts is the number of consecutive failures that locks the user.
+	// Zero disables the lockout.
+	MaxAttempts int32
+	// LockoutDuration is the duration of the first lockout. It doubles with
+	// every following lockout, up to MaxLockoutDuration.
+	LockoutDuration    time.Duration
+	MaxLockoutDuration time.Duration
+	// AfterLock returns the tasks to enqueue when this failure locks the user,
+	// they are written to the outbox in the same transaction
+	AfterLock func(user User) ([]OutboxTask, error)
+}
+
+type RecordFailedLoginTxResult struct {
+	User User
+	// Locked is true when this failure locked the user
+	Locked bool
+}
+
+// RecordFailedLoginTx records a failed login of an existing user and locks
+// the user once MaxAttempts consecutive failures are reached
+func (store *SQLStore) RecordFailedLoginTx(ctx context.Context, arg RecordFailedLoginTxParams) (RecordFailedLoginTxResult, error) {
+	var result RecordFailedLoginTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		_, err = q.CreateFailedLogin(ctx, CreateFailedLoginParams{
+			Username: arg.Username,
+			ClientIp: arg.ClientIP,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.User, err = q.IncrementFailedLoginAttempts(ctx, arg.Username)
+		if err != nil {
+			return err
+		}
+
+		if arg.MaxAttempts <= 0 || result.User.FailedLoginAttempts < arg.MaxAttempts {
+			return nil
+		}
+
+		result.User, err = q.LockUser(ctx, LockUserParams{
+			Username: arg.Username,
+			LockedUntil: pgtype.Timestamptz{
+				Time:  time.Now().Add(lockoutDuration(arg, result.User.LockoutCount)),
+				Valid: true,
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Locked = true
+
+		if arg.AfterLock == nil {
+			return nil
+		}
+		tasks, err := arg.AfterLock(result.User)
+		if err != nil {
+			return err
+		}
+
+		return enqueueOutboxTasks(ctx, q, tasks...)
+	})
+
+	return result, err
+}
+
+// lockoutDuration doubles the base duration for every previous lockout
+func lockoutDuration(arg RecordFailedLoginTxParams, lockoutCount int32) time.Duration {
+	duration := arg.LockoutDuration
+	for range lockoutCount {
+		if duration >= arg.MaxLockoutDuration {
+			break
+		}
+		duration *= 2
+	}
+
+	return min(duration, arg.MaxLockoutDuration)
+}
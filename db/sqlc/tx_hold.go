@@ -0,0 +1,261 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	HoldStatusPending  = "pending"
+	HoldStatusCaptured = "captured"
+	HoldStatusVoided   = "voided"
+	HoldStatusExpired  = "expired"
+)
+
+var (
+	ErrHoldNotPending       = errors.New("hold is not pending")
+	ErrHoldExpired          = errors.New("hold is expired")
+	ErrHoldNotExpired       = errors.New("hold is not expired yet")
+	ErrInvalidCaptureAmount = errors.New("capture amount must be positive and not exceed the held amount")
+)
+
+type HoldTxParams struct {
+	AccountID   int64     `json:"account_id"`
+	ToAccountID int64     `json:"to_account_id"`
+	Amount      int64     `json:"amount"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	// AfterCreate returns the tasks to enqueue for the new hold, they are
+	// written to the outbox in the same transaction
+	AfterCreate func(hold Hold) ([]OutboxTask, error)
+}
+
+type HoldTxResult struct {
+	Hold    Hold    `json:"hold"`
+	Account Account `json:"account"`
+}
+
+// HoldTx reserves money on an account without moving it.
+// The held amount lowers the available balance of the account but no entries are created until the hold is captured.
+func (store *SQLStore) HoldTx(ctx context.Context, arg HoldTxParams) (HoldTxResult, error) {
+	var result HoldTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.Account, err = q.AddAccountHeldAmount(ctx, AddAccountHeldAmountParams{
+			ID:     arg.AccountID,
+			Amount: arg.Amount,
+		})
+		if err != nil {
+			return err
+		}
+
+		if result.Account.IsClosed {
+			return ErrAccountClosed
+		}
+
+		if !result.Account.canSpend() {
+			return ErrInsufficientFunds
+		}
+
+		toAccount, err := q.GetAccount(ctx, arg.ToAccountID)
+		if err != nil {
+			return err
+		}
+
+		if toAccount.IsClosed {
+			return ErrAccountClosed
+		}
+
+		result.Hold, err = q.CreateHold(ctx, CreateHoldParams{
+			AccountID:   arg.AccountID,
+			ToAccountID: arg.ToAccountID,
+			Amount:      arg.Amount,
+			ExpiresAt:   arg.ExpiresAt,
+		})
+		if err != nil {
+			return err
+		}
+
+		if arg.AfterCreate == nil {
+			return nil
+		}
+		tasks, err := arg.AfterCreate(result.Hold)
+		if err != nil {
+			return err
+		}
+
+		return enqueueOutboxTasks(ctx, q, tasks...)
+	})
+
+	return result, err
+}
+
+type CaptureHoldTxParams struct {
+	HoldID int64 `json:"hold_id"`
+	// Amount to capture, it must not exceed the held amount. The rest of the hold is released.
+	Amount int64 `json:"amount"`
+	// Audit identifies who captured the hold in the audit log
+	Audit AuditContext `json:"-"`
+}
+
+type CaptureHoldTxResult struct {
+	Hold Hold `json:"hold"`
+	TransferTxResult
+}
+
+// CaptureHoldTx settles a pending hold by releasing the reserved amount
+// and transferring the captured amount to the destination account
+func (store *SQLStore) CaptureHoldTx(ctx context.Context, arg CaptureHoldTxParams) (CaptureHoldTxResult, error) {
+	var result CaptureHoldTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		hold, err := q.GetHoldForUpdate(ctx, arg.HoldID)
+		if err != nil {
+			return err
+		}
+
+		if hold.Status != HoldStatusPending {
+			return ErrHoldNotPending
+		}
+
+		if time.Now().After(hold.ExpiresAt) {
+			return ErrHoldExpired
+		}
+
+		if arg.Amount <= 0 || arg.Amount > hold.Amount {
+			return ErrInvalidCaptureAmount
+		}
+
+		// lock both accounts in a consistent order before touching them to avoid deadlocks
+		err = lockAccounts(ctx, q, hold.AccountID, hold.ToAccountID)
+		if err != nil {
+			return err
+		}
+
+		_, err = q.AddAccountHeldAmount(ctx, AddAccountHeldAmountParams{
+			ID:     hold.AccountID,
+			Amount: -hold.Amount,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.TransferTxResult, err = store.transfer(ctx, q, TransferTxParams{
+			FromAccountID: hold.AccountID,
+			ToAccountID:   hold.ToAccountID,
+			Amount:        arg.Amount,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Hold, err = q.UpdateHoldStatus(ctx, UpdateHoldStatusParams{
+			ID:     hold.ID,
+			Status: HoldStatusCaptured,
+			TransferID: pgtype.Int8{
+				Int64: result.Transfer.ID,
+				Valid: true,
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = appendAuditEvent(ctx, q, newTransferAuditEvent(arg.Audit, AuditActionTransfer, result.Transfer))
+		return err
+	})
+
+	return result, err
+}
+
+type VoidHoldTxResult struct {
+	Hold    Hold    `json:"hold"`
+	Account Account `json:"account"`
+}
+
+// VoidHoldTx cancels a pending hold and gives the reserved amount back to the available balance
+func (store *SQLStore) VoidHoldTx(ctx context.Context, holdID int64) (VoidHoldTxResult, error) {
+	var result VoidHoldTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result, err = releaseHold(ctx, q, holdID, HoldStatusVoided)
+
+		return err
+	})
+
+	return result, err
+}
+
+// ExpireHoldTx releases a pending hold whose expiry time has passed
+func (store *SQLStore) ExpireHoldTx(ctx context.Context, holdID int64) (VoidHoldTxResult, error) {
+	var result VoidHoldTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result, err = releaseHold(ctx, q, holdID, HoldStatusExpired)
+
+		return err
+	})
+
+	return result, err
+}
+
+func releaseHold(ctx context.Context, q *Queries, holdID int64, status string) (result VoidHoldTxResult, err error) {
+	hold, err := q.GetHoldForUpdate(ctx, holdID)
+	if err != nil {
+		return
+	}
+
+	if hold.Status != HoldStatusPending {
+		err = ErrHoldNotPending
+		return
+	}
+
+	if status == HoldStatusExpired && time.Now().Before(hold.ExpiresAt) {
+		err = ErrHoldNotExpired
+		return
+	}
+
+	result.Account, err = q.AddAccountHeldAmount(ctx, AddAccountHeldAmountParams{
+		ID:     hold.AccountID,
+		Amount: -hold.Amount,
+	})
+	if err != nil {
+		return
+	}
+
+	result.Hold, err = q.UpdateHoldStatus(ctx, UpdateHoldStatusParams{
+		ID:     hold.ID,
+		Status: status,
+	})
+
+	return
+}
+
+// lockAccounts locks both accounts in a consistent order, it fails with
+// ErrAccountClosed if either of them is closed
+func lockAccounts(ctx context.Context, q *Queries, accountID1, accountID2 int64) error {
+	if accountID1 > accountID2 {
+		accountID1, accountID2 = accountID2, accountID1
+	}
+
+	for _, accountID := range []int64{accountID1, accountID2} {
+		account, err := q.GetAccountForUpdate(ctx, accountID)
+		if err != nil {
+			return err
+		}
+
+		if account.IsClosed {
+			return ErrAccountClosed
+		}
+	}
+
+	return nil
+}
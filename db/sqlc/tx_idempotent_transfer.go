@@ -0,0 +1,113 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var ErrIdempotencyKeyMismatch = errors.New("idempotency key was already used with different parameters")
+
+// idempotencyKeysPkey is the primary key of the idempotency keys, only its
+// violations mean that the key was already used
+const idempotencyKeysPkey = "idempotency_keys_pkey"
+
+type IdempotentTransferTxParams struct {
+	TransferTxParams
+	// Currency is the currency of the from account the transfer was requested in
+	Currency       string `json:"currency"`
+	Username       string `json:"username"`
+	IdempotencyKey string `json:"idempotency_key"`
+}
+
+type IdempotentTransferTxResult struct {
+	TransferTxResult
+	// Replayed is true when the result was stored by an earlier request with the same key
+	Replayed bool `json:"replayed"`
+}
+
+// IdempotentTransferTx performs a money transfer at most once per username and idempotency key.
+// The key is inserted in the same transaction as the transfer, so a concurrent retry waits for
+// the first request to finish and then gets back the stored result instead of moving money again.
+func (store *SQLStore) IdempotentTransferTx(ctx context.Context, arg IdempotentTransferTxParams) (IdempotentTransferTxResult, error) {
+	var result IdempotentTransferTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		_, err := q.CreateIdempotencyKey(ctx, CreateIdempotencyKeyParams{
+			Username:      arg.Username,
+			Key:           arg.IdempotencyKey,
+			FromAccountID: arg.FromAccountID,
+			ToAccountID:   arg.ToAccountID,
+			Amount:        arg.Amount,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.TransferTxResult, err = store.transfer(ctx, q, arg.TransferTxParams)
+		if err != nil {
+			return err
+		}
+
+		jsonResult, err := json.Marshal(result.TransferTxResult)
+		if err != nil {
+			return fmt.Errorf("failed to marshal transfer result: %w", err)
+		}
+
+		_, err = q.UpdateIdempotencyKeyResult(ctx, UpdateIdempotencyKeyResultParams{
+			Username: arg.Username,
+			Key:      arg.IdempotencyKey,
+			TransferID: pgtype.Int8{
+				Int64: result.Transfer.ID,
+				Valid: true,
+			},
+			Result: jsonResult,
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = appendAuditEvent(ctx, q, newTransferAuditEvent(arg.Audit, AuditActionTransfer, result.Transfer))
+		return err
+	})
+	if ErrorCode(err) == UniqueViolation && ConstraintName(err) == idempotencyKeysPkey {
+		return store.replayTransfer(ctx, arg)
+	}
+
+	return result, err
+}
+
+func (store *SQLStore) replayTransfer(ctx context.Context, arg IdempotentTransferTxParams) (IdempotentTransferTxResult, error) {
+	result := IdempotentTransferTxResult{Replayed: true}
+
+	key, err := store.GetIdempotencyKey(ctx, GetIdempotencyKeyParams{
+		Username: arg.Username,
+		Key:      arg.IdempotencyKey,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	if key.FromAccountID != arg.FromAccountID || key.ToAccountID != arg.ToAccountID || key.Amount != arg.Amount {
+		return result, ErrIdempotencyKeyMismatch
+	}
+
+	err = json.Unmarshal(key.Result, &result.TransferTxResult)
+	if err != nil {
+		return result, fmt.Errorf("failed to unmarshal transfer result: %w", err)
+	}
+
+	// the credited amount is stored normalized, zero means the same as Amount
+	toAmount := arg.ToAmount
+	if toAmount == 0 {
+		toAmount = arg.Amount
+	}
+	if result.FromAccount.Currency != arg.Currency || result.Transfer.ToAmount != toAmount {
+		return IdempotentTransferTxResult{Replayed: true}, ErrIdempotencyKeyMismatch
+	}
+
+	return result, nil
+}
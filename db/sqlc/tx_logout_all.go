@@ -0,0 +1,36 @@
+package db
+
+import (
+	"context"
+)
+
+type LogoutAllTxResult struct {
+	User            User
+	RevokedSessions int64
+	RevokedAPIKeys  int64
+}
+
+// LogoutAllTx rejects the access tokens issued to the user so far, and blocks
+// all of their sessions and revokes all of their API keys
+func (store *SQLStore) LogoutAllTx(ctx context.Context, username string) (LogoutAllTxResult, error) {
+	var result LogoutAllTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.User, err = q.RevokeUserTokens(ctx, username)
+		if err != nil {
+			return err
+		}
+
+		result.RevokedSessions, err = q.BlockUserSessions(ctx, username)
+		if err != nil {
+			return err
+		}
+
+		result.RevokedAPIKeys, err = q.RevokeUserAPIKeys(ctx, username)
+		return err
+	})
+
+	return result, err
+}
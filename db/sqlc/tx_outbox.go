@@ -0,0 +1,144 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// maxOutboxRetryDelay caps the exponential backoff of a failing message
+const maxOutboxRetryDelay = time.Hour
+
+// OutboxTask is a worker task written to the outbox, it is enqueued by the
+// relay once the transaction that wrote it has committed
+type OutboxTask struct {
+	TaskType string
+	Payload  []byte
+	Queue    string
+	MaxRetry int32
+	// ProcessAt delays the task, it is processed right away when zero
+	ProcessAt time.Time
+}
+
+// enqueueOutboxTasks writes tasks to the outbox using the given Queries, so
+// that they are only enqueued if the transaction commits
+func enqueueOutboxTasks(ctx context.Context, q *Queries, tasks ...OutboxTask) error {
+	for _, task := range tasks {
+		processAt := task.ProcessAt
+		if processAt.IsZero() {
+			processAt = time.Now()
+		}
+
+		_, err := q.CreateOutboxMessage(ctx, CreateOutboxMessageParams{
+			TaskType:  task.TaskType,
+			Payload:   task.Payload,
+			Queue:     task.Queue,
+			MaxRetry:  task.MaxRetry,
+			ProcessAt: processAt,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// EnqueueOutboxTasksTx writes tasks that do not belong to any other write to
+// the outbox, so that every task is enqueued through the relay
+func (store *SQLStore) EnqueueOutboxTasksTx(ctx context.Context, tasks ...OutboxTask) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		return enqueueOutboxTasks(ctx, q, tasks...)
+	})
+}
+
+type RelayOutboxTxParams struct {
+	Limit int32 `json:"limit"`
+	// MaxAttempts is the number of failed publishes after which a message is
+	// given up and marked failed. Zero retries forever.
+	MaxAttempts int32 `json:"max_attempts"`
+	// RetryDelay is the time before a failed message is published again. It
+	// doubles with every failed attempt, up to an hour.
+	RetryDelay time.Duration `json:"retry_delay"`
+	// Publish enqueues the task of a message. A message is published again if
+	// the transaction fails to commit, so publishing must be idempotent.
+	Publish func(message OutboxMessage) error
+}
+
+type RelayOutboxTxResult struct {
+	Sent   int `json:"sent"`
+	Failed int `json:"failed"`
+	// Abandoned are the failed messages that reached MaxAttempts, they are
+	// not published again
+	Abandoned []OutboxMessage `json:"abandoned"`
+}
+
+// RelayOutboxTx publishes the oldest pending messages of the outbox and marks
+// them sent. Failed messages stay pending and are retried after RetryDelay,
+// until they fail MaxAttempts times.
+// The messages are locked with SKIP LOCKED, so several relays can run at once.
+func (store *SQLStore) RelayOutboxTx(ctx context.Context, arg RelayOutboxTxParams) (RelayOutboxTxResult, error) {
+	var result RelayOutboxTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		result = RelayOutboxTxResult{}
+
+		messages, err := q.ListPendingOutboxMessagesForUpdate(ctx, arg.Limit)
+		if err != nil {
+			return err
+		}
+
+		for _, message := range messages {
+			publishErr := arg.Publish(message)
+			if publishErr != nil {
+				result.Failed++
+
+				attempts := message.Attempts + 1
+				failed := arg.MaxAttempts > 0 && attempts >= arg.MaxAttempts
+
+				message, err = q.RecordOutboxMessageFailure(ctx, RecordOutboxMessageFailureParams{
+					ID:        message.ID,
+					LastError: publishErr.Error(),
+					RetryAt: pgtype.Timestamptz{
+						Time:  time.Now().Add(outboxRetryDelay(arg.RetryDelay, attempts)),
+						Valid: true,
+					},
+					Failed: failed,
+				})
+				if err != nil {
+					return err
+				}
+
+				if failed {
+					result.Abandoned = append(result.Abandoned, message)
+				}
+				continue
+			}
+
+			result.Sent++
+			err = q.MarkOutboxMessageSent(ctx, message.ID)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// outboxRetryDelay doubles the base delay for every failed attempt but the
+// first one, up to maxOutboxRetryDelay
+func outboxRetryDelay(base time.Duration, attempts int32) time.Duration {
+	delay := base
+	for range attempts - 1 {
+		if delay >= maxOutboxRetryDelay {
+			break
+		}
+		delay *= 2
+	}
+
+	return min(delay, maxOutboxRetryDelay)
+}
@@ -0,0 +1,62 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrPasswordResetTooSoon is returned when the previous password reset of the
+// user was requested less than the minimum interval ago
+var ErrPasswordResetTooSoon = errors.New("password reset was requested too recently")
+
+type RequestPasswordResetTxParams struct {
+	Username   string
+	SecretCode string
+	// MinInterval is the minimum time between two password resets of the user
+	MinInterval time.Duration
+	// AfterCreate returns the tasks to enqueue for the new password reset,
+	// they are written to the outbox in the same transaction
+	AfterCreate func(passwordReset PasswordReset) ([]OutboxTask, error)
+}
+
+// RequestPasswordResetTx creates a password reset for the user's current
+// email, unless one was created less than MinInterval ago.
+// The user is locked until the transaction ends, so concurrent requests
+// cannot both create a password reset.
+func (store *SQLStore) RequestPasswordResetTx(ctx context.Context, arg RequestPasswordResetTxParams) (PasswordReset, error) {
+	var result PasswordReset
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		user, err := q.GetUserForUpdate(ctx, arg.Username)
+		if err != nil {
+			return err
+		}
+
+		latest, err := q.GetLatestPasswordReset(ctx, user.Username)
+		if err == nil && time.Since(latest.CreatedAt) < arg.MinInterval {
+			return ErrPasswordResetTooSoon
+		}
+		if err != nil && !errors.Is(err, ErrRecordNotFound) {
+			return err
+		}
+
+		result, err = q.CreatePasswordReset(ctx, CreatePasswordResetParams{
+			Username:   user.Username,
+			Email:      user.Email,
+			SecretCode: arg.SecretCode,
+		})
+		if err != nil {
+			return err
+		}
+
+		tasks, err := arg.AfterCreate(result)
+		if err != nil {
+			return err
+		}
+
+		return enqueueOutboxTasks(ctx, q, tasks...)
+	})
+
+	return result, err
+}
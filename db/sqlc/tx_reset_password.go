@@ -0,0 +1,90 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type ResetPasswordTxParams struct {
+	ResetID        int64
+	SecretCode     string
+	HashedPassword string
+	// Audit identifies where the reset comes from in the audit log, the
+	// actor is always the user whose password is reset
+	Audit AuditContext
+}
+
+type ResetPasswordTxResult struct {
+	User            User
+	PasswordReset   PasswordReset
+	RevokedSessions int64
+	RevokedAPIKeys  int64
+}
+
+// ResetPasswordTx consumes a password reset code, sets the new password,
+// blocks every session of the user and revokes all of their API keys.
+// The user is unlocked, as the reset proves they own the account, and the
+// reset is recorded in the audit log by the same transaction.
+func (store *SQLStore) ResetPasswordTx(ctx context.Context, arg ResetPasswordTxParams) (ResetPasswordTxResult, error) {
+	var result ResetPasswordTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.PasswordReset, err = q.UpdatePasswordReset(ctx, UpdatePasswordResetParams{
+			ID:         arg.ResetID,
+			SecretCode: arg.SecretCode,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.User, err = q.UpdateUser(ctx, UpdateUserParams{
+			Username: result.PasswordReset.Username,
+			HashedPassword: pgtype.Text{
+				String: arg.HashedPassword,
+				Valid:  true,
+			},
+			PasswordChangedAt: pgtype.Timestamptz{
+				Time:  time.Now(),
+				Valid: true,
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		result.User, err = q.UnlockUser(ctx, result.User.Username)
+		if err != nil {
+			return err
+		}
+
+		result.RevokedSessions, err = q.BlockUserSessions(ctx, result.User.Username)
+		if err != nil {
+			return err
+		}
+
+		result.RevokedAPIKeys, err = q.RevokeUserAPIKeys(ctx, result.User.Username)
+		if err != nil {
+			return err
+		}
+
+		audit := arg.Audit
+		audit.Actor = result.User.Username
+		_, err = appendAuditEvent(ctx, q, CreateAuditEventTxParams{
+			AuditContext: audit,
+			Action:       AuditActionPasswordReset,
+			Target:       AuditTarget("user", result.User.Username),
+			Details: map[string]any{
+				"password_reset_id": result.PasswordReset.ID,
+				"revoked_sessions":  result.RevokedSessions,
+				"revoked_api_keys":  result.RevokedAPIKeys,
+			},
+		})
+		return err
+	})
+
+	return result, err
+}
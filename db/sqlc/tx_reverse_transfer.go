@@ -0,0 +1,118 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"math"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrTransferAlreadyReversed = errors.New("transfer is already fully reversed")
+	ErrInvalidReversalAmount   = errors.New("reversal amount must be positive and not exceed the amount left to reverse")
+	ErrReverseReversal         = errors.New("a reversal cannot be reversed")
+)
+
+type ReverseTransferTxParams struct {
+	TransferID int64 `json:"transfer_id"`
+	// Amount to give back in the currency of the original sender, it must not exceed what is left
+	// of the original transfer after earlier partial reversals. Zero reverses everything that is left.
+	Amount int64 `json:"amount"`
+	// Audit identifies who reversed the transfer in the audit log
+	Audit AuditContext `json:"-"`
+}
+
+type ReverseTransferTxResult struct {
+	OriginalTransfer Transfer `json:"original_transfer"`
+	// ReversedAmount is the total amount reversed so far, including this reversal
+	ReversedAmount int64 `json:"reversed_amount"`
+	TransferTxResult
+}
+
+// ReverseTransferTx moves money back from the receiver to the sender of a transfer.
+// It creates the compensating transfer, linked to the original one through reversal_of, together with its entries
+// in a single database transaction. Several partial reversals are allowed as long as their sum stays within the original amount.
+// Cross-currency transfers are reversed at their original exchange rate.
+func (store *SQLStore) ReverseTransferTx(ctx context.Context, arg ReverseTransferTxParams) (ReverseTransferTxResult, error) {
+	var result ReverseTransferTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		// locking the original transfer serializes concurrent reversals of it
+		result.OriginalTransfer, err = q.GetTransferForUpdate(ctx, arg.TransferID)
+		if err != nil {
+			return err
+		}
+
+		original := result.OriginalTransfer
+		if original.ReversalOf.Valid {
+			return ErrReverseReversal
+		}
+
+		reversalOf := pgtype.Int8{
+			Int64: original.ID,
+			Valid: true,
+		}
+
+		totals, err := q.GetReversalTotals(ctx, reversalOf)
+		if err != nil {
+			return err
+		}
+
+		if totals.ReversedAmount >= original.Amount {
+			return ErrTransferAlreadyReversed
+		}
+
+		amount := arg.Amount
+		if amount == 0 {
+			amount = original.Amount - totals.ReversedAmount
+		}
+
+		if amount < 0 || totals.ReversedAmount+amount > original.Amount {
+			return ErrInvalidReversalAmount
+		}
+
+		result.ReversedAmount = totals.ReversedAmount + amount
+
+		// the receiver gives back its share of the amount it was credited, at the original rate.
+		// The last reversal takes whatever is left so that rounding never leaves money behind.
+		debit := original.ToAmount - totals.DebitedAmount
+		if result.ReversedAmount < original.Amount {
+			debit = int64(math.Round(float64(amount) * float64(original.ToAmount) / float64(original.Amount)))
+		}
+
+		result.Transfer, err = q.CreateReversalTransfer(ctx, CreateReversalTransferParams{
+			FromAccountID: original.ToAccountID,
+			ToAccountID:   original.FromAccountID,
+			Amount:        debit,
+			ToAmount:      amount,
+			ExchangeRate:  1 / original.ExchangeRate,
+			ReversalOf:    reversalOf,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = moveMoney(ctx, q, TransferTxParams{
+			FromAccountID: original.ToAccountID,
+			ToAccountID:   original.FromAccountID,
+			Amount:        debit,
+			ToAmount:      amount,
+		}, &result.TransferTxResult)
+		if err != nil {
+			return err
+		}
+
+		err = store.emitTransferCreated(ctx, q, result.TransferTxResult)
+		if err != nil {
+			return err
+		}
+
+		_, err = appendAuditEvent(ctx, q, newTransferAuditEvent(arg.Audit, AuditActionTransferReverse, result.Transfer))
+		return err
+	})
+
+	return result, err
+}
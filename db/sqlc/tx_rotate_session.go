@@ -0,0 +1,50 @@
+package db
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// ErrSessionRotated is returned when the refresh token of a session that was
+// already rotated is used again, which means the token has been leaked
+var ErrSessionRotated = errors.New("session already rotated")
+
+type RotateSessionTxParams struct {
+	SessionID uuid.UUID
+	// NewSession is the session of the new refresh token. Its family is
+	// inherited from the rotated session.
+	NewSession CreateSessionParams
+}
+
+type RotateSessionTxResult struct {
+	OldSession Session
+	Session    Session
+}
+
+// RotateSessionTx marks a session as rotated and creates the session of the
+// refresh token replacing it. A session can only be rotated once.
+func (store *SQLStore) RotateSessionTx(ctx context.Context, arg RotateSessionTxParams) (RotateSessionTxResult, error) {
+	var result RotateSessionTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.OldSession, err = q.RotateSession(ctx, arg.SessionID)
+		if err != nil {
+			if errors.Is(err, ErrRecordNotFound) {
+				return ErrSessionRotated
+			}
+			return err
+		}
+
+		newSession := arg.NewSession
+		newSession.FamilyID = result.OldSession.FamilyID
+
+		result.Session, err = q.CreateSession(ctx, newSession)
+		return err
+	})
+
+	return result, err
+}
@@ -0,0 +1,190 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/yelaco/simple-bank/util"
+)
+
+const (
+	ScheduledTransferStatusActive    = "active"
+	ScheduledTransferStatusCompleted = "completed"
+	ScheduledTransferStatusFailed    = "failed"
+	ScheduledTransferStatusCancelled = "cancelled"
+)
+
+// MaxScheduledTransferFailures is the number of consecutive failed runs after which a recurring transfer is given up
+const MaxScheduledTransferFailures = 3
+
+var ErrScheduledTransferNotDue = errors.New("scheduled transfer is not due")
+
+type ExecuteScheduledTransferTxResult struct {
+	ScheduledTransfer ScheduledTransfer `json:"scheduled_transfer"`
+	TransferTxResult
+}
+
+// ExecuteScheduledTransferTx runs a due scheduled transfer through the same queries as TransferTx
+// and moves the schedule to its next run, or completes it if it was a one-off transfer.
+// It returns ErrScheduledTransferNotDue if the schedule is not active or was already run for this period,
+// so executing the same run twice is harmless.
+// Nothing is recorded when the transfer fails, use FailScheduledTransferTx for that.
+func (store *SQLStore) ExecuteScheduledTransferTx(ctx context.Context, scheduledTransferID int64) (ExecuteScheduledTransferTxResult, error) {
+	var result ExecuteScheduledTransferTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		schedule, err := q.GetScheduledTransferForUpdate(ctx, scheduledTransferID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if schedule.Status != ScheduledTransferStatusActive || schedule.NextRunAt.After(now) {
+			return ErrScheduledTransferNotDue
+		}
+
+		result.TransferTxResult, err = store.transfer(ctx, q, TransferTxParams{
+			FromAccountID: schedule.FromAccountID,
+			ToAccountID:   schedule.ToAccountID,
+			Amount:        schedule.Amount,
+		})
+		if err != nil {
+			return err
+		}
+
+		status, nextRunAt := nextScheduledRun(schedule, now)
+
+		result.ScheduledTransfer, err = q.UpdateScheduledTransferRun(ctx, UpdateScheduledTransferRunParams{
+			ID:        schedule.ID,
+			Status:    status,
+			NextRunAt: nextRunAt,
+			LastRunAt: pgtype.Timestamptz{
+				Time:  now,
+				Valid: true,
+			},
+			LastTransferID: pgtype.Int8{
+				Int64: result.Transfer.ID,
+				Valid: true,
+			},
+			FailureCount: 0,
+		})
+		if err != nil {
+			return err
+		}
+
+		// Scheduled transfers run on behalf of their owner without a request
+		details := newTransferAuditDetails(result.Transfer)
+		details.ScheduledTransferID = schedule.ID
+
+		_, err = appendAuditEvent(ctx, q, CreateAuditEventTxParams{
+			AuditContext: AuditContext{Actor: AuditActorSystem},
+			Action:       AuditActionTransfer,
+			Target:       AuditTarget("transfer", result.Transfer.ID),
+			Details:      details,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+type FailScheduledTransferTxParams struct {
+	ID     int64  `json:"id"`
+	Reason string `json:"reason"`
+	// AfterFail returns the tasks to enqueue for the failed run, they are
+	// written to the outbox in the same transaction
+	AfterFail func(schedule ScheduledTransfer) ([]OutboxTask, error)
+}
+
+// FailScheduledTransferTx records a failed run of a due scheduled transfer and skips to its next run.
+// A one-off transfer, or a recurring one that failed MaxScheduledTransferFailures times in a row, is marked as failed.
+func (store *SQLStore) FailScheduledTransferTx(ctx context.Context, arg FailScheduledTransferTxParams) (ScheduledTransfer, error) {
+	var result ScheduledTransfer
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		schedule, err := q.GetScheduledTransferForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if schedule.Status != ScheduledTransferStatusActive || schedule.NextRunAt.After(now) {
+			return ErrScheduledTransferNotDue
+		}
+
+		failureCount := schedule.FailureCount + 1
+
+		status, nextRunAt := nextScheduledRun(schedule, now)
+		if status == ScheduledTransferStatusCompleted || failureCount >= MaxScheduledTransferFailures {
+			status = ScheduledTransferStatusFailed
+		}
+
+		result, err = q.UpdateScheduledTransferRun(ctx, UpdateScheduledTransferRunParams{
+			ID:        schedule.ID,
+			Status:    status,
+			NextRunAt: nextRunAt,
+			LastRunAt: pgtype.Timestamptz{
+				Time:  now,
+				Valid: true,
+			},
+			LastTransferID: schedule.LastTransferID,
+			LastError: pgtype.Text{
+				String: arg.Reason,
+				Valid:  true,
+			},
+			FailureCount: failureCount,
+		})
+		if err != nil {
+			return err
+		}
+
+		if arg.AfterFail == nil {
+			return nil
+		}
+		tasks, err := arg.AfterFail(result)
+		if err != nil {
+			return err
+		}
+
+		return enqueueOutboxTasks(ctx, q, tasks...)
+	})
+
+	return result, err
+}
+
+// nextScheduledRun returns the status and the next run time of a schedule after it ran at the given time.
+// Runs missed while the worker was down are skipped rather than executed in a burst.
+func nextScheduledRun(schedule ScheduledTransfer, now time.Time) (string, time.Time) {
+	nextRunAt := schedule.NextRunAt
+
+	for !nextRunAt.After(now) {
+		switch schedule.Frequency {
+		case util.FrequencyDaily:
+			nextRunAt = nextRunAt.AddDate(0, 0, 1)
+		case util.FrequencyWeekly:
+			nextRunAt = nextRunAt.AddDate(0, 0, 7)
+		case util.FrequencyMonthly:
+			nextRunAt = addMonthOnDay(nextRunAt, int(schedule.AnchorDay))
+		default:
+			return ScheduledTransferStatusCompleted, schedule.NextRunAt
+		}
+	}
+
+	return ScheduledTransferStatusActive, nextRunAt
+}
+
+// addMonthOnDay moves t to the given day of the following month in UTC,
+// or to the last day of that month if it is shorter, keeping the time of day.
+// Monthly runs are computed from the anchor day rather than the previous run,
+// so that a run clamped to a short month does not shift every later run.
+func addMonthOnDay(t time.Time, day int) time.Time {
+	t = t.UTC()
+	year, month, _ := t.Date()
+
+	// day 0 of the month after next is the last day of next month
+	lastDay := time.Date(year, month+2, 0, 0, 0, 0, 0, time.UTC).Day()
+
+	return time.Date(year, month+1, min(day, lastDay), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
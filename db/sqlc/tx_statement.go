@@ -0,0 +1,71 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type StatementTxParams struct {
+	AccountID int64     `json:"account_id"`
+	FromTime  time.Time `json:"from_time"`
+	ToTime    time.Time `json:"to_time"`
+}
+
+type StatementTxResult struct {
+	Account Account `json:"account"`
+	// OpeningBalance is the balance of the account at FromTime
+	OpeningBalance int64      `json:"opening_balance"`
+	Entries        []Entry    `json:"entries"`
+	Transfers      []Transfer `json:"transfers"`
+}
+
+// StatementTx reads everything needed to build the statement of an account for the period [FromTime, ToTime).
+// All queries run on the same snapshot, so the opening balance always adds up with the listed entries.
+func (store *SQLStore) StatementTx(ctx context.Context, arg StatementTxParams) (StatementTxResult, error) {
+	var result StatementTxResult
+
+	txOptions := pgx.TxOptions{
+		IsoLevel:   pgx.RepeatableRead,
+		AccessMode: pgx.ReadOnly,
+	}
+
+	err := store.execTxWithOptions(ctx, txOptions, func(q *Queries) error {
+		var err error
+
+		result.Account, err = q.GetAccount(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+
+		// the balance of an account is only changed through entries after it is opened,
+		// so the balance at FromTime is the current one minus everything booked since then
+		totalSince, err := q.GetEntriesTotalSince(ctx, GetEntriesTotalSinceParams{
+			AccountID: arg.AccountID,
+			Since:     arg.FromTime,
+		})
+		if err != nil {
+			return err
+		}
+		result.OpeningBalance = result.Account.Balance - totalSince
+
+		result.Entries, err = q.ListEntriesBetween(ctx, ListEntriesBetweenParams{
+			AccountID: arg.AccountID,
+			FromTime:  arg.FromTime,
+			ToTime:    arg.ToTime,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Transfers, err = q.ListTransfersBetween(ctx, ListTransfersBetweenParams{
+			AccountID: arg.AccountID,
+			FromTime:  arg.FromTime,
+			ToTime:    arg.ToTime,
+		})
+		return err
+	})
+
+	return result, err
+}
@@ -0,0 +1,62 @@
+package db
+
+import (
+	"context"
+)
+
+type EnableTOTPTxParams struct {
+	Username            string
+	HashedRecoveryCodes []string
+}
+
+// EnableTOTPTx confirms the pending TOTP enrolment of a user and replaces
+// their recovery codes with the given ones
+func (store *SQLStore) EnableTOTPTx(ctx context.Context, arg EnableTOTPTxParams) (User, error) {
+	var user User
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		user, err = q.EnableUserTOTP(ctx, arg.Username)
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteRecoveryCodes(ctx, arg.Username)
+		if err != nil {
+			return err
+		}
+
+		for _, hashedCode := range arg.HashedRecoveryCodes {
+			_, err = q.CreateRecoveryCode(ctx, CreateRecoveryCodeParams{
+				Username:   arg.Username,
+				HashedCode: hashedCode,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return user, err
+}
+
+// DisableTOTPTx removes the TOTP secret and the recovery codes of a user
+func (store *SQLStore) DisableTOTPTx(ctx context.Context, username string) (User, error) {
+	var user User
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		user, err = q.DisableUserTOTP(ctx, username)
+		if err != nil {
+			return err
+		}
+
+		return q.DeleteRecoveryCodes(ctx, username)
+	})
+
+	return user, err
+}
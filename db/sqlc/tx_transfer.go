@@ -5,7 +5,15 @@ import "context"
 type TransferTxParams struct {
 	FromAccountID int64 `json:"from_account_id"`
 	ToAccountID   int64 `json:"to_account_id"`
-	Amount        int64 `json:"amount"`
+	// Amount is debited in the currency of the from account
+	Amount int64 `json:"amount"`
+	// ToAmount is credited in the currency of the to account.
+	// It is only needed for cross-currency transfers, zero means the same as Amount.
+	ToAmount int64 `json:"to_amount"`
+	// ExchangeRate is the rate ToAmount was converted at, it is ignored when ToAmount is zero
+	ExchangeRate float64 `json:"exchange_rate"`
+	// Audit identifies who made the transfer in the audit log
+	Audit AuditContext `json:"-"`
 }
 
 type TransferTxResult struct {
@@ -18,47 +26,98 @@ type TransferTxResult struct {
 
 // TransferTx performs a money transfer from one account to the other
 // It creates a transfer record, add account entries, and update accounts' balance within a single database transaction
+// Accounts of different currencies are supported by giving the converted ToAmount and the ExchangeRate used,
+// both are recorded on the transfer.
+// The transfer is recorded in the audit log by the same transaction.
+// It returns ErrInsufficientFunds if the transfer would push the available balance of the source account below -overdraft_limit,
+// and ErrAccountClosed if either account is closed
 func (store *SQLStore) TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error) {
 	var result TransferTxResult
 
 	err := store.execTx(ctx, func(q *Queries) error {
 		var err error
 
-		result.Transfer, err = q.CreateTransfer(ctx, CreateTransferParams{
-			FromAccountID: arg.FromAccountID,
-			ToAccountID:   arg.ToAccountID,
-			Amount:        arg.Amount,
-		})
+		result, err = store.transfer(ctx, q, arg)
 		if err != nil {
 			return err
 		}
 
-		result.FromEntry, err = q.CreateEntry(ctx, CreateEntryParams{
-			AccountID: arg.FromAccountID,
-			Amount:    -arg.Amount,
-		})
-		if err != nil {
-			return err
-		}
+		_, err = appendAuditEvent(ctx, q, newTransferAuditEvent(arg.Audit, AuditActionTransfer, result.Transfer))
+		return err
+	})
 
-		result.ToEntry, err = q.CreateEntry(ctx, CreateEntryParams{
-			AccountID: arg.ToAccountID,
-			Amount:    arg.Amount,
-		})
-		if err != nil {
-			return err
-		}
+	return result, err
+}
 
-		if arg.FromAccountID < arg.ToAccountID {
-			result.FromAccount, result.ToAccount, err = addMoney(ctx, q, arg.FromAccountID, -arg.Amount, arg.ToAccountID, arg.Amount)
-		} else {
-			result.ToAccount, result.FromAccount, err = addMoney(ctx, q, arg.ToAccountID, arg.Amount, arg.FromAccountID, -arg.Amount)
-		}
+// transfer runs the queries of a money transfer using the given Queries,
+// so it can be shared by every transaction that moves money.
+// The transfer.created webhook event is emitted in the same transaction.
+func (store *SQLStore) transfer(ctx context.Context, q *Queries, arg TransferTxParams) (result TransferTxResult, err error) {
+	if arg.ToAmount == 0 {
+		arg.ToAmount = arg.Amount
+		arg.ExchangeRate = 1
+	}
 
-		return nil
+	result.Transfer, err = q.CreateTransfer(ctx, CreateTransferParams{
+		FromAccountID: arg.FromAccountID,
+		ToAccountID:   arg.ToAccountID,
+		Amount:        arg.Amount,
+		ToAmount:      arg.ToAmount,
+		ExchangeRate:  arg.ExchangeRate,
 	})
+	if err != nil {
+		return
+	}
 
-	return result, err
+	err = moveMoney(ctx, q, arg, &result)
+	if err != nil {
+		return
+	}
+
+	err = store.emitTransferCreated(ctx, q, result)
+	return
+}
+
+// moveMoney creates the account entries of a transfer record and updates the accounts' balance.
+// The from account is debited with arg.Amount and the to account is credited with arg.ToAmount.
+func moveMoney(ctx context.Context, q *Queries, arg TransferTxParams, result *TransferTxResult) (err error) {
+	result.FromEntry, err = q.CreateEntry(ctx, CreateEntryParams{
+		AccountID: arg.FromAccountID,
+		Amount:    -arg.Amount,
+	})
+	if err != nil {
+		return
+	}
+
+	result.ToEntry, err = q.CreateEntry(ctx, CreateEntryParams{
+		AccountID: arg.ToAccountID,
+		Amount:    arg.ToAmount,
+	})
+	if err != nil {
+		return
+	}
+
+	if arg.FromAccountID < arg.ToAccountID {
+		result.FromAccount, result.ToAccount, err = addMoney(ctx, q, arg.FromAccountID, -arg.Amount, arg.ToAccountID, arg.ToAmount)
+	} else {
+		result.ToAccount, result.FromAccount, err = addMoney(ctx, q, arg.ToAccountID, arg.ToAmount, arg.FromAccountID, -arg.Amount)
+	}
+	if err != nil {
+		return
+	}
+
+	// the updated rows stay locked until the transaction ends,
+	// so checking them here is safe against concurrent transfers and closes
+	if result.FromAccount.IsClosed || result.ToAccount.IsClosed {
+		err = ErrAccountClosed
+		return
+	}
+
+	if !result.FromAccount.canSpend() {
+		err = ErrInsufficientFunds
+	}
+
+	return
 }
 
 func addMoney(
@@ -0,0 +1,34 @@
+package db
+
+import (
+	"context"
+)
+
+type UpdateUserTxResult struct {
+	User           User
+	RevokedAPIKeys int64
+}
+
+// UpdateUserTx updates the user, and revokes all of their API keys when the
+// password is changed
+func (store *SQLStore) UpdateUserTx(ctx context.Context, arg UpdateUserParams) (UpdateUserTxResult, error) {
+	var result UpdateUserTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.User, err = q.UpdateUser(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		if !arg.PasswordChangedAt.Valid {
+			return nil
+		}
+
+		result.RevokedAPIKeys, err = q.RevokeUserAPIKeys(ctx, result.User.Username)
+		return err
+	})
+
+	return result, err
+}
@@ -37,8 +37,17 @@ func (store *SQLStore) VerifyEmailTx(ctx context.Context, arg VerifyEmailTxParam
 				Valid: true,
 			},
 		})
+		if err != nil {
+			return err
+		}
 
-		return err
+		data := WebhookEmailVerifiedData{
+			Username: result.User.Username,
+			Email:    result.User.Email,
+		}
+		return store.emitWebhookEvent(ctx, q, WebhookEventUserEmailVerified, []string{result.User.Username}, func(owner string) any {
+			return data
+		})
 	})
 
 	return result, err
@@ -0,0 +1,183 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Events delivered to webhook endpoints
+const (
+	WebhookEventTransferCreated   = "transfer.created"
+	WebhookEventAccountCreated    = "account.created"
+	WebhookEventUserEmailVerified = "user.email_verified"
+)
+
+// WebhookEventTypes lists the events an endpoint can subscribe to
+var WebhookEventTypes = []string{
+	WebhookEventTransferCreated,
+	WebhookEventAccountCreated,
+	WebhookEventUserEmailVerified,
+}
+
+const (
+	WebhookDeliveryStatusPending   = "pending"
+	WebhookDeliveryStatusSucceeded = "succeeded"
+	WebhookDeliveryStatusFailed    = "failed"
+)
+
+// WebhookDeliveryTaskFunc returns the task sending a webhook delivery, it is
+// written to the outbox in the same transaction as the delivery
+type WebhookDeliveryTaskFunc func(ctx context.Context, delivery WebhookDelivery) (OutboxTask, error)
+
+// WebhookEvent is the body of a webhook delivery
+type WebhookEvent struct {
+	ID        uuid.UUID `json:"id"`
+	Type      string    `json:"type"`
+	CreatedAt time.Time `json:"created_at"`
+	Data      any       `json:"data"`
+}
+
+// WebhookEmailVerifiedData is the data of the user.email_verified event
+type WebhookEmailVerifiedData struct {
+	Username string `json:"username"`
+	Email    string `json:"email"`
+}
+
+// emitWebhookEvent writes a delivery for every endpoint of the given owners
+// subscribed to the event, and their tasks to the outbox, using the given
+// Queries. data returns the data of the event sent to the endpoints of an
+// owner, so that nothing about other users is disclosed.
+func (store *SQLStore) emitWebhookEvent(ctx context.Context, q *Queries, eventType string, owners []string, data func(owner string) any) error {
+	endpoints, err := q.ListWebhookEndpointsForEvent(ctx, ListWebhookEndpointsForEventParams{
+		Owners:    owners,
+		EventType: eventType,
+	})
+	if err != nil {
+		return err
+	}
+
+	event := WebhookEvent{
+		ID:        uuid.New(),
+		Type:      eventType,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	for _, endpoint := range endpoints {
+		event.Data = data(endpoint.Owner)
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("failed to marshal webhook event: %w", err)
+		}
+
+		delivery, err := q.CreateWebhookDelivery(ctx, CreateWebhookDeliveryParams{
+			EndpointID: endpoint.ID,
+			EventID:    event.ID,
+			EventType:  event.Type,
+			Payload:    payload,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = store.enqueueWebhookDelivery(ctx, q, delivery)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// enqueueWebhookDelivery writes the task sending a delivery to the outbox
+func (store *SQLStore) enqueueWebhookDelivery(ctx context.Context, q *Queries, delivery WebhookDelivery) error {
+	task, err := store.webhookDeliveryTask(ctx, delivery)
+	if err != nil {
+		return fmt.Errorf("failed to create webhook delivery task: %w", err)
+	}
+
+	return enqueueOutboxTasks(ctx, q, task)
+}
+
+// emitTransferCreated sends the result of a transfer to the endpoints of the
+// owners of both accounts
+func (store *SQLStore) emitTransferCreated(ctx context.Context, q *Queries, result TransferTxResult) error {
+	owners := []string{result.FromAccount.Owner}
+	if result.ToAccount.Owner != result.FromAccount.Owner {
+		owners = append(owners, result.ToAccount.Owner)
+	}
+
+	return store.emitWebhookEvent(ctx, q, WebhookEventTransferCreated, owners, func(owner string) any {
+		return result.forOwner(owner)
+	})
+}
+
+// forOwner hides the balances of the accounts of the result not owned by the given user
+func (result TransferTxResult) forOwner(owner string) TransferTxResult {
+	if result.FromAccount.Owner != owner {
+		result.FromAccount = result.FromAccount.redacted()
+	}
+	if result.ToAccount.Owner != owner {
+		result.ToAccount = result.ToAccount.redacted()
+	}
+	return result
+}
+
+// redacted keeps what identifies the account
+func (account Account) redacted() Account {
+	return Account{
+		ID:       account.ID,
+		Owner:    account.Owner,
+		Currency: account.Currency,
+	}
+}
+
+// CreateAccountTx creates an account and emits the account.created event
+func (store *SQLStore) CreateAccountTx(ctx context.Context, arg CreateAccountParams) (Account, error) {
+	var result Account
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result, err = q.CreateAccount(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		return store.emitWebhookEvent(ctx, q, WebhookEventAccountCreated, []string{result.Owner}, func(owner string) any {
+			return result
+		})
+	})
+
+	return result, err
+}
+
+// ReplayWebhookDeliveryTx sends the event of a delivery again, as a new
+// delivery to the same endpoint
+func (store *SQLStore) ReplayWebhookDeliveryTx(ctx context.Context, deliveryID int64) (WebhookDelivery, error) {
+	var result WebhookDelivery
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		delivery, err := q.GetWebhookDelivery(ctx, deliveryID)
+		if err != nil {
+			return err
+		}
+
+		result, err = q.CreateWebhookDelivery(ctx, CreateWebhookDeliveryParams{
+			EndpointID: delivery.EndpointID,
+			EventID:    delivery.EventID,
+			EventType:  delivery.EventType,
+			Payload:    delivery.Payload,
+		})
+		if err != nil {
+			return err
+		}
+
+		return store.enqueueWebhookDelivery(ctx, q, result)
+	})
+
+	return result, err
+}
@@ -7,6 +7,7 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgtype"
 )
@@ -19,7 +20,7 @@ INSERT INTO users (
   email
 ) VALUES (
   $1, $2, $3, $4
-) RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role
+) RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role, totp_secret, is_totp_enabled, failed_login_attempts, lockout_count, locked_until, tokens_revoked_at
 `
 
 type CreateUserParams struct {
@@ -46,12 +47,80 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.CreatedAt,
 		&i.IsEmailVerified,
 		&i.Role,
+		&i.TotpSecret,
+		&i.IsTotpEnabled,
+		&i.FailedLoginAttempts,
+		&i.LockoutCount,
+		&i.LockedUntil,
+		&i.TokensRevokedAt,
+	)
+	return i, err
+}
+
+const disableUserTOTP = `-- name: DisableUserTOTP :one
+UPDATE users
+SET
+  totp_secret = NULL,
+  is_totp_enabled = false
+WHERE username = $1
+RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role, totp_secret, is_totp_enabled, failed_login_attempts, lockout_count, locked_until, tokens_revoked_at
+`
+
+func (q *Queries) DisableUserTOTP(ctx context.Context, username string) (User, error) {
+	row := q.db.QueryRow(ctx, disableUserTOTP, username)
+	var i User
+	err := row.Scan(
+		&i.Username,
+		&i.HashedPassword,
+		&i.FullName,
+		&i.Email,
+		&i.PasswordChangedAt,
+		&i.CreatedAt,
+		&i.IsEmailVerified,
+		&i.Role,
+		&i.TotpSecret,
+		&i.IsTotpEnabled,
+		&i.FailedLoginAttempts,
+		&i.LockoutCount,
+		&i.LockedUntil,
+		&i.TokensRevokedAt,
+	)
+	return i, err
+}
+
+const enableUserTOTP = `-- name: EnableUserTOTP :one
+UPDATE users
+SET is_totp_enabled = true
+WHERE username = $1
+  AND totp_secret IS NOT NULL
+  AND is_totp_enabled = false
+RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role, totp_secret, is_totp_enabled, failed_login_attempts, lockout_count, locked_until, tokens_revoked_at
+`
+
+func (q *Queries) EnableUserTOTP(ctx context.Context, username string) (User, error) {
+	row := q.db.QueryRow(ctx, enableUserTOTP, username)
+	var i User
+	err := row.Scan(
+		&i.Username,
+		&i.HashedPassword,
+		&i.FullName,
+		&i.Email,
+		&i.PasswordChangedAt,
+		&i.CreatedAt,
+		&i.IsEmailVerified,
+		&i.Role,
+		&i.TotpSecret,
+		&i.IsTotpEnabled,
+		&i.FailedLoginAttempts,
+		&i.LockoutCount,
+		&i.LockedUntil,
+		&i.TokensRevokedAt,
 	)
 	return i, err
 }
 
 const getUser = `-- name: GetUser :one
-SELECT username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role FROM users 
+SELECT username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role, totp_secret, is_totp_enabled, failed_login_attempts, lockout_count, locked_until, tokens_revoked_at FROM users 
 WHERE username = $1 LIMIT 1
 `
 
@@ -67,6 +136,224 @@ func (q *Queries) GetUser(ctx context.Context, username string) (User, error) {
 		&i.CreatedAt,
 		&i.IsEmailVerified,
 		&i.Role,
+		&i.TotpSecret,
+		&i.IsTotpEnabled,
+		&i.FailedLoginAttempts,
+		&i.LockoutCount,
+		&i.LockedUntil,
+		&i.TokensRevokedAt,
+	)
+	return i, err
+}
+
+const getUserByEmail = `-- name: GetUserByEmail :one
+SELECT username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role, totp_secret, is_totp_enabled, failed_login_attempts, lockout_count, locked_until, tokens_revoked_at FROM users
+WHERE email = $1 LIMIT 1
+`
+
+func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error) {
+	row := q.db.QueryRow(ctx, getUserByEmail, email)
+	var i User
+	err := row.Scan(
+		&i.Username,
+		&i.HashedPassword,
+		&i.FullName,
+		&i.Email,
+		&i.PasswordChangedAt,
+		&i.CreatedAt,
+		&i.IsEmailVerified,
+		&i.Role,
+		&i.TotpSecret,
+		&i.IsTotpEnabled,
+		&i.FailedLoginAttempts,
+		&i.LockoutCount,
+		&i.LockedUntil,
+		&i.TokensRevokedAt,
+	)
+	return i, err
+}
+
+const getUserForUpdate = `-- name: GetUserForUpdate :one
+SELECT username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role, totp_secret, is_totp_enabled, failed_login_attempts, lockout_count, locked_until, tokens_revoked_at FROM users
+WHERE username = $1 LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetUserForUpdate(ctx context.Context, username string) (User, error) {
+	row := q.db.QueryRow(ctx, getUserForUpdate, username)
+	var i User
+	err := row.Scan(
+		&i.Username,
+		&i.HashedPassword,
+		&i.FullName,
+		&i.Email,
+		&i.PasswordChangedAt,
+		&i.CreatedAt,
+		&i.IsEmailVerified,
+		&i.Role,
+		&i.TotpSecret,
+		&i.IsTotpEnabled,
+		&i.FailedLoginAttempts,
+		&i.LockoutCount,
+		&i.LockedUntil,
+		&i.TokensRevokedAt,
+	)
+	return i, err
+}
+
+const getUserTokenState = `-- name: GetUserTokenState :one
+SELECT password_changed_at, tokens_revoked_at FROM users
+WHERE username = $1 LIMIT 1
+`
+
+type GetUserTokenStateRow struct {
+	PasswordChangedAt time.Time `json:"password_changed_at"`
+	TokensRevokedAt   time.Time `json:"tokens_revoked_at"`
+}
+
+func (q *Queries) GetUserTokenState(ctx context.Context, username string) (GetUserTokenStateRow, error) {
+	row := q.db.QueryRow(ctx, getUserTokenState, username)
+	var i GetUserTokenStateRow
+	err := row.Scan(&i.PasswordChangedAt, &i.TokensRevokedAt)
+	return i, err
+}
+
+const incrementFailedLoginAttempts = `-- name: IncrementFailedLoginAttempts :one
+UPDATE users
+SET failed_login_attempts = failed_login_attempts + 1
+WHERE username = $1
+RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role, totp_secret, is_totp_enabled, failed_login_attempts, lockout_count, locked_until, tokens_revoked_at
+`
+
+func (q *Queries) IncrementFailedLoginAttempts(ctx context.Context, username string) (User, error) {
+	row := q.db.QueryRow(ctx, incrementFailedLoginAttempts, username)
+	var i User
+	err := row.Scan(
+		&i.Username,
+		&i.HashedPassword,
+		&i.FullName,
+		&i.Email,
+		&i.PasswordChangedAt,
+		&i.CreatedAt,
+		&i.IsEmailVerified,
+		&i.Role,
+		&i.TotpSecret,
+		&i.IsTotpEnabled,
+		&i.FailedLoginAttempts,
+		&i.LockoutCount,
+		&i.LockedUntil,
+		&i.TokensRevokedAt,
+	)
+	return i, err
+}
+
+const lockUser = `-- name: LockUser :one
+UPDATE users
+SET
+  failed_login_attempts = 0,
+  lockout_count = lockout_count + 1,
+  locked_until = $2
+WHERE username = $1
+RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role, totp_secret, is_totp_enabled, failed_login_attempts, lockout_count, locked_until, tokens_revoked_at
+`
+
+type LockUserParams struct {
+	Username    string             `json:"username"`
+	LockedUntil pgtype.Timestamptz `json:"locked_until"`
+}
+
+func (q *Queries) LockUser(ctx context.Context, arg LockUserParams) (User, error) {
+	row := q.db.QueryRow(ctx, lockUser, arg.Username, arg.LockedUntil)
+	var i User
+	err := row.Scan(
+		&i.Username,
+		&i.HashedPassword,
+		&i.FullName,
+		&i.Email,
+		&i.PasswordChangedAt,
+		&i.CreatedAt,
+		&i.IsEmailVerified,
+		&i.Role,
+		&i.TotpSecret,
+		&i.IsTotpEnabled,
+		&i.FailedLoginAttempts,
+		&i.LockoutCount,
+		&i.LockedUntil,
+		&i.TokensRevokedAt,
+	)
+	return i, err
+}
+
+const resetFailedLoginAttempts = `-- name: ResetFailedLoginAttempts :exec
+UPDATE users
+SET
+  failed_login_attempts = 0,
+  lockout_count = 0
+WHERE username = $1
+`
+
+func (q *Queries) ResetFailedLoginAttempts(ctx context.Context, username string) error {
+	_, err := q.db.Exec(ctx, resetFailedLoginAttempts, username)
+	return err
+}
+
+const revokeUserTokens = `-- name: RevokeUserTokens :one
+UPDATE users
+SET tokens_revoked_at = now()
+WHERE username = $1
+RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role, totp_secret, is_totp_enabled, failed_login_attempts, lockout_count, locked_until, tokens_revoked_at
+`
+
+func (q *Queries) RevokeUserTokens(ctx context.Context, username string) (User, error) {
+	row := q.db.QueryRow(ctx, revokeUserTokens, username)
+	var i User
+	err := row.Scan(
+		&i.Username,
+		&i.HashedPassword,
+		&i.FullName,
+		&i.Email,
+		&i.PasswordChangedAt,
+		&i.CreatedAt,
+		&i.IsEmailVerified,
+		&i.Role,
+		&i.TotpSecret,
+		&i.IsTotpEnabled,
+		&i.FailedLoginAttempts,
+		&i.LockoutCount,
+		&i.LockedUntil,
+		&i.TokensRevokedAt,
+	)
+	return i, err
+}
+
+const unlockUser = `-- name: UnlockUser :one
+UPDATE users
+SET
+  failed_login_attempts = 0,
+  lockout_count = 0,
+  locked_until = NULL
+WHERE username = $1
+RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role, totp_secret, is_totp_enabled, failed_login_attempts, lockout_count, locked_until, tokens_revoked_at
+`
+
+func (q *Queries) UnlockUser(ctx context.Context, username string) (User, error) {
+	row := q.db.QueryRow(ctx, unlockUser, username)
+	var i User
+	err := row.Scan(
+		&i.Username,
+		&i.HashedPassword,
+		&i.FullName,
+		&i.Email,
+		&i.PasswordChangedAt,
+		&i.CreatedAt,
+		&i.IsEmailVerified,
+		&i.Role,
+		&i.TotpSecret,
+		&i.IsTotpEnabled,
+		&i.FailedLoginAttempts,
+		&i.LockoutCount,
+		&i.LockedUntil,
+		&i.TokensRevokedAt,
 	)
 	return i, err
 }
@@ -81,7 +368,7 @@ SET
   is_email_verified = COALESCE($5, is_email_verified)
 WHERE
   username = $6
-RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role
+RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role, totp_secret, is_totp_enabled, failed_login_attempts, lockout_count, locked_until, tokens_revoked_at
 `
 
 type UpdateUserParams struct {
@@ -112,6 +399,83 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.CreatedAt,
 		&i.IsEmailVerified,
 		&i.Role,
+		&i.TotpSecret,
+		&i.IsTotpEnabled,
+		&i.FailedLoginAttempts,
+		&i.LockoutCount,
+		&i.LockedUntil,
+		&i.TokensRevokedAt,
+	)
+	return i, err
+}
+
+const updateUserRole = `-- name: UpdateUserRole :one
+UPDATE users
+SET
+  role = $2,
+  tokens_revoked_at = now()
+WHERE username = $1
+RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role, totp_secret, is_totp_enabled, failed_login_attempts, lockout_count, locked_until, tokens_revoked_at
+`
+
+type UpdateUserRoleParams struct {
+	Username string `json:"username"`
+	Role     string `json:"role"`
+}
+
+func (q *Queries) UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams) (User, error) {
+	row := q.db.QueryRow(ctx, updateUserRole, arg.Username, arg.Role)
+	var i User
+	err := row.Scan(
+		&i.Username,
+		&i.HashedPassword,
+		&i.FullName,
+		&i.Email,
+		&i.PasswordChangedAt,
+		&i.CreatedAt,
+		&i.IsEmailVerified,
+		&i.Role,
+		&i.TotpSecret,
+		&i.IsTotpEnabled,
+		&i.FailedLoginAttempts,
+		&i.LockoutCount,
+		&i.LockedUntil,
+		&i.TokensRevokedAt,
+	)
+	return i, err
+}
+
+const updateUserTOTPSecret = `-- name: UpdateUserTOTPSecret :one
+UPDATE users
+SET totp_secret = $2
+WHERE username = $1
+  AND is_totp_enabled = false
+RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role, totp_secret, is_totp_enabled, failed_login_attempts, lockout_count, locked_until, tokens_revoked_at
+`
+
+type UpdateUserTOTPSecretParams struct {
+	Username   string      `json:"username"`
+	TotpSecret pgtype.Text `json:"totp_secret"`
+}
+
+func (q *Queries) UpdateUserTOTPSecret(ctx context.Context, arg UpdateUserTOTPSecretParams) (User, error) {
+	row := q.db.QueryRow(ctx, updateUserTOTPSecret, arg.Username, arg.TotpSecret)
+	var i User
+	err := row.Scan(
+		&i.Username,
+		&i.HashedPassword,
+		&i.FullName,
+		&i.Email,
+		&i.PasswordChangedAt,
+		&i.CreatedAt,
+		&i.IsEmailVerified,
+		&i.Role,
+		&i.TotpSecret,
+		&i.IsTotpEnabled,
+		&i.FailedLoginAttempts,
+		&i.LockoutCount,
+		&i.LockedUntil,
+		&i.TokensRevokedAt,
 	)
 	return i, err
 }
@@ -144,3 +144,205 @@ func TestUpdateUserAllFields(t *testing.T) {
 	require.NotEqual(t, oldUser.FullName, updatedUser.FullName)
 	require.Equal(t, newFullName, updatedUser.FullName)
 }
+
+func TestUpdateUserTx(t *testing.T) {
+	user := createRandomUser(t)
+	createRandomAPIKey(t, user)
+
+	// API keys are kept when the password is not changed
+	result, err := testStore.UpdateUserTx(context.Background(), UpdateUserParams{
+		FullName: pgtype.Text{
+			String: util.RandomOwner(),
+			Valid:  true,
+		},
+		Username: user.Username,
+	})
+	require.NoError(t, err)
+	require.Zero(t, result.RevokedAPIKeys)
+
+	result, err = testStore.UpdateUserTx(context.Background(), UpdateUserParams{
+		HashedPassword: pgtype.Text{
+			String: util.RandomString(10),
+			Valid:  true,
+		},
+		PasswordChangedAt: pgtype.Timestamptz{
+			Time:  time.Now(),
+			Valid: true,
+		},
+		Username: user.Username,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), result.RevokedAPIKeys)
+
+	apiKeys, err := testStore.ListAPIKeys(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Empty(t, apiKeys)
+}
+
+func TestLogoutAllTx(t *testing.T) {
+	user := createRandomUser(t)
+	session := createRandomSession(t, user)
+	createRandomAPIKey(t, user)
+
+	result, err := testStore.LogoutAllTx(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), result.RevokedSessions)
+	require.Equal(t, int64(1), result.RevokedAPIKeys)
+	require.True(t, result.User.TokensRevokedAt.After(user.TokensRevokedAt))
+
+	session, err = testStore.GetSession(context.Background(), session.ID)
+	require.NoError(t, err)
+	require.True(t, session.IsBlocked)
+
+	apiKeys, err := testStore.ListAPIKeys(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Empty(t, apiKeys)
+}
+
+func TestResetPasswordTx(t *testing.T) {
+	user := createRandomUser(t)
+	session := createRandomSession(t, user)
+	createRandomAPIKey(t, user)
+
+	_, err := testStore.LockUser(context.Background(), LockUserParams{
+		Username:    user.Username,
+		LockedUntil: pgtype.Timestamptz{Time: time.Now().Add(time.Hour), Valid: true},
+	})
+	require.NoError(t, err)
+
+	passwordReset, err := testStore.CreatePasswordReset(context.Background(), CreatePasswordResetParams{
+		Username:   user.Username,
+		Email:      user.Email,
+		SecretCode: util.RandomString(32),
+	})
+	require.NoError(t, err)
+
+	arg := ResetPasswordTxParams{
+		ResetID:        passwordReset.ID,
+		SecretCode:     passwordReset.SecretCode,
+		HashedPassword: util.RandomString(10),
+	}
+
+	result, err := testStore.ResetPasswordTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.True(t, result.PasswordReset.IsUsed)
+	require.Equal(t, arg.HashedPassword, result.User.HashedPassword)
+	require.WithinDuration(t, time.Now(), result.User.PasswordChangedAt, time.Second)
+	require.Equal(t, int64(1), result.RevokedSessions)
+	require.Equal(t, int64(1), result.RevokedAPIKeys)
+	require.False(t, result.User.LockedUntil.Valid)
+	require.Zero(t, result.User.FailedLoginAttempts)
+
+	events, err := testStore.ListAuditEvents(context.Background(), ListAuditEventsParams{
+		Action: pgtype.Text{String: AuditActionPasswordReset, Valid: true},
+		Target: pgtype.Text{String: AuditTarget("user", user.Username), Valid: true},
+		Limit:  10,
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, user.Username, events[0].Actor)
+
+	session, err = testStore.GetSession(context.Background(), session.ID)
+	require.NoError(t, err)
+	require.True(t, session.IsBlocked)
+
+	apiKeys, err := testStore.ListAPIKeys(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Empty(t, apiKeys)
+
+	// the code can only be used once
+	_, err = testStore.ResetPasswordTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrRecordNotFound)
+}
+
+func TestRequestPasswordResetTx(t *testing.T) {
+	user := createRandomUser(t)
+
+	arg := RequestPasswordResetTxParams{
+		Username:    user.Username,
+		SecretCode:  util.RandomString(32),
+		MinInterval: time.Minute,
+		AfterCreate: func(passwordReset PasswordReset) ([]OutboxTask, error) {
+			return []OutboxTask{{
+				TaskType: "task:test",
+				Payload:  []byte(passwordReset.SecretCode),
+				Queue:    "critical",
+				MaxRetry: 10,
+			}}, nil
+		},
+	}
+
+	passwordReset, err := testStore.RequestPasswordResetTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, user.Username, passwordReset.Username)
+	require.Equal(t, user.Email, passwordReset.Email)
+	require.Equal(t, arg.SecretCode, passwordReset.SecretCode)
+	require.False(t, passwordReset.IsUsed)
+
+	// another reset within the interval is refused
+	arg.SecretCode = util.RandomString(32)
+	_, err = testStore.RequestPasswordResetTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrPasswordResetTooSoon)
+
+	latest, err := testStore.GetLatestPasswordReset(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Equal(t, passwordReset.ID, latest.ID)
+
+	// but allowed once it has passed
+	arg.MinInterval = 0
+	_, err = testStore.RequestPasswordResetTx(context.Background(), arg)
+	require.NoError(t, err)
+}
+
+func TestRecordFailedLoginTx(t *testing.T) {
+	user := createRandomUser(t)
+
+	locks := 0
+	arg := RecordFailedLoginTxParams{
+		Username:           user.Username,
+		ClientIP:           "127.0.0.1",
+		MaxAttempts:        3,
+		LockoutDuration:    time.Minute,
+		MaxLockoutDuration: 3 * time.Minute,
+		AfterLock: func(user User) ([]OutboxTask, error) {
+			locks++
+			return nil, nil
+		},
+	}
+
+	for i := range 2 {
+		result, err := testStore.RecordFailedLoginTx(context.Background(), arg)
+		require.NoError(t, err)
+		require.False(t, result.Locked)
+		require.Equal(t, int32(i+1), result.User.FailedLoginAttempts)
+	}
+
+	result, err := testStore.RecordFailedLoginTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.True(t, result.Locked)
+	require.Zero(t, result.User.FailedLoginAttempts)
+	require.Equal(t, int32(1), result.User.LockoutCount)
+	require.WithinDuration(t, time.Now().Add(time.Minute), result.User.LockedUntil.Time, time.Second)
+
+	// the second lockout lasts twice as long
+	for range 3 {
+		result, err = testStore.RecordFailedLoginTx(context.Background(), arg)
+		require.NoError(t, err)
+	}
+	require.True(t, result.Locked)
+	require.WithinDuration(t, time.Now().Add(2*time.Minute), result.User.LockedUntil.Time, time.Second)
+
+	// and is capped by the max duration
+	for range 3 {
+		result, err = testStore.RecordFailedLoginTx(context.Background(), arg)
+		require.NoError(t, err)
+	}
+	require.True(t, result.Locked)
+	require.WithinDuration(t, time.Now().Add(3*time.Minute), result.User.LockedUntil.Time, time.Second)
+	require.Equal(t, 3, locks)
+
+	user, err = testStore.UnlockUser(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.False(t, user.LockedUntil.Valid)
+	require.Zero(t, user.LockoutCount)
+}
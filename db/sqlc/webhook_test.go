@@ -0,0 +1,149 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/yelaco/simple-bank/util"
+)
+
+func createRandomWebhookEndpoint(t *testing.T, owner string, eventTypes ...string) WebhookEndpoint {
+	arg := CreateWebhookEndpointParams{
+		Owner:      owner,
+		Url:        "https://example.com/" + util.RandomString(8),
+		Secret:     util.RandomString(32),
+		EventTypes: eventTypes,
+	}
+
+	endpoint, err := testStore.CreateWebhookEndpoint(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, endpoint)
+
+	require.Equal(t, arg.Owner, endpoint.Owner)
+	require.Equal(t, arg.Url, endpoint.Url)
+	require.Equal(t, arg.Secret, endpoint.Secret)
+	require.Equal(t, arg.EventTypes, endpoint.EventTypes)
+	require.False(t, endpoint.DisabledAt.Valid)
+	require.NotZero(t, endpoint.CreatedAt)
+
+	return endpoint
+}
+
+func TestCreateWebhookEndpoint(t *testing.T) {
+	user := createRandomUser(t)
+	createRandomWebhookEndpoint(t, user.Username, WebhookEventTransferCreated)
+}
+
+func TestDisableWebhookEndpoint(t *testing.T) {
+	user := createRandomUser(t)
+	endpoint := createRandomWebhookEndpoint(t, user.Username, WebhookEventTransferCreated)
+
+	_, err := testStore.DisableWebhookEndpoint(context.Background(), DisableWebhookEndpointParams{
+		ID:    endpoint.ID,
+		Owner: util.RandomOwner(),
+	})
+	require.ErrorIs(t, err, ErrRecordNotFound)
+
+	disabled, err := testStore.DisableWebhookEndpoint(context.Background(), DisableWebhookEndpointParams{
+		ID:    endpoint.ID,
+		Owner: user.Username,
+	})
+	require.NoError(t, err)
+	require.True(t, disabled.DisabledAt.Valid)
+
+	endpoints, err := testStore.ListWebhookEndpoints(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Empty(t, endpoints)
+}
+
+func TestTransferTxEmitsWebhookEvent(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+
+	endpoint1 := createRandomWebhookEndpoint(t, account1.Owner, WebhookEventTransferCreated)
+	endpoint2 := createRandomWebhookEndpoint(t, account2.Owner, WebhookEventTransferCreated)
+	// not subscribed to transfers
+	endpoint3 := createRandomWebhookEndpoint(t, account1.Owner, WebhookEventAccountCreated)
+
+	result, err := testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+
+	deliveries1, err := testStore.ListWebhookDeliveries(context.Background(), ListWebhookDeliveriesParams{
+		EndpointID: endpoint1.ID,
+		Limit:      10,
+	})
+	require.NoError(t, err)
+	require.Len(t, deliveries1, 1)
+
+	delivery1 := deliveries1[0]
+	require.Equal(t, WebhookEventTransferCreated, delivery1.EventType)
+	require.Equal(t, WebhookDeliveryStatusPending, delivery1.Status)
+	require.Zero(t, delivery1.Attempts)
+
+	var event struct {
+		WebhookEvent
+		Data TransferTxResult `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(delivery1.Payload, &event))
+	require.Equal(t, delivery1.EventID, event.ID)
+	require.Equal(t, result.Transfer.ID, event.Data.Transfer.ID)
+	require.Equal(t, result.FromAccount.Balance, event.Data.FromAccount.Balance)
+	// the balance of the other user's account is not disclosed
+	require.Equal(t, account2.ID, event.Data.ToAccount.ID)
+	require.Zero(t, event.Data.ToAccount.Balance)
+
+	deliveries2, err := testStore.ListWebhookDeliveries(context.Background(), ListWebhookDeliveriesParams{
+		EndpointID: endpoint2.ID,
+		Limit:      10,
+	})
+	require.NoError(t, err)
+	require.Len(t, deliveries2, 1)
+	require.Equal(t, delivery1.EventID, deliveries2[0].EventID)
+
+	deliveries3, err := testStore.ListWebhookDeliveries(context.Background(), ListWebhookDeliveriesParams{
+		EndpointID: endpoint3.ID,
+		Limit:      10,
+	})
+	require.NoError(t, err)
+	require.Empty(t, deliveries3)
+}
+
+func TestReplayWebhookDeliveryTx(t *testing.T) {
+	user := createRandomUser(t)
+	endpoint := createRandomWebhookEndpoint(t, user.Username, WebhookEventAccountCreated)
+
+	account, err := testStore.CreateAccountTx(context.Background(), CreateAccountParams{
+		Owner:    user.Username,
+		Balance:  0,
+		Currency: util.RandomCurrency(),
+	})
+	require.NoError(t, err)
+
+	deliveries, err := testStore.ListWebhookDeliveries(context.Background(), ListWebhookDeliveriesParams{
+		EndpointID: endpoint.ID,
+		Limit:      10,
+	})
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+
+	var event struct {
+		WebhookEvent
+		Data Account `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(deliveries[0].Payload, &event))
+	require.Equal(t, account.ID, event.Data.ID)
+
+	replay, err := testStore.ReplayWebhookDeliveryTx(context.Background(), deliveries[0].ID)
+	require.NoError(t, err)
+	require.NotEqual(t, deliveries[0].ID, replay.ID)
+	require.Equal(t, deliveries[0].EndpointID, replay.EndpointID)
+	require.Equal(t, deliveries[0].EventID, replay.EventID)
+	require.Equal(t, deliveries[0].Payload, replay.Payload)
+	require.Equal(t, WebhookDeliveryStatusPending, replay.Status)
+}
@@ -0,0 +1,21 @@
+// Package fx provides foreign exchange rates used to move money between accounts of different currencies.
+// It defines the RateProvider interface.
+package fx
+
+import (
+	"context"
+	"errors"
+	"math"
+)
+
+var ErrRateNotFound = errors.New("exchange rate not found")
+
+type RateProvider interface {
+	// GetRate returns how many units of the "to" currency one unit of the "from" currency buys
+	GetRate(ctx context.Context, from string, to string) (float64, error)
+}
+
+// Convert converts an amount in minor units at the given rate, rounding to the nearest minor unit
+func Convert(amount int64, rate float64) int64 {
+	return int64(math.Round(float64(amount) * rate))
+}
@@ -0,0 +1,70 @@
+package fx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// StaticRateProvider serves a fixed set of exchange rates.
+// Rates are keyed by currency pair, e.g. "USD/EUR".
+// The inverse of a pair is used when only the opposite direction is known.
+type StaticRateProvider struct {
+	rates map[string]float64
+}
+
+func NewStaticRateProvider(rates map[string]float64) (RateProvider, error) {
+	provider := &StaticRateProvider{
+		rates: make(map[string]float64, len(rates)),
+	}
+
+	for pair, rate := range rates {
+		from, to, ok := strings.Cut(pair, "/")
+		if !ok || from == "" || to == "" {
+			return nil, fmt.Errorf("fx.NewStaticRateProvider: invalid currency pair %q", pair)
+		}
+		if rate <= 0 {
+			return nil, fmt.Errorf("fx.NewStaticRateProvider: rate of %s must be positive", pair)
+		}
+		provider.rates[pairKey(from, to)] = rate
+	}
+
+	return provider, nil
+}
+
+// NewFileRateProvider loads the rates from a JSON file mapping currency pairs to rates
+func NewFileRateProvider(path string) (RateProvider, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("fx.NewFileRateProvider: cannot read rates file: %w", err)
+	}
+
+	var rates map[string]float64
+	if err := json.Unmarshal(data, &rates); err != nil {
+		return nil, fmt.Errorf("fx.NewFileRateProvider: cannot parse rates file: %w", err)
+	}
+
+	return NewStaticRateProvider(rates)
+}
+
+func (provider *StaticRateProvider) GetRate(_ context.Context, from string, to string) (float64, error) {
+	if from == to {
+		return 1, nil
+	}
+
+	if rate, ok := provider.rates[pairKey(from, to)]; ok {
+		return rate, nil
+	}
+
+	if rate, ok := provider.rates[pairKey(to, from)]; ok {
+		return 1 / rate, nil
+	}
+
+	return 0, fmt.Errorf("%w: %s/%s", ErrRateNotFound, from, to)
+}
+
+func pairKey(from string, to string) string {
+	return from + "/" + to
+}
@@ -0,0 +1,64 @@
+package fx
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/yelaco/simple-bank/util"
+)
+
+func TestStaticRateProvider(t *testing.T) {
+	provider, err := NewStaticRateProvider(map[string]float64{
+		"USD/EUR": 0.8,
+	})
+	require.NoError(t, err)
+
+	rate, err := provider.GetRate(context.Background(), util.USD, util.EUR)
+	require.NoError(t, err)
+	require.Equal(t, 0.8, rate)
+
+	rate, err = provider.GetRate(context.Background(), util.EUR, util.USD)
+	require.NoError(t, err)
+	require.Equal(t, 1.25, rate)
+
+	rate, err = provider.GetRate(context.Background(), util.CAD, util.CAD)
+	require.NoError(t, err)
+	require.Equal(t, 1.0, rate)
+
+	_, err = provider.GetRate(context.Background(), util.USD, util.CAD)
+	require.ErrorIs(t, err, ErrRateNotFound)
+}
+
+func TestStaticRateProviderInvalidRates(t *testing.T) {
+	_, err := NewStaticRateProvider(map[string]float64{"USDEUR": 0.8})
+	require.Error(t, err)
+
+	_, err = NewStaticRateProvider(map[string]float64{"USD/EUR": 0})
+	require.Error(t, err)
+}
+
+func TestFileRateProvider(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rates.json")
+	err := os.WriteFile(path, []byte(`{"USD/CAD": 1.5}`), 0o600)
+	require.NoError(t, err)
+
+	provider, err := NewFileRateProvider(path)
+	require.NoError(t, err)
+
+	rate, err := provider.GetRate(context.Background(), util.USD, util.CAD)
+	require.NoError(t, err)
+	require.Equal(t, 1.5, rate)
+
+	_, err = NewFileRateProvider(filepath.Join(t.TempDir(), "missing.json"))
+	require.Error(t, err)
+}
+
+func TestConvert(t *testing.T) {
+	require.Equal(t, int64(92), Convert(100, 0.92))
+	require.Equal(t, int64(137), Convert(100, 1.37))
+	require.Equal(t, int64(1), Convert(1, 0.5))
+	require.Equal(t, int64(0), Convert(1, 0.4))
+}
@@ -0,0 +1,60 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	db "github.com/yelaco/simple-bank/db/sqlc"
+	"github.com/yelaco/simple-bank/token"
+)
+
+var errAPIKeyRevoked = errors.New("api key has been revoked")
+
+// verifyAPIKey maps an API key to the payload of an access token carrying the
+// scopes of the key. Keys are only checked against their own revocation time,
+// which changing or resetting the password and logging out of all sessions
+// set; the role is read from the user on every call, so role changes apply
+// without revoking them.
+func (server *Server) verifyAPIKey(ctx context.Context, key string) (*token.Payload, error) {
+	id, err := token.ParseAPIKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := server.store.GetAPIKey(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, token.ErrInvalidToken
+		}
+		return nil, fmt.Errorf("cannot get api key: %w", err)
+	}
+
+	apiKey := row.APIKey
+	if !token.CheckAPIKey(key, apiKey.HashedKey) {
+		return nil, token.ErrInvalidToken
+	}
+
+	if apiKey.RevokedAt.Valid {
+		return nil, errAPIKeyRevoked
+	}
+
+	if time.Now().After(apiKey.ExpiresAt) {
+		return nil, token.ErrExpiredToken
+	}
+
+	if err := server.store.TouchAPIKey(ctx, apiKey.ID); err != nil {
+		log.Error().Err(err).Str("api_key_id", apiKey.ID.String()).Msg("failed to update api key last used time")
+	}
+
+	return &token.Payload{
+		ID:        apiKey.ID,
+		Username:  apiKey.Username,
+		Role:      row.Role,
+		IssuedAt:  apiKey.CreatedAt,
+		ExpiredAt: apiKey.ExpiresAt,
+		Scopes:    apiKey.Scopes,
+	}, nil
+}
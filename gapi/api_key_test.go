@@ -0,0 +1,219 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/yelaco/simple-bank/db/mock"
+	db "github.com/yelaco/simple-bank/db/sqlc"
+	"github.com/yelaco/simple-bank/gen/pb/v1"
+	"github.com/yelaco/simple-bank/permission"
+	"github.com/yelaco/simple-bank/token"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestAPIKeyAuthorization(t *testing.T) {
+	user, _ := randomUser(t)
+	account := randomAccount(user.Username)
+
+	id, key, hashedKey, err := token.NewAPIKey()
+	require.NoError(t, err)
+
+	_, otherKey, _, err := token.NewAPIKey()
+	require.NoError(t, err)
+
+	apiKey := db.APIKey{
+		ID:        id,
+		Username:  user.Username,
+		Name:      "reporting",
+		HashedKey: hashedKey,
+		Scopes:    []string{permission.AccountsReadOwn},
+		ExpiresAt: time.Now().Add(24 * time.Hour),
+		CreatedAt: time.Now().Add(-time.Hour),
+	}
+
+	testCases := []struct {
+		name          string
+		key           string
+		buildStubs    func(store *mockdb.MockStore)
+		call          func(ctx context.Context, server *Server) error
+		checkResponse func(t *testing.T, err error)
+	}{
+		{
+			name: "OK",
+			key:  key,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAPIKey(gomock.Any(), gomock.Eq(id)).
+					Times(1).
+					Return(db.GetAPIKeyRow{APIKey: apiKey, Role: user.Role}, nil)
+				store.EXPECT().
+					TouchAPIKey(gomock.Any(), gomock.Eq(id)).
+					Times(1).
+					Return(nil)
+				store.EXPECT().
+					GetAccount(gomock.Any(), gomock.Eq(account.ID)).
+					Times(1).
+					Return(account, nil)
+			},
+			call: func(ctx context.Context, server *Server) error {
+				_, err := invoke(ctx, server, pb.SimpleBank_GetAccount_FullMethodName, &pb.GetAccountRequest{Id: account.ID}, server.GetAccount)
+				return err
+			},
+			checkResponse: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			// API keys are revoked on their own, so logging out every session
+			// or changing the role after the key was created does not reject it
+			name: "TokensRevokedAfterCreation",
+			key:  key,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserTokenState(gomock.Any(), gomock.Any()).
+					AnyTimes().
+					Return(db.GetUserTokenStateRow{TokensRevokedAt: time.Now()}, nil)
+				store.EXPECT().
+					GetAPIKey(gomock.Any(), gomock.Eq(id)).
+					Times(1).
+					Return(db.GetAPIKeyRow{APIKey: apiKey, Role: user.Role}, nil)
+				store.EXPECT().
+					TouchAPIKey(gomock.Any(), gomock.Eq(id)).
+					Times(1).
+					Return(nil)
+				store.EXPECT().
+					GetAccount(gomock.Any(), gomock.Eq(account.ID)).
+					Times(1).
+					Return(account, nil)
+			},
+			call: func(ctx context.Context, server *Server) error {
+				_, err := invoke(ctx, server, pb.SimpleBank_GetAccount_FullMethodName, &pb.GetAccountRequest{Id: account.ID}, server.GetAccount)
+				return err
+			},
+			checkResponse: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "OutOfScope",
+			key:  key,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAPIKey(gomock.Any(), gomock.Eq(id)).
+					Times(1).
+					Return(db.GetAPIKeyRow{APIKey: apiKey, Role: user.Role}, nil)
+				store.EXPECT().
+					TouchAPIKey(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+				store.EXPECT().
+					CreateAccountTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			call: func(ctx context.Context, server *Server) error {
+				_, err := invoke(ctx, server, pb.SimpleBank_CreateAccount_FullMethodName, &pb.CreateAccountRequest{Currency: account.Currency}, server.CreateAccount)
+				return err
+			},
+			checkResponse: func(t *testing.T, err error) {
+				requireStatusCode(t, codes.PermissionDenied, err)
+			},
+		},
+		{
+			name: "WrongSecret",
+			key:  key[:len(key)-40] + otherKey[len(otherKey)-40:],
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAPIKey(gomock.Any(), gomock.Eq(id)).
+					Times(1).
+					Return(db.GetAPIKeyRow{APIKey: apiKey, Role: user.Role}, nil)
+				store.EXPECT().
+					TouchAPIKey(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			call: func(ctx context.Context, server *Server) error {
+				_, err := invoke(ctx, server, pb.SimpleBank_GetAccount_FullMethodName, &pb.GetAccountRequest{Id: account.ID}, server.GetAccount)
+				return err
+			},
+			checkResponse: func(t *testing.T, err error) {
+				requireStatusCode(t, codes.Unauthenticated, err)
+			},
+		},
+		{
+			name: "Revoked",
+			key:  key,
+			buildStubs: func(store *mockdb.MockStore) {
+				revoked := apiKey
+				revoked.RevokedAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+				store.EXPECT().
+					GetAPIKey(gomock.Any(), gomock.Eq(id)).
+					Times(1).
+					Return(db.GetAPIKeyRow{APIKey: revoked, Role: user.Role}, nil)
+				store.EXPECT().
+					TouchAPIKey(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			call: func(ctx context.Context, server *Server) error {
+				_, err := invoke(ctx, server, pb.SimpleBank_GetAccount_FullMethodName, &pb.GetAccountRequest{Id: account.ID}, server.GetAccount)
+				return err
+			},
+			checkResponse: func(t *testing.T, err error) {
+				requireStatusCode(t, codes.Unauthenticated, err)
+			},
+		},
+		{
+			name: "Expired",
+			key:  key,
+			buildStubs: func(store *mockdb.MockStore) {
+				expired := apiKey
+				expired.ExpiresAt = time.Now().Add(-time.Minute)
+				store.EXPECT().
+					GetAPIKey(gomock.Any(), gomock.Eq(id)).
+					Times(1).
+					Return(db.GetAPIKeyRow{APIKey: expired, Role: user.Role}, nil)
+				store.EXPECT().
+					TouchAPIKey(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			call: func(ctx context.Context, server *Server) error {
+				_, err := invoke(ctx, server, pb.SimpleBank_GetAccount_FullMethodName, &pb.GetAccountRequest{Id: account.ID}, server.GetAccount)
+				return err
+			},
+			checkResponse: func(t *testing.T, err error) {
+				requireStatusCode(t, codes.Unauthenticated, err)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store, nil)
+
+			md := metadata.MD{
+				authorizationHeader: []string{fmt.Sprintf("%s %s", authorizationAPIKey, tc.key)},
+			}
+			ctx := metadata.NewIncomingContext(context.Background(), md)
+			tc.checkResponse(t, tc.call(ctx, server))
+		})
+	}
+}
+
+func requireStatusCode(t *testing.T, code codes.Code, err error) {
+	require.Error(t, err)
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, code, st.Code())
+}
@@ -0,0 +1,39 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/yelaco/simple-bank/db/sqlc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// auditContext identifies the actor of a request and where it comes from in
+// the audit log
+func (server *Server) auditContext(ctx context.Context, actor string) db.AuditContext {
+	mtdt := server.extractMetadata(ctx)
+
+	return db.AuditContext{
+		Actor:     actor,
+		ClientIP:  mtdt.ClientIP,
+		UserAgent: mtdt.UserAgent,
+		RequestID: mtdt.RequestID,
+	}
+}
+
+// recordAuditEvent appends an event to the audit log. The request fails when
+// the event cannot be recorded, so that clients never get the result of an
+// unrecorded action.
+func (server *Server) recordAuditEvent(ctx context.Context, audit db.AuditContext, action string, target string, details any) error {
+	_, err := server.store.CreateAuditEventTx(ctx, db.CreateAuditEventTxParams{
+		AuditContext: audit,
+		Action:       action,
+		Target:       target,
+		Details:      details,
+	})
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to record audit event: %s", err)
+	}
+
+	return nil
+}
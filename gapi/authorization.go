@@ -3,19 +3,49 @@ package gapi
 import (
 	"context"
 	"fmt"
-	"slices"
 	"strings"
 
+	"github.com/yelaco/simple-bank/permission"
 	"github.com/yelaco/simple-bank/token"
+	"github.com/yelaco/simple-bank/util"
 	"google.golang.org/grpc/metadata"
 )
 
 const (
 	authorizationHeader = "authorization"
 	authorizationBearer = "bearer"
+	authorizationAPIKey = "apikey"
 )
 
-func (server *Server) authorizeUser(ctx context.Context, accessibleRoles []string) (*token.Payload, error) {
+// authorization is the authenticated caller of an RPC together with the
+// permissions granted to their role
+type authorization struct {
+	*token.Payload
+	permissions permission.Set
+}
+
+// can reports whether the caller has the given permission
+func (auth *authorization) can(permission string) bool {
+	return auth.permissions.Has(permission)
+}
+
+// authorizationKey is the context key of the authenticated caller
+type authorizationKey struct{}
+
+// authorizationFromContext returns the caller authenticated by the
+// authorization interceptor. Handlers of public methods have no caller.
+func authorizationFromContext(ctx context.Context) (*authorization, error) {
+	auth, ok := ctx.Value(authorizationKey{}).(*authorization)
+	if !ok {
+		return nil, unauthenticatedError(fmt.Errorf("missing authorization"))
+	}
+
+	return auth, nil
+}
+
+// authorizeUser verifies the credentials in the authorization header and
+// loads the permissions of the caller
+func (server *Server) authorizeUser(ctx context.Context) (*authorization, error) {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
 		return nil, fmt.Errorf("missing metadata")
@@ -32,24 +62,51 @@ func (server *Server) authorizeUser(ctx context.Context, accessibleRoles []strin
 		return nil, fmt.Errorf("invalid authorization header format")
 	}
 
+	var payload *token.Payload
+	var err error
+
 	authType := strings.ToLower(fields[0])
-	if authType != authorizationBearer {
+	switch authType {
+	case authorizationBearer:
+		payload, err = server.tokenMaker.VerifyToken(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid access token: %s", err)
+		}
+
+		// Scoped access tokens are only issued to OAuth2 clients
+		if payload.Scopes != nil {
+			if err := server.checkOAuthToken(ctx, payload); err != nil {
+				return nil, fmt.Errorf("invalid access token: %s", err)
+			}
+		}
+
+		if err := server.userState.CheckToken(ctx, payload); err != nil {
+			return nil, fmt.Errorf("invalid access token: %s", err)
+		}
+	case authorizationAPIKey:
+		payload, err = server.verifyAPIKey(ctx, fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid api key: %s", err)
+		}
+	default:
 		return nil, fmt.Errorf("unsupported authorization type: %s", authType)
 	}
 
-	accessToken := fields[1]
-	payload, err := server.tokenMaker.VerifyToken(accessToken)
-	if err != nil {
-		return nil, fmt.Errorf("invalid access token: %s", err)
+	if payload.Role == util.MFAChallengeRole {
+		return nil, fmt.Errorf("mfa token cannot be used as access token")
 	}
 
-	if !hasPermission(payload.Role, accessibleRoles) {
-		return nil, fmt.Errorf("permission denied")
+	permissions, err := server.roles.RolePermissions(ctx, payload.Role)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load permissions: %s", err)
 	}
 
-	return payload, nil
-}
+	if payload.Scopes != nil {
+		permissions = permissions.Restrict(payload.Scopes)
+	}
 
-func hasPermission(userRole string, accessibleRoles []string) bool {
-	return slices.Contains(accessibleRoles, userRole)
+	return &authorization{
+		Payload:     payload,
+		permissions: permissions,
+	}, nil
 }
@@ -7,11 +7,180 @@ import (
 )
 
 func convertUser(user db.User) *pb.User {
-	return &pb.User{
+	rsp := &pb.User{
 		Username:          user.Username,
 		FullName:          user.FullName,
 		Email:             user.Email,
 		PasswordChangedAt: timestamppb.New(user.PasswordChangedAt),
 		CreatedAt:         timestamppb.New(user.CreatedAt),
+		IsTotpEnabled:     user.IsTotpEnabled,
 	}
+	if user.LockedUntil.Valid {
+		rsp.LockedUntil = timestamppb.New(user.LockedUntil.Time)
+	}
+	return rsp
+}
+
+func convertAccount(account db.Account) *pb.Account {
+	return &pb.Account{
+		Id:               account.ID,
+		Owner:            account.Owner,
+		Balance:          account.Balance,
+		Currency:         account.Currency,
+		IsClosed:         account.IsClosed,
+		CreatedAt:        timestamppb.New(account.CreateAt),
+		OverdraftLimit:   account.OverdraftLimit,
+		HeldAmount:       account.HeldAmount,
+		AvailableBalance: account.AvailableBalance(),
+	}
+}
+
+func convertTransfer(transfer db.Transfer) *pb.Transfer {
+	rsp := &pb.Transfer{
+		Id:            transfer.ID,
+		FromAccountId: transfer.FromAccountID,
+		ToAccountId:   transfer.ToAccountID,
+		Amount:        transfer.Amount,
+		ToAmount:      transfer.ToAmount,
+		ExchangeRate:  transfer.ExchangeRate,
+		CreatedAt:     timestamppb.New(transfer.CreatedAt),
+	}
+	if transfer.ReversalOf.Valid {
+		rsp.ReversalOf = &transfer.ReversalOf.Int64
+	}
+	return rsp
+}
+
+func convertEntry(entry db.Entry) *pb.Entry {
+	return &pb.Entry{
+		Id:        entry.ID,
+		AccountId: entry.AccountID,
+		Amount:    entry.Amount,
+		CreatedAt: timestamppb.New(entry.CreatedAt),
+	}
+}
+
+func convertHold(hold db.Hold) *pb.Hold {
+	rsp := &pb.Hold{
+		Id:          hold.ID,
+		AccountId:   hold.AccountID,
+		ToAccountId: hold.ToAccountID,
+		Amount:      hold.Amount,
+		Status:      hold.Status,
+		ExpiresAt:   timestamppb.New(hold.ExpiresAt),
+		CreatedAt:   timestamppb.New(hold.CreatedAt),
+	}
+	if hold.TransferID.Valid {
+		rsp.TransferId = &hold.TransferID.Int64
+	}
+	return rsp
+}
+
+func convertScheduledTransfer(scheduledTransfer db.ScheduledTransfer) *pb.ScheduledTransfer {
+	rsp := &pb.ScheduledTransfer{
+		Id:            scheduledTransfer.ID,
+		Owner:         scheduledTransfer.Owner,
+		FromAccountId: scheduledTransfer.FromAccountID,
+		ToAccountId:   scheduledTransfer.ToAccountID,
+		Amount:        scheduledTransfer.Amount,
+		Frequency:     scheduledTransfer.Frequency,
+		Status:        scheduledTransfer.Status,
+		NextRunAt:     timestamppb.New(scheduledTransfer.NextRunAt),
+		FailureCount:  scheduledTransfer.FailureCount,
+		CreatedAt:     timestamppb.New(scheduledTransfer.CreatedAt),
+	}
+	if scheduledTransfer.LastRunAt.Valid {
+		rsp.LastRunAt = timestamppb.New(scheduledTransfer.LastRunAt.Time)
+	}
+	if scheduledTransfer.LastTransferID.Valid {
+		rsp.LastTransferId = &scheduledTransfer.LastTransferID.Int64
+	}
+	if scheduledTransfer.LastError.Valid {
+		rsp.LastError = &scheduledTransfer.LastError.String
+	}
+	return rsp
+}
+
+func convertSession(session db.Session) *pb.Session {
+	return &pb.Session{
+		Id:        session.ID.String(),
+		Username:  session.Username,
+		UserAgent: session.UserAgent,
+		ClientIp:  session.ClientIp,
+		IsBlocked: session.IsBlocked,
+		ExpiresAt: timestamppb.New(session.ExpiresAt),
+		CreatedAt: timestamppb.New(session.CreatedAt),
+	}
+}
+
+func convertAPIKey(apiKey db.APIKey) *pb.APIKey {
+	rsp := &pb.APIKey{
+		Id:        apiKey.ID.String(),
+		Name:      apiKey.Name,
+		Scopes:    apiKey.Scopes,
+		ExpiresAt: timestamppb.New(apiKey.ExpiresAt),
+		CreatedAt: timestamppb.New(apiKey.CreatedAt),
+	}
+
+	if apiKey.LastUsedAt.Valid {
+		rsp.LastUsedAt = timestamppb.New(apiKey.LastUsedAt.Time)
+	}
+
+	return rsp
+}
+
+func convertOAuthClient(client db.OAuthClient) *pb.OAuthClient {
+	return &pb.OAuthClient{
+		ClientId:     client.ID,
+		Name:         client.Name,
+		RedirectUris: client.RedirectUris,
+		Scopes:       client.Scopes,
+		CreatedAt:    timestamppb.New(client.CreatedAt),
+	}
+}
+
+func convertAuditEvent(event db.AuditEvent) *pb.AuditEvent {
+	return &pb.AuditEvent{
+		Id:        event.ID,
+		Actor:     event.Actor,
+		Action:    event.Action,
+		Target:    event.Target,
+		Details:   string(event.Details),
+		ClientIp:  event.ClientIp,
+		UserAgent: event.UserAgent,
+		RequestId: event.RequestID,
+		PrevHash:  event.PrevHash,
+		Hash:      event.Hash,
+		CreatedAt: timestamppb.New(event.CreatedAt),
+	}
+}
+
+func convertWebhookEndpoint(endpoint db.WebhookEndpoint) *pb.WebhookEndpoint {
+	return &pb.WebhookEndpoint{
+		Id:         endpoint.ID,
+		Url:        endpoint.Url,
+		EventTypes: endpoint.EventTypes,
+		CreatedAt:  timestamppb.New(endpoint.CreatedAt),
+	}
+}
+
+func convertWebhookDelivery(delivery db.WebhookDelivery) *pb.WebhookDelivery {
+	rsp := &pb.WebhookDelivery{
+		Id:             delivery.ID,
+		EndpointId:     delivery.EndpointID,
+		EventId:        delivery.EventID.String(),
+		EventType:      delivery.EventType,
+		Payload:        string(delivery.Payload),
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		ResponseStatus: delivery.ResponseStatus.Int32,
+		LastError:      delivery.LastError.String,
+		CreatedAt:      timestamppb.New(delivery.CreatedAt),
+	}
+
+	if delivery.DeliveredAt.Valid {
+		rsp.DeliveredAt = timestamppb.New(delivery.DeliveredAt.Time)
+	}
+
+	return rsp
 }
@@ -0,0 +1,296 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/yelaco/simple-bank/db/mock"
+	"github.com/yelaco/simple-bank/gen/pb/v1"
+	"github.com/yelaco/simple-bank/permission"
+	"github.com/yelaco/simple-bank/token"
+	"github.com/yelaco/simple-bank/util"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+func TestMethodPermissionsCoverService(t *testing.T) {
+	var methodNames []string
+	for _, method := range pb.SimpleBank_ServiceDesc.Methods {
+		methodNames = append(methodNames, method.MethodName)
+	}
+	for _, stream := range pb.SimpleBank_ServiceDesc.Streams {
+		methodNames = append(methodNames, stream.StreamName)
+	}
+
+	for _, methodName := range methodNames {
+		fullMethod := "/" + pb.SimpleBank_ServiceDesc.ServiceName + "/" + methodName
+
+		_, registered := methodPermissions[fullMethod]
+		require.True(t, publicMethods[fullMethod] != registered, "%s must be either public or have a permission", fullMethod)
+	}
+}
+
+func TestAuthorizationInterceptor(t *testing.T) {
+	user, _ := randomUser(t)
+
+	testCases := []struct {
+		name          string
+		method        string
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, called bool, err error)
+	}{
+		{
+			name:   "OK",
+			method: pb.SimpleBank_GetAccount_FullMethodName,
+			buildStubs: func(store *mockdb.MockStore) {
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, util.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, called bool, err error) {
+				require.NoError(t, err)
+				require.True(t, called)
+			},
+		},
+		{
+			name:   "PublicMethod",
+			method: pb.SimpleBank_LoginUser_FullMethodName,
+			buildStubs: func(store *mockdb.MockStore) {
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return context.Background()
+			},
+			checkResponse: func(t *testing.T, called bool, err error) {
+				require.NoError(t, err)
+				require.True(t, called)
+			},
+		},
+		{
+			name:   "HealthCheck",
+			method: healthpb.Health_Check_FullMethodName,
+			buildStubs: func(store *mockdb.MockStore) {
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return context.Background()
+			},
+			checkResponse: func(t *testing.T, called bool, err error) {
+				require.NoError(t, err)
+				require.True(t, called)
+			},
+		},
+		{
+			name:   "NoAuthorization",
+			method: pb.SimpleBank_GetAccount_FullMethodName,
+			buildStubs: func(store *mockdb.MockStore) {
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return context.Background()
+			},
+			checkResponse: func(t *testing.T, called bool, err error) {
+				require.False(t, called)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+		{
+			name:   "MissingPermission",
+			method: pb.SimpleBank_UnlockUser_FullMethodName,
+			buildStubs: func(store *mockdb.MockStore) {
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, util.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, called bool, err error) {
+				require.False(t, called)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.PermissionDenied, st.Code())
+			},
+		},
+		{
+			name:   "CustomRole",
+			method: pb.SimpleBank_GetAccount_FullMethodName,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListRolePermissions(gomock.Any(), gomock.Eq("auditor")).
+					Times(1).
+					Return([]string{permission.AccountsReadAny}, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, "auditor", time.Minute)
+			},
+			checkResponse: func(t *testing.T, called bool, err error) {
+				require.NoError(t, err)
+				require.True(t, called)
+			},
+		},
+		{
+			name:   "UnknownRole",
+			method: pb.SimpleBank_GetAccount_FullMethodName,
+			buildStubs: func(store *mockdb.MockStore) {
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, "unknown", time.Minute)
+			},
+			checkResponse: func(t *testing.T, called bool, err error) {
+				require.False(t, called)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.PermissionDenied, st.Code())
+			},
+		},
+		{
+			name:   "MFAToken",
+			method: pb.SimpleBank_GetAccount_FullMethodName,
+			buildStubs: func(store *mockdb.MockStore) {
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, util.MFAChallengeRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, called bool, err error) {
+				require.False(t, called)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+		{
+			name:   "UnregisteredMethod",
+			method: "/pb.v1.SimpleBank/Unknown",
+			buildStubs: func(store *mockdb.MockStore) {
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, util.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, called bool, err error) {
+				require.False(t, called)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.PermissionDenied, st.Code())
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store, nil)
+
+			called := false
+			handler := func(ctx context.Context, req any) (any, error) {
+				called = true
+
+				// only handlers of public methods run without a caller
+				auth, err := authorizationFromContext(ctx)
+				if publicMethods[tc.method] {
+					require.Error(t, err)
+				} else {
+					require.NoError(t, err)
+					require.Equal(t, user.Username, auth.Username)
+				}
+				return nil, nil
+			}
+
+			ctx := tc.buildContext(t, server.tokenMaker)
+			info := &grpc.UnaryServerInfo{FullMethod: tc.method}
+			_, err := server.AuthorizationInterceptor(ctx, nil, info, handler)
+			tc.checkResponse(t, called, err)
+		})
+	}
+}
+
+// testServerStream is a server stream that only has a context
+type testServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (stream *testServerStream) Context() context.Context {
+	return stream.ctx
+}
+
+func TestStreamAuthorizationInterceptor(t *testing.T) {
+	user, _ := randomUser(t)
+
+	testCases := []struct {
+		name          string
+		method        string
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, called bool, err error)
+	}{
+		{
+			name:   "OK",
+			method: pb.SimpleBank_ListAccounts_FullMethodName,
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, util.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, called bool, err error) {
+				require.NoError(t, err)
+				require.True(t, called)
+			},
+		},
+		{
+			name:   "NoAuthorization",
+			method: pb.SimpleBank_ListAccounts_FullMethodName,
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return context.Background()
+			},
+			checkResponse: func(t *testing.T, called bool, err error) {
+				require.False(t, called)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+		{
+			name:   "UnregisteredMethod",
+			method: "/pb.v1.SimpleBank/Unknown",
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, util.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, called bool, err error) {
+				require.False(t, called)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.PermissionDenied, st.Code())
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			server := newTestServer(t, store, nil)
+
+			called := false
+			handler := func(srv any, stream grpc.ServerStream) error {
+				called = true
+
+				auth, err := authorizationFromContext(stream.Context())
+				require.NoError(t, err)
+				require.Equal(t, user.Username, auth.Username)
+				return nil
+			}
+
+			stream := &testServerStream{ctx: tc.buildContext(t, server.tokenMaker)}
+			info := &grpc.StreamServerInfo{FullMethod: tc.method, IsServerStream: true}
+			err := server.StreamAuthorizationInterceptor(server, stream, info, handler)
+			tc.checkResponse(t, called, err)
+		})
+	}
+}
@@ -33,6 +33,7 @@ func GrpcLogger(
 
 	logger.Str("protocol", "grpc").
 		Str("method", info.FullMethod).
+		Str("request_id", requestIDFromContext(ctx)).
 		Int("status_code", int(statusCode)).
 		Str("status_text", statusCode.String()).
 		Dur("duration", duration).
@@ -0,0 +1,119 @@
+package gapi
+
+import (
+	"context"
+	"time"
+
+	"github.com/hibiken/asynq"
+	db "github.com/yelaco/simple-bank/db/sqlc"
+	"github.com/yelaco/simple-bank/worker"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxLockoutDuration caps the exponential backoff of repeated lockouts
+const maxLockoutDuration = 24 * time.Hour
+
+// checkClientIP rejects logins from a client IP with too many recent failures,
+// whichever usernames they were for
+func (server *Server) checkClientIP(ctx context.Context, clientIP string) error {
+	if server.config.LoginMaxIPAttempts <= 0 {
+		return nil
+	}
+
+	failures, err := server.store.CountFailedLoginsByClientIP(ctx, db.CountFailedLoginsByClientIPParams{
+		ClientIp: clientIP,
+		Since:    time.Now().Add(-server.config.LoginAttemptWindow),
+	})
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to count failed logins: %s", err)
+	}
+
+	if failures >= server.config.LoginMaxIPAttempts {
+		return status.Errorf(codes.ResourceExhausted, "too many failed login attempts, try again later")
+	}
+
+	return nil
+}
+
+func checkUserLocked(user db.User) error {
+	if user.LockedUntil.Valid && time.Now().Before(user.LockedUntil.Time) {
+		return status.Errorf(codes.FailedPrecondition, "account is locked until %s", user.LockedUntil.Time.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+// recordUnknownUserLogin records a failed login for a username that does not
+// exist, so that it still counts toward the limit of the client IP
+func (server *Server) recordUnknownUserLogin(ctx context.Context, username string, clientIP string) error {
+	_, err := server.store.CreateFailedLogin(ctx, db.CreateFailedLoginParams{
+		Username: username,
+		ClientIp: clientIP,
+	})
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to record failed login: %s", err)
+	}
+
+	return server.recordFailedLoginEvent(ctx, username, clientIP, map[string]any{
+		"unknown_user": true,
+	})
+}
+
+// recordFailedLogin records a wrong password or second factor, locks the user
+// after too many consecutive failures and notifies them by email through the outbox
+func (server *Server) recordFailedLogin(ctx context.Context, user db.User, clientIP string) error {
+	result, err := server.store.RecordFailedLoginTx(ctx, db.RecordFailedLoginTxParams{
+		Username:           user.Username,
+		ClientIP:           clientIP,
+		MaxAttempts:        server.config.LoginMaxAttempts,
+		LockoutDuration:    server.config.LoginLockoutDuration,
+		MaxLockoutDuration: maxLockoutDuration,
+		AfterLock: func(user db.User) ([]db.OutboxTask, error) {
+			taskPayload := &worker.PayloadSendAccountLockedEmail{
+				Username: user.Username,
+				ClientIP: clientIP,
+			}
+			opts := []asynq.Option{
+				asynq.MaxRetry(10),
+				asynq.Queue(worker.QueueCritical),
+			}
+			task, err := worker.NewOutboxTask(ctx, worker.TaskSendAccountLockedEmail, taskPayload, opts...)
+			if err != nil {
+				return nil, err
+			}
+			return []db.OutboxTask{task}, nil
+		},
+	})
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to record failed login: %s", err)
+	}
+
+	return server.recordFailedLoginEvent(ctx, user.Username, clientIP, map[string]any{
+		"failed_attempts": result.User.FailedLoginAttempts,
+		"locked":          result.Locked,
+	})
+}
+
+// recordFailedLoginEvent records a failed login in the audit log. The actor is
+// the username that was tried, which may not exist.
+func (server *Server) recordFailedLoginEvent(ctx context.Context, username string, clientIP string, details map[string]any) error {
+	audit := server.auditContext(ctx, username)
+	audit.ClientIP = clientIP
+
+	return server.recordAuditEvent(ctx, audit, db.AuditActionLoginFailed, db.AuditTarget("user", username), details)
+}
+
+// resetFailedLogins clears the failure counters after a successful login
+func (server *Server) resetFailedLogins(ctx context.Context, user db.User) error {
+	if user.FailedLoginAttempts == 0 && user.LockoutCount == 0 {
+		return nil
+	}
+
+	err := server.store.ResetFailedLoginAttempts(ctx, user.Username)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to reset failed logins: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,248 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/yelaco/simple-bank/db/mock"
+	db "github.com/yelaco/simple-bank/db/sqlc"
+	"github.com/yelaco/simple-bank/gen/pb/v1"
+	"github.com/yelaco/simple-bank/worker"
+	mockwk "github.com/yelaco/simple-bank/worker/mock"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestLoginUserLockout(t *testing.T) {
+	user, password := randomUser(t)
+
+	lockedUser := user
+	lockedUser.LockedUntil = pgtype.Timestamptz{Time: time.Now().Add(time.Minute), Valid: true}
+
+	testCases := []struct {
+		name          string
+		password      string
+		buildStubs    func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor)
+		checkResponse func(t *testing.T, res *pb.LoginUserResponse, err error)
+	}{
+		{
+			name:     "WrongPassword",
+			password: "wrong-password",
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					CountFailedLoginsByClientIP(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(int64(0), nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					RecordFailedLoginTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.RecordFailedLoginTxParams) (db.RecordFailedLoginTxResult, error) {
+						require.Equal(t, user.Username, arg.Username)
+						require.Equal(t, int32(5), arg.MaxAttempts)
+						return db.RecordFailedLoginTxResult{User: user}, nil
+					})
+				taskDistributor.EXPECT().
+					DistributeTaskSendAccountLockedEmail(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.LoginUserResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+		{
+			name:     "WrongPasswordLocks",
+			password: "wrong-password",
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					CountFailedLoginsByClientIP(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(int64(0), nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					RecordFailedLoginTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.RecordFailedLoginTxParams) (db.RecordFailedLoginTxResult, error) {
+						tasks, err := arg.AfterLock(lockedUser)
+						require.NoError(t, err)
+						require.Len(t, tasks, 1)
+						require.Equal(t, worker.TaskSendAccountLockedEmail, tasks[0].TaskType)
+
+						var payload worker.PayloadSendAccountLockedEmail
+						require.NoError(t, json.Unmarshal(tasks[0].Payload, &payload))
+						require.Equal(t, user.Username, payload.Username)
+
+						return db.RecordFailedLoginTxResult{User: lockedUser, Locked: true}, nil
+					})
+				store.EXPECT().
+					CreateAuditEventTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.CreateAuditEventTxParams) (db.AuditEvent, error) {
+						require.Equal(t, db.AuditActionLoginFailed, arg.Action)
+						require.Equal(t, user.Username, arg.Actor)
+						require.Equal(t, db.AuditTarget("user", user.Username), arg.Target)
+						require.Equal(t, true, arg.Details.(map[string]any)["locked"])
+						return db.AuditEvent{}, nil
+					})
+				// The task is written to the outbox by the transaction
+				taskDistributor.EXPECT().
+					DistributeTaskSendAccountLockedEmail(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.LoginUserResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+		{
+			name:     "AuditFailure",
+			password: "wrong-password",
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					CountFailedLoginsByClientIP(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(int64(0), nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					RecordFailedLoginTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RecordFailedLoginTxResult{User: user}, nil)
+				store.EXPECT().
+					CreateAuditEventTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.AuditEvent{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, res *pb.LoginUserResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Internal, st.Code())
+			},
+		},
+		{
+			name:     "Locked",
+			password: password,
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					CountFailedLoginsByClientIP(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(int64(0), nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(lockedUser, nil)
+				store.EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.LoginUserResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.FailedPrecondition, st.Code())
+			},
+		},
+		{
+			name:     "ClientIPBlocked",
+			password: password,
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					CountFailedLoginsByClientIP(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(int64(20), nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.LoginUserResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.ResourceExhausted, st.Code())
+			},
+		},
+		{
+			name:     "SuccessResetsFailures",
+			password: password,
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				failedUser := user
+				failedUser.FailedLoginAttempts = 3
+				store.EXPECT().
+					CountFailedLoginsByClientIP(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(int64(0), nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(failedUser, nil)
+				store.EXPECT().
+					ResetFailedLoginAttempts(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(nil)
+				store.EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(randomSession(user.Username), nil)
+				store.EXPECT().
+					CreateAuditEventTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.CreateAuditEventTxParams) (db.AuditEvent, error) {
+						require.Equal(t, db.AuditActionLogin, arg.Action)
+						require.Equal(t, user.Username, arg.Actor)
+						return db.AuditEvent{}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, res *pb.LoginUserResponse, err error) {
+				require.NoError(t, err)
+				require.NotEmpty(t, res.GetAccessToken())
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+			store := mockdb.NewMockStore(storeCtrl)
+
+			taskCtrl := gomock.NewController(t)
+			defer taskCtrl.Finish()
+			taskDistributor := mockwk.NewMockTaskDistributor(taskCtrl)
+
+			tc.buildStubs(store, taskDistributor)
+
+			server := newTestServer(t, store, taskDistributor)
+			server.config.LoginMaxAttempts = 5
+			server.config.LoginLockoutDuration = time.Minute
+			server.config.LoginMaxIPAttempts = 20
+			server.config.LoginAttemptWindow = 15 * time.Minute
+
+			req := &pb.LoginUserRequest{
+				Username: user.Username,
+				Password: tc.password,
+			}
+			res, err := server.LoginUser(context.Background(), req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
@@ -7,20 +7,96 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/require"
+	mockdb "github.com/yelaco/simple-bank/db/mock"
 	db "github.com/yelaco/simple-bank/db/sqlc"
+	"github.com/yelaco/simple-bank/fx"
+	"github.com/yelaco/simple-bank/permission"
 	"github.com/yelaco/simple-bank/token"
 	"github.com/yelaco/simple-bank/util"
 	"github.com/yelaco/simple-bank/worker"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 )
 
+// rolePermissions mirrors the roles seeded by the migrations
+var rolePermissions = map[string][]string{
+	util.DepositorRole: {
+		permission.UsersUpdateOwn,
+		permission.AccountsCreateOwn,
+		permission.AccountsReadOwn,
+		permission.AccountsCloseOwn,
+		permission.StatementsSendOwn,
+		permission.TransfersCreateOwn,
+		permission.ScheduledTransfersCreateOwn,
+		permission.ScheduledTransfersCancelOwn,
+		permission.HoldsCreateOwn,
+		permission.HoldsCaptureOwn,
+		permission.HoldsVoidOwn,
+		permission.MFAManageOwn,
+		permission.APIKeysManageOwn,
+		permission.OAuthClientsManageOwn,
+		permission.SessionsReadOwn,
+		permission.SessionsRevokeOwn,
+		permission.WebhooksManageOwn,
+	},
+	util.BankerRole: {
+		permission.UsersUpdateAny,
+		permission.UsersUnlockAny,
+		permission.RolesAssignAny,
+		permission.AccountsCreateAny,
+		permission.AccountsReadAny,
+		permission.AccountsCloseAny,
+		permission.AccountsUpdateOverdraftAny,
+		permission.StatementsSendAny,
+		permission.TransfersCreateOwn,
+		permission.TransfersReverseAny,
+		permission.ScheduledTransfersCreateOwn,
+		permission.ScheduledTransfersCancelAny,
+		permission.HoldsCreateOwn,
+		permission.HoldsCaptureAny,
+		permission.HoldsVoidAny,
+		permission.MFAManageOwn,
+		permission.APIKeysManageOwn,
+		permission.OAuthClientsManageOwn,
+		permission.SessionsReadOwn,
+		permission.SessionsRevokeOwn,
+		permission.WebhooksManageOwn,
+		permission.AuditReadAny,
+	},
+}
+
 func newTestServer(t *testing.T, store db.Store, taskDistributor worker.TaskDistributor) *Server {
 	config := util.Config{
 		TokenSymmetricKey:   util.RandomString(32),
 		AccessTokenDuration: time.Minute,
 	}
 
-	server, err := NewServer(config, store, taskDistributor)
+	rateProvider, err := fx.NewStaticRateProvider(map[string]float64{
+		util.USD + "/" + util.EUR: 0.9,
+	})
+	require.NoError(t, err)
+
+	// access tokens are never revoked, roles have their default permissions
+	// and audit events are recorded unless a test expects otherwise
+	if mockStore, ok := store.(*mockdb.MockStore); ok {
+		mockStore.EXPECT().
+			GetUserTokenState(gomock.Any(), gomock.Any()).
+			AnyTimes().
+			Return(db.GetUserTokenStateRow{}, nil)
+		mockStore.EXPECT().
+			ListRolePermissions(gomock.Any(), gomock.Any()).
+			AnyTimes().
+			DoAndReturn(func(_ context.Context, role string) ([]string, error) {
+				return rolePermissions[role], nil
+			})
+		mockStore.EXPECT().
+			CreateAuditEventTx(gomock.Any(), gomock.Any()).
+			AnyTimes().
+			Return(db.AuditEvent{}, nil)
+	}
+
+	server, err := NewServer(config, store, taskDistributor, rateProvider)
 	require.NoError(t, err)
 
 	return server
@@ -37,3 +113,28 @@ func newContextWithBearerToken(t *testing.T, tokenMaker token.Maker, username st
 	}
 	return metadata.NewIncomingContext(context.Background(), md)
 }
+
+// invoke calls an RPC through the authorization interceptor, the way the gRPC
+// server does
+func invoke[Req any, Res any](
+	ctx context.Context,
+	server *Server,
+	method string,
+	req Req,
+	handler func(context.Context, Req) (Res, error),
+) (Res, error) {
+	info := &grpc.UnaryServerInfo{
+		Server:     server,
+		FullMethod: method,
+	}
+
+	res, err := server.AuthorizationInterceptor(ctx, req, info, func(ctx context.Context, req any) (any, error) {
+		return handler(ctx, req.(Req))
+	})
+	if err != nil {
+		var zero Res
+		return zero, err
+	}
+
+	return res.(Res), nil
+}
@@ -2,6 +2,8 @@ package gapi
 
 import (
 	"context"
+	"net"
+	"strings"
 
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
@@ -11,33 +13,74 @@ const (
 	grpcGatewayUserAgentHeader = "grpcgateway-user-agent"
 	userAgentHeader            = "user-agent"
 	xForwardedForHeader        = "x-forwarded-for"
+	requestIDHeader            = "x-request-id"
 )
 
 type Metadata struct {
 	UserAgent string
 	ClientIP  string
+	RequestID string
 }
 
 func (server *Server) extractMetadata(ctx context.Context) *Metadata {
 	mtdt := new(Metadata)
 
+	if p, ok := peer.FromContext(ctx); ok {
+		mtdt.ClientIP = hostOf(p.Addr.String())
+	}
+
 	if md, ok := metadata.FromIncomingContext(ctx); ok {
-		if userAgent := md.Get(grpcGatewayUserAgentHeader); len(userAgent) > 0 {
+		if userAgent := md.Get(userAgentHeader); len(userAgent) > 0 {
 			mtdt.UserAgent = userAgent[0]
 		}
 
-		if userAgent := md.Get(userAgentHeader); len(userAgent) > 0 {
-			mtdt.UserAgent = userAgent[0]
+		if requestID := md.Get(requestIDHeader); len(requestID) > 0 {
+			mtdt.RequestID = requestID[0]
 		}
 
-		if clientIPs := md.Get(xForwardedForHeader); len(clientIPs) > 0 {
-			mtdt.ClientIP = clientIPs[0]
+		// Requests proxied by the HTTP gateway come from the loopback address,
+		// the gateway forwards the address and user agent of the real client
+		if isGatewayPeer(mtdt.ClientIP) {
+			if userAgent := md.Get(grpcGatewayUserAgentHeader); len(userAgent) > 0 {
+				mtdt.UserAgent = userAgent[0]
+			}
+
+			if clientIP := lastForwardedFor(md.Get(xForwardedForHeader)); clientIP != "" {
+				mtdt.ClientIP = clientIP
+			}
 		}
 	}
 
-	if p, ok := peer.FromContext(ctx); ok {
-		mtdt.ClientIP = p.Addr.String()
+	return mtdt
+}
+
+// hostOf drops the port of an address, so that every connection of a client
+// is identified by the same IP
+func hostOf(address string) string {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return address
 	}
+	return host
+}
 
-	return mtdt
+// lastForwardedFor returns the address the gateway appended to the
+// X-Forwarded-For header. The entries before it are sent by the client and
+// cannot be trusted.
+func lastForwardedFor(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+
+	hops := strings.Split(values[len(values)-1], ",")
+	return hostOf(strings.TrimSpace(hops[len(hops)-1]))
+}
+
+func isGatewayPeer(address string) bool {
+	if address == "" {
+		return false
+	}
+
+	ip := net.ParseIP(hostOf(address))
+	return ip != nil && ip.IsLoopback()
 }
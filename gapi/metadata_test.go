@@ -0,0 +1,76 @@
+package gapi
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func newPeerContext(address string, md metadata.MD) context.Context {
+	ctx := context.Background()
+	if address != "" {
+		addr, _ := net.ResolveTCPAddr("tcp", address)
+		ctx = peer.NewContext(ctx, &peer.Peer{Addr: addr})
+	}
+	if md != nil {
+		ctx = metadata.NewIncomingContext(ctx, md)
+	}
+	return ctx
+}
+
+func TestExtractMetadataClientIP(t *testing.T) {
+	server := &Server{}
+
+	testCases := []struct {
+		name     string
+		ctx      context.Context
+		clientIP string
+	}{
+		{
+			name:     "DirectPeer",
+			ctx:      newPeerContext("203.0.113.7:51234", nil),
+			clientIP: "203.0.113.7",
+		},
+		{
+			name:     "DirectPeerOtherPort",
+			ctx:      newPeerContext("203.0.113.7:60001", nil),
+			clientIP: "203.0.113.7",
+		},
+		{
+			name:     "DirectPeerIgnoresForwardedFor",
+			ctx:      newPeerContext("203.0.113.7:51234", metadata.Pairs(xForwardedForHeader, "198.51.100.1")),
+			clientIP: "203.0.113.7",
+		},
+		{
+			name:     "Gateway",
+			ctx:      newPeerContext("127.0.0.1:40000", metadata.Pairs(xForwardedForHeader, "203.0.113.7")),
+			clientIP: "203.0.113.7",
+		},
+		{
+			name:     "GatewaySpoofedForwardedFor",
+			ctx:      newPeerContext("127.0.0.1:40000", metadata.Pairs(xForwardedForHeader, "198.51.100.1, 10.0.0.1, 203.0.113.7")),
+			clientIP: "203.0.113.7",
+		},
+		{
+			name:     "GatewayIPv6",
+			ctx:      newPeerContext("[::1]:40000", metadata.Pairs(xForwardedForHeader, "198.51.100.1, 2001:db8::1")),
+			clientIP: "2001:db8::1",
+		},
+		{
+			name:     "NoPeer",
+			ctx:      newPeerContext("", metadata.Pairs(xForwardedForHeader, "198.51.100.1")),
+			clientIP: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mtdt := server.extractMetadata(tc.ctx)
+			require.Equal(t, tc.clientIP, mtdt.ClientIP)
+		})
+	}
+}
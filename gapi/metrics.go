@@ -0,0 +1,81 @@
+package gapi
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/yelaco/simple-bank/metrics"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// GrpcMetrics records the count and latency of unary RPCs
+func GrpcMetrics(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	startTime := time.Now()
+	result, err := handler(ctx, req)
+	metrics.ObserveGRPCRequest(info.FullMethod, status.Code(err), time.Since(startTime))
+
+	return result, err
+}
+
+// GrpcStreamMetrics records the count and duration of streaming RPCs
+func GrpcStreamMetrics(
+	srv any,
+	stream grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	startTime := time.Now()
+	err := handler(srv, stream)
+	metrics.ObserveGRPCRequest(info.FullMethod, status.Code(err), time.Since(startTime))
+
+	return err
+}
+
+type httpRouteKey struct{}
+
+// HTTPMetrics records the count and latency of requests to the gateway
+func HTTPMetrics(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		startTime := time.Now()
+		rec := &ResponseRecorder{
+			ResponseWriter: res,
+			StatusCode:     http.StatusOK,
+		}
+
+		route := new(string)
+		req = req.WithContext(context.WithValue(req.Context(), httpRouteKey{}, route))
+		handler.ServeHTTP(rec, req)
+
+		// The gateway routes are all mounted under "/" of the ServeMux, their
+		// own pattern is recorded by GatewayRoute. The pattern of the other
+		// routes is set by the ServeMux while routing the request.
+		if *route == "" {
+			*route = req.Pattern
+		}
+		if *route == "" {
+			*route = "unmatched"
+		}
+		metrics.ObserveHTTPRequest(req.Method, *route, rec.StatusCode, time.Since(startTime))
+	})
+}
+
+// GatewayRoute is a gateway middleware recording the path pattern of the
+// matched route for HTTPMetrics
+func GatewayRoute(next runtime.HandlerFunc) runtime.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+		if route, ok := req.Context().Value(httpRouteKey{}).(*string); ok {
+			if pattern, ok := runtime.HTTPPattern(req.Context()); ok {
+				*route = pattern.String()
+			}
+		}
+		next(res, req, pathParams)
+	}
+}
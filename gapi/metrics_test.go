@@ -0,0 +1,43 @@
+package gapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/stretchr/testify/require"
+	"github.com/yelaco/simple-bank/metrics"
+)
+
+func TestHTTPMetricsRoute(t *testing.T) {
+	grpcMux := runtime.NewServeMux(runtime.WithMiddlewares(GatewayRoute))
+	handle := func(res http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+		res.WriteHeader(http.StatusOK)
+	}
+	require.NoError(t, grpcMux.HandlePath(http.MethodPost, "/v1/test_create_item", handle))
+	require.NoError(t, grpcMux.HandlePath(http.MethodGet, "/v1/test_items/{id}", handle))
+
+	mux := http.NewServeMux()
+	mux.Handle("/", grpcMux)
+	mux.HandleFunc("/test_health", func(res http.ResponseWriter, req *http.Request) {})
+
+	handler := HTTPMetrics(mux)
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodPost, "/v1/test_create_item", nil),
+		httptest.NewRequest(http.MethodGet, "/v1/test_items/1", nil),
+		httptest.NewRequest(http.MethodGet, "/v1/test_items/2", nil),
+		httptest.NewRequest(http.MethodGet, "/test_health", nil),
+	} {
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	recorder := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	body := recorder.Body.String()
+	require.Contains(t, body, `simple_bank_http_requests_total{code="200",method="POST",route="/v1/test_create_item"} 1`)
+	// path parameters don't create new series
+	require.Contains(t, body, `simple_bank_http_requests_total{code="200",method="GET",route="/v1/test_items/{id=*}"} 2`)
+	require.Contains(t, body, `simple_bank_http_requests_total{code="200",method="GET",route="/test_health"} 1`)
+}
@@ -0,0 +1,59 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/yelaco/simple-bank/db/sqlc"
+	"github.com/yelaco/simple-bank/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var errInvalidSecondFactor = errors.New("invalid two-factor code")
+
+// verifySecondFactor accepts either a code from the user's TOTP authenticator
+// or one of their unused recovery codes, which is consumed on success
+func (server *Server) verifySecondFactor(ctx context.Context, user db.User, code string) error {
+	if !user.IsTotpEnabled || !user.TotpSecret.Valid {
+		return errInvalidSecondFactor
+	}
+
+	if util.ValidateTOTPCode(code, user.TotpSecret.String) {
+		return nil
+	}
+
+	if !util.IsRecoveryCode(code) {
+		return errInvalidSecondFactor
+	}
+
+	recoveryCodes, err := server.store.ListUnusedRecoveryCodes(ctx, user.Username)
+	if err != nil {
+		return err
+	}
+
+	for _, recoveryCode := range recoveryCodes {
+		if util.CheckPassword(code, recoveryCode.HashedCode) != nil {
+			continue
+		}
+
+		_, err = server.store.UseRecoveryCode(ctx, recoveryCode.ID)
+		if err != nil {
+			if errors.Is(err, db.ErrRecordNotFound) {
+				// used concurrently by another request
+				return errInvalidSecondFactor
+			}
+			return err
+		}
+		return nil
+	}
+
+	return errInvalidSecondFactor
+}
+
+func secondFactorError(err error) error {
+	if errors.Is(err, errInvalidSecondFactor) {
+		return status.Errorf(codes.Unauthenticated, "%s", err)
+	}
+	return status.Errorf(codes.Internal, "failed to verify two-factor code: %s", err)
+}
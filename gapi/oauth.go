@@ -0,0 +1,146 @@
+package gapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	db "github.com/yelaco/simple-bank/db/sqlc"
+	"github.com/yelaco/simple-bank/token"
+)
+
+// Endpoints of the OAuth2 authorization server, served by the HTTP gateway
+const (
+	oauthAuthorizePath = "/oauth/authorize"
+	oauthTokenPath     = "/oauth/token"
+	oauthRevokePath    = "/oauth/revoke"
+)
+
+// Error codes defined by RFC 6749 and RFC 7009
+const (
+	oauthErrInvalidRequest          = "invalid_request"
+	oauthErrInvalidClient           = "invalid_client"
+	oauthErrInvalidGrant            = "invalid_grant"
+	oauthErrInvalidScope            = "invalid_scope"
+	oauthErrUnsupportedGrantType    = "unsupported_grant_type"
+	oauthErrUnsupportedResponseType = "unsupported_response_type"
+	oauthErrAccessDenied            = "access_denied"
+	oauthErrServerError             = "server_error"
+)
+
+var (
+	errOAuthTokenRevoked = errors.New("token has been revoked")
+	errOAuthClient       = errors.New("invalid client credentials")
+)
+
+// oauthError is the error response of the token and revocation endpoints
+type oauthError struct {
+	Code        string `json:"error"`
+	Description string `json:"error_description,omitempty"`
+}
+
+// RegisterOAuthHandlers serves the OAuth2 authorization code flow with PKCE and
+// the client credentials flow, so that registered third-party clients can
+// access the accounts of a user with their consent. Access tokens are regular
+// tokens of the user limited to the granted scopes.
+func (server *Server) RegisterOAuthHandlers(mux *http.ServeMux) {
+	mux.HandleFunc("GET "+oauthAuthorizePath, server.handleOAuthAuthorize)
+	mux.HandleFunc("POST "+oauthAuthorizePath, server.handleOAuthConsent)
+	mux.HandleFunc("POST "+oauthTokenPath, server.handleOAuthToken)
+	mux.HandleFunc("POST "+oauthRevokePath, server.handleOAuthRevoke)
+}
+
+// checkOAuthToken rejects access tokens issued to an OAuth2 client that have
+// been revoked since
+func (server *Server) checkOAuthToken(ctx context.Context, payload *token.Payload) error {
+	oauthToken, err := server.store.GetOAuthToken(ctx, payload.ID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return token.ErrInvalidToken
+		}
+		return fmt.Errorf("cannot get oauth token: %w", err)
+	}
+
+	if oauthToken.RevokedAt.Valid {
+		return errOAuthTokenRevoked
+	}
+
+	return nil
+}
+
+// authenticateOAuthClient checks the client credentials, which are sent either
+// with HTTP basic authentication or in the request body
+func (server *Server) authenticateOAuthClient(req *http.Request) (db.OAuthClient, error) {
+	clientID, secret, ok := req.BasicAuth()
+	if ok {
+		// RFC 6749 form-encodes the credentials before they are base64 encoded
+		var err error
+		if clientID, err = url.QueryUnescape(clientID); err != nil {
+			return db.OAuthClient{}, errOAuthClient
+		}
+		if secret, err = url.QueryUnescape(secret); err != nil {
+			return db.OAuthClient{}, errOAuthClient
+		}
+	} else {
+		clientID = req.PostFormValue("client_id")
+		secret = req.PostFormValue("client_secret")
+	}
+
+	if clientID == "" || secret == "" {
+		return db.OAuthClient{}, errOAuthClient
+	}
+
+	client, err := server.store.GetOAuthClient(req.Context(), clientID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return db.OAuthClient{}, errOAuthClient
+		}
+		return db.OAuthClient{}, err
+	}
+
+	if !token.CheckOAuthSecret(secret, client.HashedSecret) {
+		return db.OAuthClient{}, errOAuthClient
+	}
+
+	return client, nil
+}
+
+// parseScopes parses a space-delimited scope parameter. The client is granted
+// its registered scopes when the parameter is omitted.
+func parseScopes(value string, client db.OAuthClient) ([]string, error) {
+	scopes := strings.Fields(value)
+	if len(scopes) == 0 {
+		return client.Scopes, nil
+	}
+
+	slices.Sort(scopes)
+	scopes = slices.Compact(scopes)
+
+	for _, scope := range scopes {
+		if !slices.Contains(client.Scopes, scope) {
+			return nil, fmt.Errorf("scope %s is not allowed for this client", scope)
+		}
+	}
+
+	return scopes, nil
+}
+
+func writeOAuthJSON(res http.ResponseWriter, statusCode int, body any) {
+	res.Header().Set("Content-Type", "application/json")
+	res.Header().Set("Cache-Control", "no-store")
+	res.Header().Set("Pragma", "no-cache")
+	res.WriteHeader(statusCode)
+	_ = json.NewEncoder(res).Encode(body)
+}
+
+func writeOAuthError(res http.ResponseWriter, statusCode int, code string, description string) {
+	if code == oauthErrInvalidClient {
+		res.Header().Set("WWW-Authenticate", `Basic realm="simple-bank"`)
+	}
+	writeOAuthJSON(res, statusCode, oauthError{Code: code, Description: description})
+}
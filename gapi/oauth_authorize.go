@@ -0,0 +1,332 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"html/template"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	db "github.com/yelaco/simple-bank/db/sqlc"
+	"github.com/yelaco/simple-bank/token"
+	"github.com/yelaco/simple-bank/util"
+	"google.golang.org/grpc/status"
+)
+
+// authorizationCodeDuration is how long an authorization code can be exchanged
+// for an access token
+const authorizationCodeDuration = 10 * time.Minute
+
+const codeChallengeMethodS256 = "S256"
+
+var consentTemplate = template.Must(template.New("consent").Parse(`<!DOCTYPE html>
+<html>
+<head>
+  <meta charset="utf-8">
+  <title>Authorize {{.Client.Name}} - Simple Bank</title>
+</head>
+<body>
+  <h1>{{.Client.Name}} wants to access your Simple Bank account</h1>
+  <p>If you approve, it will be allowed to:</p>
+  <ul>
+    {{range .Scopes}}<li>{{.}}</li>{{end}}
+  </ul>
+  {{if .Error}}<p role="alert"><strong>{{.Error}}</strong></p>{{end}}
+  <form method="post" action="{{.Action}}">
+    <input type="hidden" name="response_type" value="code">
+    <input type="hidden" name="client_id" value="{{.Client.ID}}">
+    <input type="hidden" name="redirect_uri" value="{{.RedirectURI}}">
+    <input type="hidden" name="scope" value="{{.Scope}}">
+    <input type="hidden" name="state" value="{{.State}}">
+    <input type="hidden" name="code_challenge" value="{{.CodeChallenge}}">
+    <input type="hidden" name="code_challenge_method" value="S256">
+    <p><label>Username <input name="username" value="{{.Username}}" autocomplete="username" required></label></p>
+    <p><label>Password <input name="password" type="password" autocomplete="current-password" required></label></p>
+    <p><label>Two-factor code, if enabled <input name="otp" autocomplete="one-time-code"></label></p>
+    <button type="submit" name="consent" value="approve">Approve</button>
+    <button type="submit" name="consent" value="deny" formnovalidate>Deny</button>
+  </form>
+</body>
+</html>
+`))
+
+type consentPage struct {
+	Client        db.OAuthClient
+	Scopes        []string
+	Scope         string
+	RedirectURI   string
+	State         string
+	CodeChallenge string
+	Username      string
+	Error         string
+	Action        string
+}
+
+// authorizeRequest is a validated authorization request of the authorization
+// code flow
+type authorizeRequest struct {
+	client        db.OAuthClient
+	redirectURI   string
+	scopes        []string
+	state         string
+	codeChallenge string
+}
+
+// authorizeRequestError is an invalid authorization request. Errors are sent
+// back to the client through the redirect URI once it is known to be valid,
+// otherwise they are shown to the user.
+type authorizeRequestError struct {
+	redirectURI string
+	state       string
+	code        string
+	description string
+}
+
+func (err *authorizeRequestError) Error() string {
+	return err.description
+}
+
+func (server *Server) handleOAuthAuthorize(res http.ResponseWriter, req *http.Request) {
+	authReq, err := server.parseAuthorizeRequest(req.Context(), req.URL.Query())
+	if err != nil {
+		writeAuthorizeError(res, req, err)
+		return
+	}
+
+	server.renderConsent(res, http.StatusOK, authReq, "", "")
+}
+
+func (server *Server) handleOAuthConsent(res http.ResponseWriter, req *http.Request) {
+	if err := req.ParseForm(); err != nil {
+		http.Error(res, "invalid form", http.StatusBadRequest)
+		return
+	}
+
+	authReq, err := server.parseAuthorizeRequest(req.Context(), req.PostForm)
+	if err != nil {
+		writeAuthorizeError(res, req, err)
+		return
+	}
+
+	if req.PostForm.Get("consent") != "approve" {
+		redirectWithError(res, req, authReq.redirectURI, authReq.state, oauthErrAccessDenied, "the user denied the request")
+		return
+	}
+
+	username := req.PostForm.Get("username")
+	user, err := server.authenticateConsent(req.Context(), username, req.PostForm.Get("password"), req.PostForm.Get("otp"), httpClientIP(req))
+	if err != nil {
+		server.renderConsent(res, http.StatusUnauthorized, authReq, username, status.Convert(err).Message())
+		return
+	}
+
+	audit := db.AuditContext{
+		Actor:     user.Username,
+		ClientIP:  httpClientIP(req),
+		UserAgent: req.UserAgent(),
+		RequestID: req.Header.Get(requestIDHeader),
+	}
+	err = server.recordAuditEvent(req.Context(), audit, db.AuditActionLogin, db.AuditTarget("user", user.Username), map[string]any{
+		"oauth_client_id": authReq.client.ID,
+	})
+	if err != nil {
+		http.Error(res, "failed to record login", http.StatusInternalServerError)
+		return
+	}
+
+	code, hashedCode, err := token.NewAuthorizationCode()
+	if err != nil {
+		http.Error(res, "failed to generate authorization code", http.StatusInternalServerError)
+		return
+	}
+
+	_, err = server.store.CreateOAuthAuthorizationCode(req.Context(), db.CreateOAuthAuthorizationCodeParams{
+		HashedCode:    hashedCode,
+		ClientID:      authReq.client.ID,
+		Username:      user.Username,
+		RedirectUri:   authReq.redirectURI,
+		Scopes:        authReq.scopes,
+		CodeChallenge: authReq.codeChallenge,
+		ExpiresAt:     time.Now().Add(authorizationCodeDuration),
+	})
+	if err != nil {
+		redirectWithError(res, req, authReq.redirectURI, authReq.state, oauthErrServerError, "failed to create authorization code")
+		return
+	}
+
+	redirect(res, req, authReq.redirectURI, url.Values{
+		"code":  {code},
+		"state": {authReq.state},
+	})
+}
+
+func (server *Server) parseAuthorizeRequest(ctx context.Context, params url.Values) (*authorizeRequest, error) {
+	client, err := server.store.GetOAuthClient(ctx, params.Get("client_id"))
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, &authorizeRequestError{code: oauthErrInvalidClient, description: "unknown client"}
+		}
+		return nil, &authorizeRequestError{code: oauthErrServerError, description: "failed to get client"}
+	}
+
+	// The redirect URI must be registered before anything is sent to it
+	redirectURI := params.Get("redirect_uri")
+	if redirectURI == "" && len(client.RedirectUris) == 1 {
+		redirectURI = client.RedirectUris[0]
+	}
+	if !slices.Contains(client.RedirectUris, redirectURI) {
+		return nil, &authorizeRequestError{code: oauthErrInvalidRequest, description: "redirect_uri is not registered for this client"}
+	}
+
+	state := params.Get("state")
+	invalid := func(code string, description string) error {
+		return &authorizeRequestError{
+			redirectURI: redirectURI,
+			state:       state,
+			code:        code,
+			description: description,
+		}
+	}
+
+	if params.Get("response_type") != "code" {
+		return nil, invalid(oauthErrUnsupportedResponseType, "response_type must be code")
+	}
+
+	// PKCE is required for every client, with the S256 method only
+	codeChallenge := params.Get("code_challenge")
+	if codeChallenge == "" || params.Get("code_challenge_method") != codeChallengeMethodS256 {
+		return nil, invalid(oauthErrInvalidRequest, "code_challenge with the S256 method is required")
+	}
+
+	scopes, err := parseScopes(params.Get("scope"), client)
+	if err != nil {
+		return nil, invalid(oauthErrInvalidScope, err.Error())
+	}
+
+	return &authorizeRequest{
+		client:        client,
+		redirectURI:   redirectURI,
+		scopes:        scopes,
+		state:         state,
+		codeChallenge: codeChallenge,
+	}, nil
+}
+
+// authenticateConsent signs the user in on the consent screen, with the same
+// lockout and second factor rules as LoginUser
+func (server *Server) authenticateConsent(ctx context.Context, username string, password string, otp string, clientIP string) (db.User, error) {
+	if err := server.checkClientIP(ctx, clientIP); err != nil {
+		return db.User{}, err
+	}
+
+	user, err := server.store.GetUser(ctx, username)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			if err := server.recordUnknownUserLogin(ctx, username, clientIP); err != nil {
+				return db.User{}, err
+			}
+			return db.User{}, errors.New("incorrect username or password")
+		}
+		return db.User{}, errors.New("failed to find user")
+	}
+
+	if err := checkUserLocked(user); err != nil {
+		return db.User{}, err
+	}
+
+	if err := util.CheckPassword(password, user.HashedPassword); err != nil {
+		if err := server.recordFailedLogin(ctx, user, clientIP); err != nil {
+			return db.User{}, err
+		}
+		return db.User{}, errors.New("incorrect username or password")
+	}
+
+	if user.IsTotpEnabled {
+		if err := server.verifySecondFactor(ctx, user, otp); err != nil {
+			if errors.Is(err, errInvalidSecondFactor) {
+				if err := server.recordFailedLogin(ctx, user, clientIP); err != nil {
+					return db.User{}, err
+				}
+			}
+			return db.User{}, secondFactorError(err)
+		}
+	}
+
+	if err := server.resetFailedLogins(ctx, user); err != nil {
+		return db.User{}, err
+	}
+
+	return user, nil
+}
+
+func (server *Server) renderConsent(res http.ResponseWriter, statusCode int, authReq *authorizeRequest, username string, errMessage string) {
+	page := consentPage{
+		Client:        authReq.client,
+		Scopes:        authReq.scopes,
+		Scope:         strings.Join(authReq.scopes, " "),
+		RedirectURI:   authReq.redirectURI,
+		State:         authReq.state,
+		CodeChallenge: authReq.codeChallenge,
+		Username:      username,
+		Error:         errMessage,
+		Action:        oauthAuthorizePath,
+	}
+
+	// The consent screen must not be framed by another site
+	res.Header().Set("Content-Type", "text/html; charset=utf-8")
+	res.Header().Set("Cache-Control", "no-store")
+	res.Header().Set("X-Frame-Options", "DENY")
+	res.Header().Set("Content-Security-Policy", "frame-ancestors 'none'")
+	res.WriteHeader(statusCode)
+	_ = consentTemplate.Execute(res, page)
+}
+
+func writeAuthorizeError(res http.ResponseWriter, req *http.Request, err error) {
+	var authErr *authorizeRequestError
+	if errors.As(err, &authErr) && authErr.redirectURI != "" {
+		redirectWithError(res, req, authErr.redirectURI, authErr.state, authErr.code, authErr.description)
+		return
+	}
+
+	http.Error(res, err.Error(), http.StatusBadRequest)
+}
+
+func redirectWithError(res http.ResponseWriter, req *http.Request, redirectURI string, state string, code string, description string) {
+	redirect(res, req, redirectURI, url.Values{
+		"error":             {code},
+		"error_description": {description},
+		"state":             {state},
+	})
+}
+
+// redirect sends the user agent back to the client with the given parameters
+// added to the query of the redirect URI
+func redirect(res http.ResponseWriter, req *http.Request, redirectURI string, params url.Values) {
+	uri, err := url.Parse(redirectURI)
+	if err != nil {
+		http.Error(res, "invalid redirect_uri", http.StatusBadRequest)
+		return
+	}
+
+	query := uri.Query()
+	for key, values := range params {
+		if len(values) > 0 && values[0] != "" {
+			query.Set(key, values[0])
+		}
+	}
+	uri.RawQuery = query.Encode()
+
+	http.Redirect(res, req, uri.String(), http.StatusFound)
+}
+
+func httpClientIP(req *http.Request) string {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+	return host
+}
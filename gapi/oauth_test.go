@@ -0,0 +1,489 @@
+package gapi
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/yelaco/simple-bank/db/mock"
+	db "github.com/yelaco/simple-bank/db/sqlc"
+	"github.com/yelaco/simple-bank/gen/pb/v1"
+	"github.com/yelaco/simple-bank/permission"
+	"github.com/yelaco/simple-bank/util"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+// oauthTestStore keeps the OAuth2 state of the mock store in memory, so that
+// the whole flow can run against it
+type oauthTestStore struct {
+	mu      sync.Mutex
+	clients map[string]db.OAuthClient
+	codes   map[string]db.OAuthAuthorizationCode
+	tokens  map[uuid.UUID]db.OAuthToken
+}
+
+func newOAuthTestStore(store *mockdb.MockStore, users ...db.User) *oauthTestStore {
+	s := &oauthTestStore{
+		clients: make(map[string]db.OAuthClient),
+		codes:   make(map[string]db.OAuthAuthorizationCode),
+		tokens:  make(map[uuid.UUID]db.OAuthToken),
+	}
+
+	store.EXPECT().
+		GetUser(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		DoAndReturn(func(_ context.Context, username string) (db.User, error) {
+			for _, user := range users {
+				if user.Username == username {
+					return user, nil
+				}
+			}
+			return db.User{}, db.ErrRecordNotFound
+		})
+	store.EXPECT().
+		CreateOAuthClient(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		DoAndReturn(func(_ context.Context, arg db.CreateOAuthClientParams) (db.OAuthClient, error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			client := db.OAuthClient{
+				ID:           arg.ID,
+				Owner:        arg.Owner,
+				Name:         arg.Name,
+				HashedSecret: arg.HashedSecret,
+				RedirectUris: arg.RedirectUris,
+				Scopes:       arg.Scopes,
+				CreatedAt:    time.Now(),
+			}
+			s.clients[client.ID] = client
+			return client, nil
+		})
+	store.EXPECT().
+		GetOAuthClient(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		DoAndReturn(func(_ context.Context, id string) (db.OAuthClient, error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			client, ok := s.clients[id]
+			if !ok {
+				return db.OAuthClient{}, db.ErrRecordNotFound
+			}
+			return client, nil
+		})
+	store.EXPECT().
+		CreateOAuthAuthorizationCode(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		DoAndReturn(func(_ context.Context, arg db.CreateOAuthAuthorizationCodeParams) (db.OAuthAuthorizationCode, error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			code := db.OAuthAuthorizationCode{
+				HashedCode:    arg.HashedCode,
+				ClientID:      arg.ClientID,
+				Username:      arg.Username,
+				RedirectUri:   arg.RedirectUri,
+				Scopes:        arg.Scopes,
+				CodeChallenge: arg.CodeChallenge,
+				ExpiresAt:     arg.ExpiresAt,
+				CreatedAt:     time.Now(),
+			}
+			s.codes[code.HashedCode] = code
+			return code, nil
+		})
+	store.EXPECT().
+		ConsumeOAuthAuthorizationCode(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		DoAndReturn(func(_ context.Context, hashedCode string) (db.OAuthAuthorizationCode, error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			code, ok := s.codes[hashedCode]
+			if !ok {
+				return db.OAuthAuthorizationCode{}, db.ErrRecordNotFound
+			}
+			delete(s.codes, hashedCode)
+			return code, nil
+		})
+	store.EXPECT().
+		CreateOAuthToken(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		DoAndReturn(func(_ context.Context, arg db.CreateOAuthTokenParams) (db.OAuthToken, error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			oauthToken := db.OAuthToken{
+				ID:        arg.ID,
+				ClientID:  arg.ClientID,
+				Username:  arg.Username,
+				Scopes:    arg.Scopes,
+				ExpiresAt: arg.ExpiresAt,
+				CreatedAt: time.Now(),
+			}
+			s.tokens[oauthToken.ID] = oauthToken
+			return oauthToken, nil
+		})
+	store.EXPECT().
+		GetOAuthToken(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		DoAndReturn(func(_ context.Context, id uuid.UUID) (db.OAuthToken, error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			oauthToken, ok := s.tokens[id]
+			if !ok {
+				return db.OAuthToken{}, db.ErrRecordNotFound
+			}
+			return oauthToken, nil
+		})
+	store.EXPECT().
+		RevokeOAuthToken(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		DoAndReturn(func(_ context.Context, arg db.RevokeOAuthTokenParams) (int64, error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			oauthToken, ok := s.tokens[arg.ID]
+			if !ok || oauthToken.ClientID != arg.ClientID || oauthToken.RevokedAt.Valid {
+				return 0, nil
+			}
+			oauthToken.RevokedAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+			s.tokens[arg.ID] = oauthToken
+			return 1, nil
+		})
+
+	return s
+}
+
+// oauthTestClient is a third-party application running the authorization
+// code flow against the gateway
+type oauthTestClient struct {
+	t            *testing.T
+	server       *httptest.Server
+	callback     *httptest.Server
+	clientID     string
+	clientSecret string
+
+	// the query of the last redirect to the callback
+	redirects chan url.Values
+}
+
+func newOAuthTestClient(t *testing.T, server *httptest.Server) *oauthTestClient {
+	client := &oauthTestClient{
+		t:         t,
+		server:    server,
+		redirects: make(chan url.Values, 1),
+	}
+	client.callback = httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		client.redirects <- req.URL.Query()
+	}))
+	t.Cleanup(client.callback.Close)
+
+	return client
+}
+
+func (client *oauthTestClient) redirectURI() string {
+	return client.callback.URL + "/callback"
+}
+
+func (client *oauthTestClient) authorizeQuery(scope string, state string, codeChallenge string) url.Values {
+	return url.Values{
+		"response_type":         {"code"},
+		"client_id":             {client.clientID},
+		"redirect_uri":          {client.redirectURI()},
+		"scope":                 {scope},
+		"state":                 {state},
+		"code_challenge":        {codeChallenge},
+		"code_challenge_method": {codeChallengeMethodS256},
+	}
+}
+
+// consent submits the consent screen and returns the query the user agent was
+// redirected to the callback with
+func (client *oauthTestClient) consent(form url.Values) url.Values {
+	res, err := http.PostForm(client.server.URL+oauthAuthorizePath, form)
+	require.NoError(client.t, err)
+	defer res.Body.Close()
+	require.Equal(client.t, http.StatusOK, res.StatusCode)
+
+	select {
+	case query := <-client.redirects:
+		return query
+	default:
+		client.t.Fatal("user agent was not redirected to the callback")
+		return nil
+	}
+}
+
+func (client *oauthTestClient) token(form url.Values) (int, map[string]any) {
+	req, err := http.NewRequest(http.MethodPost, client.server.URL+oauthTokenPath, strings.NewReader(form.Encode()))
+	require.NoError(client.t, err)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(client.clientID, client.clientSecret)
+
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(client.t, err)
+	defer res.Body.Close()
+
+	var body map[string]any
+	require.NoError(client.t, json.NewDecoder(res.Body).Decode(&body))
+	return res.StatusCode, body
+}
+
+func (client *oauthTestClient) revoke(accessToken string) {
+	res, err := http.PostForm(client.server.URL+oauthRevokePath, url.Values{
+		"token":         {accessToken},
+		"client_id":     {client.clientID},
+		"client_secret": {client.clientSecret},
+	})
+	require.NoError(client.t, err)
+	defer res.Body.Close()
+	require.Equal(client.t, http.StatusOK, res.StatusCode)
+}
+
+func newCodeChallenge() (verifier string, challenge string) {
+	verifier = util.RandomString(64)
+	sum := sha256.Sum256([]byte(verifier))
+	return verifier, base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func newContextWithAccessToken(accessToken string) context.Context {
+	md := metadata.MD{
+		authorizationHeader: []string{fmt.Sprintf("%s %s", authorizationBearer, accessToken)},
+	}
+	return metadata.NewIncomingContext(context.Background(), md)
+}
+
+func TestOAuthFlow(t *testing.T) {
+	owner, _ := randomUser(t)
+	user, password := randomUser(t)
+	account := randomAccount(user.Username)
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	newOAuthTestStore(store, owner, user)
+	store.EXPECT().
+		GetAccount(gomock.Any(), gomock.Eq(account.ID)).
+		AnyTimes().
+		Return(account, nil)
+
+	server := newTestServer(t, store, nil)
+
+	mux := http.NewServeMux()
+	server.RegisterOAuthHandlers(mux)
+	httpServer := httptest.NewServer(mux)
+	defer httpServer.Close()
+
+	client := newOAuthTestClient(t, httpServer)
+
+	// The partner registers its application
+	ctx := newContextWithBearerToken(t, server.tokenMaker, owner.Username, owner.Role, time.Minute)
+	registered, err := invoke(ctx, server, pb.SimpleBank_CreateOAuthClient_FullMethodName, &pb.CreateOAuthClientRequest{
+		Name:         "Budget App",
+		RedirectUris: []string{client.redirectURI()},
+		Scopes:       []string{permission.AccountsReadOwn, permission.TransfersCreateOwn},
+	}, server.CreateOAuthClient)
+	require.NoError(t, err)
+	client.clientID = registered.GetClient().GetClientId()
+	client.clientSecret = registered.GetClientSecret()
+
+	verifier, challenge := newCodeChallenge()
+	query := client.authorizeQuery(permission.AccountsReadOwn, "xyz", challenge)
+
+	// The user is shown the consent screen
+	res, err := http.Get(httpServer.URL + oauthAuthorizePath + "?" + query.Encode())
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	require.Equal(t, "DENY", res.Header.Get("X-Frame-Options"))
+
+	// A wrong password keeps the user on the consent screen
+	store.EXPECT().
+		RecordFailedLoginTx(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(db.RecordFailedLoginTxResult{}, nil)
+
+	form := client.authorizeQuery(permission.AccountsReadOwn, "xyz", challenge)
+	form.Set("consent", "approve")
+	form.Set("username", user.Username)
+	form.Set("password", "wrong-password")
+	res, err = http.PostForm(httpServer.URL+oauthAuthorizePath, form)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusUnauthorized, res.StatusCode)
+
+	// The user approves and the client gets a code
+	form.Set("password", password)
+	redirect := client.consent(form)
+	require.Equal(t, "xyz", redirect.Get("state"))
+	code := redirect.Get("code")
+	require.NotEmpty(t, code)
+
+	// A wrong verifier does not get a token, and burns the code
+	status, body := client.token(url.Values{
+		"grant_type":    {grantTypeAuthorizationCode},
+		"code":          {code},
+		"redirect_uri":  {client.redirectURI()},
+		"code_verifier": {"wrong-verifier"},
+	})
+	require.Equal(t, http.StatusBadRequest, status)
+	require.Equal(t, oauthErrInvalidGrant, body["error"])
+
+	status, body = client.token(url.Values{
+		"grant_type":    {grantTypeAuthorizationCode},
+		"code":          {code},
+		"redirect_uri":  {client.redirectURI()},
+		"code_verifier": {verifier},
+	})
+	require.Equal(t, http.StatusBadRequest, status)
+	require.Equal(t, oauthErrInvalidGrant, body["error"])
+
+	// With a new code and the right verifier, the client gets a scoped token
+	redirect = client.consent(form)
+	status, body = client.token(url.Values{
+		"grant_type":    {grantTypeAuthorizationCode},
+		"code":          {redirect.Get("code")},
+		"redirect_uri":  {client.redirectURI()},
+		"code_verifier": {verifier},
+	})
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, "Bearer", body["token_type"])
+	require.Equal(t, permission.AccountsReadOwn, body["scope"])
+	accessToken := body["access_token"].(string)
+
+	ctx = newContextWithAccessToken(accessToken)
+	_, err = invoke(ctx, server, pb.SimpleBank_GetAccount_FullMethodName, &pb.GetAccountRequest{Id: account.ID}, server.GetAccount)
+	require.NoError(t, err)
+
+	_, err = invoke(ctx, server, pb.SimpleBank_CreateTransfer_FullMethodName, &pb.CreateTransferRequest{}, server.CreateTransfer)
+	requireStatusCode(t, codes.PermissionDenied, err)
+
+	// Once revoked, the token is rejected
+	client.revoke(accessToken)
+	_, err = invoke(ctx, server, pb.SimpleBank_GetAccount_FullMethodName, &pb.GetAccountRequest{Id: account.ID}, server.GetAccount)
+	requireStatusCode(t, codes.Unauthenticated, err)
+
+	// The client can also act on behalf of its owner
+	status, body = client.token(url.Values{
+		"grant_type": {grantTypeClientCredentials},
+	})
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, permission.AccountsReadOwn+" "+permission.TransfersCreateOwn, body["scope"])
+
+	payload, err := server.tokenMaker.VerifyToken(body["access_token"].(string))
+	require.NoError(t, err)
+	require.Equal(t, owner.Username, payload.Username)
+}
+
+func TestOAuthAuthorizeErrors(t *testing.T) {
+	owner, _ := randomUser(t)
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	oauthStore := newOAuthTestStore(store, owner)
+
+	server := newTestServer(t, store, nil)
+
+	mux := http.NewServeMux()
+	server.RegisterOAuthHandlers(mux)
+	httpServer := httptest.NewServer(mux)
+	defer httpServer.Close()
+
+	client := newOAuthTestClient(t, httpServer)
+	client.clientID = "sbc_test"
+	client.clientSecret = "secret"
+	oauthStore.clients[client.clientID] = db.OAuthClient{
+		ID:           client.clientID,
+		Owner:        owner.Username,
+		Name:         "Budget App",
+		HashedSecret: "unused",
+		RedirectUris: []string{client.redirectURI()},
+		Scopes:       []string{permission.AccountsReadOwn},
+	}
+
+	_, challenge := newCodeChallenge()
+
+	noRedirect := &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		buildQuery    func() url.Values
+		checkResponse func(t *testing.T, res *http.Response)
+	}{
+		{
+			name: "UnknownClient",
+			buildQuery: func() url.Values {
+				query := client.authorizeQuery(permission.AccountsReadOwn, "xyz", challenge)
+				query.Set("client_id", "sbc_unknown")
+				return query
+			},
+			checkResponse: func(t *testing.T, res *http.Response) {
+				require.Equal(t, http.StatusBadRequest, res.StatusCode)
+			},
+		},
+		{
+			name: "UnregisteredRedirectURI",
+			buildQuery: func() url.Values {
+				query := client.authorizeQuery(permission.AccountsReadOwn, "xyz", challenge)
+				query.Set("redirect_uri", "https://attacker.example/callback")
+				return query
+			},
+			checkResponse: func(t *testing.T, res *http.Response) {
+				require.Equal(t, http.StatusBadRequest, res.StatusCode)
+			},
+		},
+		{
+			name: "MissingCodeChallenge",
+			buildQuery: func() url.Values {
+				query := client.authorizeQuery(permission.AccountsReadOwn, "xyz", challenge)
+				query.Del("code_challenge")
+				return query
+			},
+			checkResponse: func(t *testing.T, res *http.Response) {
+				require.Equal(t, http.StatusFound, res.StatusCode)
+				location, err := url.Parse(res.Header.Get("Location"))
+				require.NoError(t, err)
+				require.Equal(t, oauthErrInvalidRequest, location.Query().Get("error"))
+				require.Equal(t, "xyz", location.Query().Get("state"))
+			},
+		},
+		{
+			name: "ScopeNotAllowed",
+			buildQuery: func() url.Values {
+				return client.authorizeQuery(permission.AccountsReadAny, "xyz", challenge)
+			},
+			checkResponse: func(t *testing.T, res *http.Response) {
+				require.Equal(t, http.StatusFound, res.StatusCode)
+				location, err := url.Parse(res.Header.Get("Location"))
+				require.NoError(t, err)
+				require.Equal(t, oauthErrInvalidScope, location.Query().Get("error"))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := noRedirect.Get(httpServer.URL + oauthAuthorizePath + "?" + tc.buildQuery().Encode())
+			require.NoError(t, err)
+			defer res.Body.Close()
+			tc.checkResponse(t, res)
+		})
+	}
+
+	// Wrong client credentials are rejected by the token endpoint
+	status, body := client.token(url.Values{"grant_type": {grantTypeClientCredentials}})
+	require.Equal(t, http.StatusUnauthorized, status)
+	require.Equal(t, oauthErrInvalidClient, body["error"])
+}
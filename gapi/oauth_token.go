@@ -0,0 +1,185 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	db "github.com/yelaco/simple-bank/db/sqlc"
+	"github.com/yelaco/simple-bank/token"
+)
+
+const (
+	grantTypeAuthorizationCode = "authorization_code"
+	grantTypeClientCredentials = "client_credentials"
+)
+
+type oauthTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+	Scope       string `json:"scope"`
+}
+
+func (server *Server) handleOAuthToken(res http.ResponseWriter, req *http.Request) {
+	if err := req.ParseForm(); err != nil {
+		writeOAuthError(res, http.StatusBadRequest, oauthErrInvalidRequest, "invalid form")
+		return
+	}
+
+	client, err := server.authenticateOAuthClient(req)
+	if err != nil {
+		if errors.Is(err, errOAuthClient) {
+			writeOAuthError(res, http.StatusUnauthorized, oauthErrInvalidClient, err.Error())
+			return
+		}
+		writeOAuthError(res, http.StatusInternalServerError, oauthErrServerError, "failed to authenticate client")
+		return
+	}
+
+	switch req.PostForm.Get("grant_type") {
+	case grantTypeAuthorizationCode:
+		server.exchangeAuthorizationCode(res, req, client)
+	case grantTypeClientCredentials:
+		scopes, err := parseScopes(req.PostForm.Get("scope"), client)
+		if err != nil {
+			writeOAuthError(res, http.StatusBadRequest, oauthErrInvalidScope, err.Error())
+			return
+		}
+
+		// The client acts on behalf of the user who registered it
+		server.issueOAuthToken(res, req.Context(), client, client.Owner, scopes)
+	default:
+		writeOAuthError(res, http.StatusBadRequest, oauthErrUnsupportedGrantType, "grant_type must be authorization_code or client_credentials")
+	}
+}
+
+func (server *Server) exchangeAuthorizationCode(res http.ResponseWriter, req *http.Request, client db.OAuthClient) {
+	code := req.PostForm.Get("code")
+	verifier := req.PostForm.Get("code_verifier")
+	if code == "" || verifier == "" {
+		writeOAuthError(res, http.StatusBadRequest, oauthErrInvalidRequest, "code and code_verifier are required")
+		return
+	}
+
+	// Codes are deleted as they are exchanged, so that they can only be used once
+	authCode, err := server.store.ConsumeOAuthAuthorizationCode(req.Context(), token.HashOAuthSecret(code))
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			writeOAuthError(res, http.StatusBadRequest, oauthErrInvalidGrant, "invalid authorization code")
+			return
+		}
+		writeOAuthError(res, http.StatusInternalServerError, oauthErrServerError, "failed to get authorization code")
+		return
+	}
+
+	if authCode.ClientID != client.ID {
+		writeOAuthError(res, http.StatusBadRequest, oauthErrInvalidGrant, "authorization code was issued to another client")
+		return
+	}
+
+	if time.Now().After(authCode.ExpiresAt) {
+		writeOAuthError(res, http.StatusBadRequest, oauthErrInvalidGrant, "authorization code has expired")
+		return
+	}
+
+	if req.PostForm.Get("redirect_uri") != authCode.RedirectUri {
+		writeOAuthError(res, http.StatusBadRequest, oauthErrInvalidGrant, "redirect_uri does not match the authorization request")
+		return
+	}
+
+	if !token.CheckCodeChallenge(verifier, authCode.CodeChallenge) {
+		writeOAuthError(res, http.StatusBadRequest, oauthErrInvalidGrant, "invalid code_verifier")
+		return
+	}
+
+	server.issueOAuthToken(res, req.Context(), client, authCode.Username, authCode.Scopes)
+}
+
+// issueOAuthToken issues an access token of the user limited to the granted
+// scopes and records it, so that it can be revoked
+func (server *Server) issueOAuthToken(res http.ResponseWriter, ctx context.Context, client db.OAuthClient, username string, scopes []string) {
+	user, err := server.store.GetUser(ctx, username)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			writeOAuthError(res, http.StatusBadRequest, oauthErrInvalidGrant, "user not found")
+			return
+		}
+		writeOAuthError(res, http.StatusInternalServerError, oauthErrServerError, "failed to find user")
+		return
+	}
+
+	accessToken, payload, err := server.tokenMaker.CreateScopedToken(
+		user.Username,
+		user.Role,
+		scopes,
+		server.config.AccessTokenDuration,
+	)
+	if err != nil {
+		writeOAuthError(res, http.StatusInternalServerError, oauthErrServerError, "failed to create access token")
+		return
+	}
+
+	_, err = server.store.CreateOAuthToken(ctx, db.CreateOAuthTokenParams{
+		ID:        payload.ID,
+		ClientID:  client.ID,
+		Username:  user.Username,
+		Scopes:    scopes,
+		ExpiresAt: payload.ExpiredAt,
+	})
+	if err != nil {
+		writeOAuthError(res, http.StatusInternalServerError, oauthErrServerError, "failed to record access token")
+		return
+	}
+
+	writeOAuthJSON(res, http.StatusOK, oauthTokenResponse{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(time.Until(payload.ExpiredAt).Seconds()),
+		Scope:       strings.Join(scopes, " "),
+	})
+}
+
+// handleOAuthRevoke revokes an access token issued to the client. As required
+// by RFC 7009, invalid or unknown tokens are not reported as errors.
+func (server *Server) handleOAuthRevoke(res http.ResponseWriter, req *http.Request) {
+	if err := req.ParseForm(); err != nil {
+		writeOAuthError(res, http.StatusBadRequest, oauthErrInvalidRequest, "invalid form")
+		return
+	}
+
+	client, err := server.authenticateOAuthClient(req)
+	if err != nil {
+		if errors.Is(err, errOAuthClient) {
+			writeOAuthError(res, http.StatusUnauthorized, oauthErrInvalidClient, err.Error())
+			return
+		}
+		writeOAuthError(res, http.StatusInternalServerError, oauthErrServerError, "failed to authenticate client")
+		return
+	}
+
+	accessToken := req.PostForm.Get("token")
+	if accessToken == "" {
+		writeOAuthError(res, http.StatusBadRequest, oauthErrInvalidRequest, "token is required")
+		return
+	}
+
+	payload, err := server.tokenMaker.VerifyToken(accessToken)
+	if err != nil || payload.Scopes == nil {
+		res.WriteHeader(http.StatusOK)
+		return
+	}
+
+	_, err = server.store.RevokeOAuthToken(req.Context(), db.RevokeOAuthTokenParams{
+		ID:       payload.ID,
+		ClientID: client.ID,
+	})
+	if err != nil {
+		writeOAuthError(res, http.StatusServiceUnavailable, oauthErrServerError, "failed to revoke token")
+		return
+	}
+
+	res.WriteHeader(http.StatusOK)
+}
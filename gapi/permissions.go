@@ -0,0 +1,71 @@
+package gapi
+
+import (
+	"github.com/yelaco/simple-bank/gen/pb/v1"
+	"github.com/yelaco/simple-bank/permission"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// publicMethods can be called without an access token
+var publicMethods = map[string]bool{
+	pb.SimpleBank_CreateUser_FullMethodName:           true,
+	pb.SimpleBank_LoginUser_FullMethodName:            true,
+	pb.SimpleBank_VerifyLoginMFA_FullMethodName:       true,
+	pb.SimpleBank_VerifyEmail_FullMethodName:          true,
+	pb.SimpleBank_RequestPasswordReset_FullMethodName: true,
+	pb.SimpleBank_ResetPassword_FullMethodName:        true,
+	pb.SimpleBank_RenewAccessToken_FullMethodName:     true,
+
+	// Health checks are probed by the orchestrator without credentials
+	healthpb.Health_Check_FullMethodName: true,
+	healthpb.Health_Watch_FullMethodName: true,
+}
+
+// methodPermissions maps every other RPC to the permission the caller needs.
+// For RPCs on resources of a specific user the "own" permission is required,
+// the handler then checks for the "any" permission when the resource belongs
+// to someone else. RPCs missing from both maps are always denied.
+var methodPermissions = map[string]string{
+	pb.SimpleBank_UpdateUser_FullMethodName: permission.UsersUpdateOwn,
+	pb.SimpleBank_UnlockUser_FullMethodName: permission.UsersUnlockAny,
+	pb.SimpleBank_AssignRole_FullMethodName: permission.RolesAssignAny,
+
+	pb.SimpleBank_CreateAccount_FullMethodName:        permission.AccountsCreateOwn,
+	pb.SimpleBank_GetAccount_FullMethodName:           permission.AccountsReadOwn,
+	pb.SimpleBank_ListAccounts_FullMethodName:         permission.AccountsReadOwn,
+	pb.SimpleBank_CloseAccount_FullMethodName:         permission.AccountsCloseOwn,
+	pb.SimpleBank_UpdateOverdraftLimit_FullMethodName: permission.AccountsUpdateOverdraftAny,
+	pb.SimpleBank_SendStatement_FullMethodName:        permission.StatementsSendOwn,
+
+	pb.SimpleBank_CreateTransfer_FullMethodName:          permission.TransfersCreateOwn,
+	pb.SimpleBank_ReverseTransfer_FullMethodName:         permission.TransfersReverseAny,
+	pb.SimpleBank_CreateScheduledTransfer_FullMethodName: permission.ScheduledTransfersCreateOwn,
+	pb.SimpleBank_CancelScheduledTransfer_FullMethodName: permission.ScheduledTransfersCancelOwn,
+
+	pb.SimpleBank_CreateHold_FullMethodName:  permission.HoldsCreateOwn,
+	pb.SimpleBank_CaptureHold_FullMethodName: permission.HoldsCaptureOwn,
+	pb.SimpleBank_VoidHold_FullMethodName:    permission.HoldsVoidOwn,
+
+	pb.SimpleBank_EnrollTOTP_FullMethodName:  permission.MFAManageOwn,
+	pb.SimpleBank_ConfirmTOTP_FullMethodName: permission.MFAManageOwn,
+	pb.SimpleBank_DisableTOTP_FullMethodName: permission.MFAManageOwn,
+
+	pb.SimpleBank_CreateAPIKey_FullMethodName: permission.APIKeysManageOwn,
+	pb.SimpleBank_ListAPIKeys_FullMethodName:  permission.APIKeysManageOwn,
+	pb.SimpleBank_RevokeAPIKey_FullMethodName: permission.APIKeysManageOwn,
+
+	pb.SimpleBank_CreateOAuthClient_FullMethodName: permission.OAuthClientsManageOwn,
+
+	pb.SimpleBank_ListSessions_FullMethodName:  permission.SessionsReadOwn,
+	pb.SimpleBank_RevokeSession_FullMethodName: permission.SessionsRevokeOwn,
+	pb.SimpleBank_Logout_FullMethodName:        permission.SessionsRevokeOwn,
+	pb.SimpleBank_LogoutAll_FullMethodName:     permission.SessionsRevokeOwn,
+
+	pb.SimpleBank_ListAuditEvents_FullMethodName: permission.AuditReadAny,
+
+	pb.SimpleBank_CreateWebhookEndpoint_FullMethodName: permission.WebhooksManageOwn,
+	pb.SimpleBank_ListWebhookEndpoints_FullMethodName:  permission.WebhooksManageOwn,
+	pb.SimpleBank_DeleteWebhookEndpoint_FullMethodName: permission.WebhooksManageOwn,
+	pb.SimpleBank_ListWebhookDeliveries_FullMethodName: permission.WebhooksManageOwn,
+	pb.SimpleBank_ReplayWebhookDelivery_FullMethodName: permission.WebhooksManageOwn,
+}
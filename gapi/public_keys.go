@@ -0,0 +1,57 @@
+package gapi
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+
+	"github.com/yelaco/simple-bank/token"
+)
+
+// publicKeysPath is where the gateway publishes the token verification keys
+const publicKeysPath = "/.well-known/jwks.json"
+
+type jsonWebKey struct {
+	KeyType   string `json:"kty"`
+	Curve     string `json:"crv"`
+	X         string `json:"x"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+// RegisterPublicKeysHandler publishes the keys that verify our access tokens in
+// a JWKS-like document, so that other services can verify tokens without the
+// signing key. The key set is empty when tokens are signed with a symmetric key.
+func (server *Server) RegisterPublicKeysHandler(mux *http.ServeMux) {
+	mux.HandleFunc("GET "+publicKeysPath, server.handlePublicKeys)
+}
+
+func (server *Server) handlePublicKeys(res http.ResponseWriter, req *http.Request) {
+	keySet := jsonWebKeySet{
+		Keys: []jsonWebKey{},
+	}
+
+	if provider, ok := server.tokenMaker.(token.PublicKeyProvider); ok {
+		for _, key := range provider.PublicKeys() {
+			keySet.Keys = append(keySet.Keys, jsonWebKey{
+				KeyType:   "OKP",
+				Curve:     "Ed25519",
+				X:         base64.RawURLEncoding.EncodeToString(key.Key.ExportBytes()),
+				KeyID:     key.KeyID,
+				Use:       "sig",
+				Algorithm: "EdDSA",
+			})
+		}
+	}
+
+	res.Header().Set("Content-Type", "application/json")
+	res.Header().Set("Cache-Control", "public, max-age=300")
+	if err := json.NewEncoder(res).Encode(keySet); err != nil {
+		http.Error(res, "failed to encode public keys", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,80 @@
+package gapi
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"aidanwoods.dev/go-paseto"
+	"github.com/stretchr/testify/require"
+	"github.com/yelaco/simple-bank/util"
+)
+
+func TestPublicKeysHandler(t *testing.T) {
+	secretKey := paseto.NewV4AsymmetricSecretKey()
+	retiredKey := paseto.NewV4AsymmetricSecretKey().Public()
+
+	testCases := []struct {
+		name      string
+		config    util.Config
+		checkKeys func(t *testing.T, keySet jsonWebKeySet)
+	}{
+		{
+			name: "PublicKeys",
+			config: util.Config{
+				TokenKeyID:      "key-2",
+				TokenPrivateKey: secretKey.ExportSeedHex(),
+				TokenPublicKeys: []string{"key-1:" + retiredKey.ExportHex()},
+			},
+			checkKeys: func(t *testing.T, keySet jsonWebKeySet) {
+				require.Len(t, keySet.Keys, 2)
+				require.Equal(t, "key-2", keySet.Keys[0].KeyID)
+				require.Equal(t, "key-1", keySet.Keys[1].KeyID)
+
+				for _, key := range keySet.Keys {
+					require.Equal(t, "OKP", key.KeyType)
+					require.Equal(t, "Ed25519", key.Curve)
+					require.Equal(t, "EdDSA", key.Algorithm)
+				}
+
+				x, err := base64.RawURLEncoding.DecodeString(keySet.Keys[0].X)
+				require.NoError(t, err)
+				require.Equal(t, secretKey.Public().ExportBytes(), x)
+			},
+		},
+		{
+			name: "SymmetricKey",
+			config: util.Config{
+				TokenSymmetricKey: util.RandomString(32),
+			},
+			checkKeys: func(t *testing.T, keySet jsonWebKeySet) {
+				require.Empty(t, keySet.Keys)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.config.AccessTokenDuration = time.Minute
+			server, err := NewServer(tc.config, nil, nil, nil)
+			require.NoError(t, err)
+
+			mux := http.NewServeMux()
+			server.RegisterPublicKeysHandler(mux)
+
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodGet, publicKeysPath, nil)
+			mux.ServeHTTP(recorder, request)
+			require.Equal(t, http.StatusOK, recorder.Code)
+			require.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+
+			var keySet jsonWebKeySet
+			err = json.NewDecoder(recorder.Body).Decode(&keySet)
+			require.NoError(t, err)
+			tc.checkKeys(t, keySet)
+		})
+	}
+}
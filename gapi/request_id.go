@@ -0,0 +1,69 @@
+package gapi
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// maxRequestIDLength bounds the request IDs accepted from clients, longer ones
+// are replaced
+const maxRequestIDLength = 128
+
+// GrpcRequestID gives every request an ID, which is logged and recorded in the
+// audit log. The ID sent by the client or the gateway in x-request-id is kept,
+// otherwise a new one is generated. It is sent back in the response header.
+func GrpcRequestID(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	requestID := ""
+	if values := md.Get(requestIDHeader); len(values) > 0 && len(values[0]) <= maxRequestIDLength {
+		requestID = values[0]
+	}
+	if requestID == "" {
+		requestID = uuid.NewString()
+		md = md.Copy()
+		md.Set(requestIDHeader, requestID)
+		ctx = metadata.NewIncomingContext(ctx, md)
+	}
+
+	_ = grpc.SetHeader(ctx, metadata.Pairs(requestIDHeader, requestID))
+
+	return handler(ctx, req)
+}
+
+func requestIDFromContext(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(requestIDHeader); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// IncomingHeaderMatcher forwards the X-Request-Id header of gateway requests to
+// the gRPC server, along with the headers forwarded by default
+func IncomingHeaderMatcher(key string) (string, bool) {
+	if strings.EqualFold(key, requestIDHeader) {
+		return requestIDHeader, true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// OutgoingHeaderMatcher returns the request ID to gateway clients in the
+// X-Request-Id header, other metadata keeps the default prefix
+func OutgoingHeaderMatcher(key string) (string, bool) {
+	if key == requestIDHeader {
+		return http.CanonicalHeaderKey(requestIDHeader), true
+	}
+	return runtime.MetadataHeaderPrefix + key, true
+}
@@ -0,0 +1,60 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/yelaco/simple-bank/db/sqlc"
+	"github.com/yelaco/simple-bank/gen/pb/v1"
+	"github.com/yelaco/simple-bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) AssignRole(ctx context.Context, req *pb.AssignRoleRequest) (*pb.AssignRoleResponse, error) {
+	authPayload, err := authorizationFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateAssignRoleRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	result, err := server.store.AssignRoleTx(ctx, db.AssignRoleTxParams{
+		Username: req.GetUsername(),
+		Role:     req.GetRole(),
+		Audit:    server.auditContext(ctx, authPayload.Username),
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRoleNotFound) {
+			return nil, status.Errorf(codes.NotFound, "role not found")
+		}
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to assign role: %s", err)
+	}
+
+	server.userState.Invalidate(result.User.Username)
+
+	rsp := &pb.AssignRoleResponse{
+		User:            convertUser(result.User),
+		RevokedSessions: result.RevokedSessions,
+	}
+	return rsp, nil
+}
+
+func validateAssignRoleRequest(req *pb.AssignRoleRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateUsername(req.GetUsername()); err != nil {
+		violations = append(violations, fieldViolation("username", err))
+	}
+
+	if err := val.ValidateRole(req.GetRole()); err != nil {
+		violations = append(violations, fieldViolation("role", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,163 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/yelaco/simple-bank/db/mock"
+	db "github.com/yelaco/simple-bank/db/sqlc"
+	"github.com/yelaco/simple-bank/gen/pb/v1"
+	"github.com/yelaco/simple-bank/token"
+	"github.com/yelaco/simple-bank/util"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestAssignRoleAPI(t *testing.T) {
+	banker, _ := randomUser(t)
+	banker.Role = util.BankerRole
+	user, _ := randomUser(t)
+
+	promoted := user
+	promoted.Role = util.BankerRole
+
+	testCases := []struct {
+		name          string
+		req           *pb.AssignRoleRequest
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.AssignRoleResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &pb.AssignRoleRequest{
+				Username: user.Username,
+				Role:     util.BankerRole,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.AssignRoleTxParams{
+					Username: user.Username,
+					Role:     util.BankerRole,
+					Audit:    db.AuditContext{Actor: banker.Username},
+				}
+				store.EXPECT().
+					AssignRoleTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.AssignRoleTxResult{User: promoted, RevokedSessions: 2}, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, banker.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.AssignRoleResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, user.Username, res.GetUser().GetUsername())
+				require.Equal(t, int64(2), res.GetRevokedSessions())
+			},
+		},
+		{
+			name: "DepositorNotAllowed",
+			req: &pb.AssignRoleRequest{
+				Username: user.Username,
+				Role:     util.BankerRole,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					AssignRoleTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.AssignRoleResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.PermissionDenied, st.Code())
+			},
+		},
+		{
+			name: "RoleNotFound",
+			req: &pb.AssignRoleRequest{
+				Username: user.Username,
+				Role:     "auditor",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					AssignRoleTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.AssignRoleTxResult{}, db.ErrRoleNotFound)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, banker.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.AssignRoleResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.NotFound, st.Code())
+			},
+		},
+		{
+			name: "UserNotFound",
+			req: &pb.AssignRoleRequest{
+				Username: user.Username,
+				Role:     util.BankerRole,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					AssignRoleTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.AssignRoleTxResult{}, db.ErrRecordNotFound)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, banker.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.AssignRoleResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.NotFound, st.Code())
+			},
+		},
+		{
+			name: "InvalidRole",
+			req: &pb.AssignRoleRequest{
+				Username: user.Username,
+				Role:     "Banker!",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					AssignRoleTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, banker.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.AssignRoleResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store, nil)
+
+			ctx := tc.buildContext(t, server.tokenMaker)
+			res, err := invoke(ctx, server, pb.SimpleBank_AssignRole_FullMethodName, tc.req, server.AssignRole)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
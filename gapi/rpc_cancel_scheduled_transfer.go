@@ -0,0 +1,59 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/yelaco/simple-bank/db/sqlc"
+	"github.com/yelaco/simple-bank/gen/pb/v1"
+	"github.com/yelaco/simple-bank/permission"
+	"github.com/yelaco/simple-bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CancelScheduledTransfer(ctx context.Context, req *pb.CancelScheduledTransferRequest) (*pb.CancelScheduledTransferResponse, error) {
+	authPayload, err := authorizationFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateCancelScheduledTransferRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	scheduledTransfer, err := server.store.GetScheduledTransfer(ctx, req.GetId())
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "scheduled transfer not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get scheduled transfer: %s", err)
+	}
+
+	if !authPayload.can(permission.ScheduledTransfersCancelAny) && scheduledTransfer.Owner != authPayload.Username {
+		return nil, status.Errorf(codes.PermissionDenied, "cannot cancel other user's scheduled transfer")
+	}
+
+	scheduledTransfer, err = server.store.CancelScheduledTransfer(ctx, req.GetId())
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.FailedPrecondition, "scheduled transfer is not active")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to cancel scheduled transfer: %s", err)
+	}
+
+	rsp := &pb.CancelScheduledTransferResponse{
+		ScheduledTransfer: convertScheduledTransfer(scheduledTransfer),
+	}
+	return rsp, nil
+}
+
+func validateCancelScheduledTransferRequest(req *pb.CancelScheduledTransferRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+
+	return violations
+}
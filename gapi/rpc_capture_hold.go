@@ -0,0 +1,101 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/yelaco/simple-bank/db/sqlc"
+	"github.com/yelaco/simple-bank/gen/pb/v1"
+	"github.com/yelaco/simple-bank/metrics"
+	"github.com/yelaco/simple-bank/permission"
+	"github.com/yelaco/simple-bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CaptureHold(ctx context.Context, req *pb.CaptureHoldRequest) (*pb.CaptureHoldResponse, error) {
+	authPayload, err := authorizationFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateCaptureHoldRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	hold, err := server.getHold(ctx, req.GetHoldId())
+	if err != nil {
+		return nil, err
+	}
+
+	// only the receiving side settles a hold
+	toAccount, err := server.store.GetAccount(ctx, hold.ToAccountID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get account: %s", err)
+	}
+
+	if !authPayload.can(permission.HoldsCaptureAny) && authPayload.Username != toAccount.Owner {
+		return nil, status.Errorf(codes.PermissionDenied, "cannot capture hold for other user's account")
+	}
+
+	amount := hold.Amount
+	if req.Amount != nil {
+		amount = req.GetAmount()
+	}
+
+	txResult, err := server.store.CaptureHoldTx(ctx, db.CaptureHoldTxParams{
+		HoldID: hold.ID,
+		Amount: amount,
+		Audit:  server.auditContext(ctx, authPayload.Username),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, db.ErrInvalidCaptureAmount):
+			return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("amount", err)})
+		case errors.Is(err, db.ErrHoldNotPending),
+			errors.Is(err, db.ErrHoldExpired),
+			errors.Is(err, db.ErrInsufficientFunds),
+			errors.Is(err, db.ErrAccountClosed):
+			return nil, status.Errorf(codes.FailedPrecondition, "%s", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to capture hold: %s", err)
+	}
+
+	metrics.ObserveTransfer(txResult.FromAccount.Currency, txResult.Transfer.Amount)
+
+	rsp := &pb.CaptureHoldResponse{
+		Hold:     convertHold(txResult.Hold),
+		Transfer: convertTransfer(txResult.Transfer),
+		ToEntry:  convertEntry(txResult.ToEntry),
+	}
+	return rsp, nil
+}
+
+// getHold returns the hold with the given ID. The returned error is already a gRPC status error.
+func (server *Server) getHold(ctx context.Context, holdID int64) (db.Hold, error) {
+	hold, err := server.store.GetHold(ctx, holdID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return hold, status.Errorf(codes.NotFound, "hold not found")
+		}
+		return hold, status.Errorf(codes.Internal, "failed to get hold: %s", err)
+	}
+
+	return hold, nil
+}
+
+func validateCaptureHoldRequest(req *pb.CaptureHoldRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetHoldId()); err != nil {
+		violations = append(violations, fieldViolation("hold_id", err))
+	}
+
+	if req.Amount != nil {
+		if err := val.ValidateAmount(req.GetAmount()); err != nil {
+			violations = append(violations, fieldViolation("amount", err))
+		}
+	}
+
+	return violations
+}
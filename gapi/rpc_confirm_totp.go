@@ -0,0 +1,85 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/yelaco/simple-bank/db/sqlc"
+	"github.com/yelaco/simple-bank/gen/pb/v1"
+	"github.com/yelaco/simple-bank/util"
+	"github.com/yelaco/simple-bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ConfirmTOTP(ctx context.Context, req *pb.ConfirmTOTPRequest) (*pb.ConfirmTOTPResponse, error) {
+	authPayload, err := authorizationFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateConfirmTOTPRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	user, err := server.store.GetUser(ctx, authPayload.Username)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get user: %s", err)
+	}
+
+	if user.IsTotpEnabled {
+		return nil, status.Errorf(codes.FailedPrecondition, "two-factor authentication is already enabled")
+	}
+
+	if !user.TotpSecret.Valid {
+		return nil, status.Errorf(codes.FailedPrecondition, "no pending totp enrolment")
+	}
+
+	if !util.ValidateTOTPCode(req.GetCode(), user.TotpSecret.String) {
+		return nil, status.Errorf(codes.Unauthenticated, "%s", errInvalidSecondFactor)
+	}
+
+	recoveryCodes, err := util.GenerateRecoveryCodes(util.RecoveryCodeCount)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%s", err)
+	}
+
+	hashedCodes := make([]string, 0, len(recoveryCodes))
+	for _, code := range recoveryCodes {
+		hashedCode, err := util.HashPassword(code)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to hash recovery code: %s", err)
+		}
+		hashedCodes = append(hashedCodes, hashedCode)
+	}
+
+	user, err = server.store.EnableTOTPTx(ctx, db.EnableTOTPTxParams{
+		Username:            user.Username,
+		HashedRecoveryCodes: hashedCodes,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.FailedPrecondition, "two-factor authentication is already enabled")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to enable totp: %s", err)
+	}
+
+	rsp := &pb.ConfirmTOTPResponse{
+		User:          convertUser(user),
+		RecoveryCodes: recoveryCodes,
+	}
+	return rsp, nil
+}
+
+func validateConfirmTOTPRequest(req *pb.ConfirmTOTPRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateMFACode(req.GetCode()); err != nil {
+		violations = append(violations, fieldViolation("code", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,168 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pquerna/otp/totp"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/yelaco/simple-bank/db/mock"
+	db "github.com/yelaco/simple-bank/db/sqlc"
+	"github.com/yelaco/simple-bank/gen/pb/v1"
+	"github.com/yelaco/simple-bank/token"
+	"github.com/yelaco/simple-bank/util"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestConfirmTOTPAPI(t *testing.T) {
+	enabledUser := randomTOTPUser(t)
+
+	pendingUser := enabledUser
+	pendingUser.IsTotpEnabled = false
+
+	notEnrolledUser := pendingUser
+	notEnrolledUser.TotpSecret = pgtype.Text{}
+
+	code, err := totp.GenerateCode(pendingUser.TotpSecret.String, time.Now())
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		code          string
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.ConfirmTOTPResponse, err error)
+	}{
+		{
+			name: "OK",
+			code: code,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(pendingUser.Username)).
+					Times(1).
+					Return(pendingUser, nil)
+				store.EXPECT().
+					EnableTOTPTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.EnableTOTPTxParams) (db.User, error) {
+						require.Equal(t, pendingUser.Username, arg.Username)
+						require.Len(t, arg.HashedRecoveryCodes, util.RecoveryCodeCount)
+						return enabledUser, nil
+					})
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, pendingUser.Username, pendingUser.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ConfirmTOTPResponse, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, res)
+				require.True(t, res.GetUser().GetIsTotpEnabled())
+				require.Len(t, res.GetRecoveryCodes(), util.RecoveryCodeCount)
+			},
+		},
+		{
+			name: "WrongCode",
+			code: "000000",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(pendingUser.Username)).
+					Times(1).
+					Return(pendingUser, nil)
+				store.EXPECT().
+					EnableTOTPTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, pendingUser.Username, pendingUser.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ConfirmTOTPResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+		{
+			name: "AlreadyEnabled",
+			code: code,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(enabledUser.Username)).
+					Times(1).
+					Return(enabledUser, nil)
+				store.EXPECT().
+					EnableTOTPTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, enabledUser.Username, enabledUser.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ConfirmTOTPResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.FailedPrecondition, st.Code())
+			},
+		},
+		{
+			name: "NotEnrolled",
+			code: code,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(notEnrolledUser.Username)).
+					Times(1).
+					Return(notEnrolledUser, nil)
+				store.EXPECT().
+					EnableTOTPTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, notEnrolledUser.Username, notEnrolledUser.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ConfirmTOTPResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.FailedPrecondition, st.Code())
+			},
+		},
+		{
+			name: "MFATokenAsAccessToken",
+			code: code,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, pendingUser.Username, util.MFAChallengeRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ConfirmTOTPResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+			store := mockdb.NewMockStore(storeCtrl)
+
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store, nil)
+
+			ctx := tc.buildContext(t, server.tokenMaker)
+			res, err := invoke(ctx, server, pb.SimpleBank_ConfirmTOTP_FullMethodName, &pb.ConfirmTOTPRequest{Code: tc.code}, server.ConfirmTOTP)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
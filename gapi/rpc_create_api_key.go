@@ -0,0 +1,82 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	db "github.com/yelaco/simple-bank/db/sqlc"
+	"github.com/yelaco/simple-bank/gen/pb/v1"
+	"github.com/yelaco/simple-bank/token"
+	"github.com/yelaco/simple-bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CreateAPIKey(ctx context.Context, req *pb.CreateAPIKeyRequest) (*pb.CreateAPIKeyResponse, error) {
+	authPayload, err := authorizationFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateCreateAPIKeyRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	// A key can only be granted permissions its owner has
+	for _, scope := range req.GetScopes() {
+		if !authPayload.can(scope) {
+			return nil, status.Errorf(codes.PermissionDenied, "cannot grant scope %s", scope)
+		}
+	}
+
+	id, key, hashedKey, err := token.NewAPIKey()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate api key: %s", err)
+	}
+
+	apiKey, err := server.store.CreateAPIKey(ctx, db.CreateAPIKeyParams{
+		ID:        id,
+		Username:  authPayload.Username,
+		Name:      req.GetName(),
+		HashedKey: hashedKey,
+		Scopes:    req.GetScopes(),
+		ExpiresAt: time.Now().AddDate(0, 0, int(req.GetExpiresInDays())),
+	})
+	if err != nil {
+		if db.ErrorCode(err) == db.UniqueViolation {
+			return nil, status.Errorf(codes.AlreadyExists, "api key %q already exists", req.GetName())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to create api key: %s", err)
+	}
+
+	rsp := &pb.CreateAPIKeyResponse{
+		ApiKey: convertAPIKey(apiKey),
+		Key:    key,
+	}
+	return rsp, nil
+}
+
+func validateCreateAPIKeyRequest(req *pb.CreateAPIKeyRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateAPIKeyName(req.GetName()); err != nil {
+		violations = append(violations, fieldViolation("name", err))
+	}
+
+	if len(req.GetScopes()) == 0 {
+		violations = append(violations, fieldViolation("scopes", fmt.Errorf("must not be empty")))
+	}
+
+	for _, scope := range req.GetScopes() {
+		if err := val.ValidatePermission(scope); err != nil {
+			violations = append(violations, fieldViolation("scopes", err))
+		}
+	}
+
+	if err := val.ValidateExpiresInDays(req.GetExpiresInDays()); err != nil {
+		violations = append(violations, fieldViolation("expires_in_days", err))
+	}
+
+	return violations
+}
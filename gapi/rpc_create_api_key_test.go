@@ -0,0 +1,129 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/yelaco/simple-bank/db/mock"
+	db "github.com/yelaco/simple-bank/db/sqlc"
+	"github.com/yelaco/simple-bank/gen/pb/v1"
+	"github.com/yelaco/simple-bank/permission"
+	"github.com/yelaco/simple-bank/token"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+)
+
+func TestCreateAPIKeyAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	testCases := []struct {
+		name          string
+		req           *pb.CreateAPIKeyRequest
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, res *pb.CreateAPIKeyResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &pb.CreateAPIKeyRequest{
+				Name:          "reporting",
+				Scopes:        []string{permission.AccountsReadOwn},
+				ExpiresInDays: 30,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateAPIKey(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.CreateAPIKeyParams) (db.APIKey, error) {
+						require.Equal(t, user.Username, arg.Username)
+						require.Equal(t, "reporting", arg.Name)
+						require.Equal(t, []string{permission.AccountsReadOwn}, arg.Scopes)
+						require.WithinDuration(t, time.Now().AddDate(0, 0, 30), arg.ExpiresAt, time.Second)
+						return db.APIKey{
+							ID:        arg.ID,
+							Username:  arg.Username,
+							Name:      arg.Name,
+							HashedKey: arg.HashedKey,
+							Scopes:    arg.Scopes,
+							ExpiresAt: arg.ExpiresAt,
+							CreatedAt: time.Now(),
+						}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateAPIKeyResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "reporting", res.GetApiKey().GetName())
+
+				id, err := token.ParseAPIKey(res.GetKey())
+				require.NoError(t, err)
+				require.Equal(t, id.String(), res.GetApiKey().GetId())
+			},
+		},
+		{
+			name: "ScopeNotGranted",
+			req: &pb.CreateAPIKeyRequest{
+				Name:          "reporting",
+				Scopes:        []string{permission.AccountsReadAny},
+				ExpiresInDays: 30,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateAPIKey(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateAPIKeyResponse, err error) {
+				requireStatusCode(t, codes.PermissionDenied, err)
+			},
+		},
+		{
+			name: "DuplicateName",
+			req: &pb.CreateAPIKeyRequest{
+				Name:          "reporting",
+				Scopes:        []string{permission.AccountsReadOwn},
+				ExpiresInDays: 30,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateAPIKey(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.APIKey{}, db.ErrUniqueViolation)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateAPIKeyResponse, err error) {
+				requireStatusCode(t, codes.AlreadyExists, err)
+			},
+		},
+		{
+			name: "InvalidArguments",
+			req: &pb.CreateAPIKeyRequest{
+				Name:          "x",
+				Scopes:        nil,
+				ExpiresInDays: 0,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateAPIKey(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateAPIKeyResponse, err error) {
+				requireStatusCode(t, codes.InvalidArgument, err)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store, nil)
+
+			ctx := newContextWithBearerToken(t, server.tokenMaker, user.Username, user.Role, time.Minute)
+			res, err := invoke(ctx, server, pb.SimpleBank_CreateAPIKey_FullMethodName, tc.req, server.CreateAPIKey)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/hibiken/asynq"
+	db "github.com/yelaco/simple-bank/db/sqlc"
+	"github.com/yelaco/simple-bank/gen/pb/v1"
+	"github.com/yelaco/simple-bank/val"
+	"github.com/yelaco/simple-bank/worker"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CreateHold(ctx context.Context, req *pb.CreateHoldRequest) (*pb.CreateHoldResponse, error) {
+	authPayload, err := authorizationFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateCreateHoldRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := server.validateAccount(ctx, req.GetAccountId(), req.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+
+	if account.Owner != authPayload.Username {
+		return nil, status.Errorf(codes.PermissionDenied, "account does not belong to the authenticated user")
+	}
+
+	_, err = server.validateAccount(ctx, req.GetToAccountId(), req.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+
+	txResult, err := server.store.HoldTx(ctx, db.HoldTxParams{
+		AccountID:   req.GetAccountId(),
+		ToAccountID: req.GetToAccountId(),
+		Amount:      req.GetAmount(),
+		ExpiresAt:   time.Now().Add(server.config.HoldDuration),
+		AfterCreate: func(hold db.Hold) ([]db.OutboxTask, error) {
+			taskPayload := &worker.PayloadExpireHold{
+				HoldID: hold.ID,
+			}
+			opts := []asynq.Option{
+				asynq.MaxRetry(10),
+				asynq.ProcessAt(hold.ExpiresAt),
+				asynq.Queue(worker.QueueDefault),
+			}
+			task, err := worker.NewOutboxTask(ctx, worker.TaskExpireHold, taskPayload, opts...)
+			if err != nil {
+				return nil, err
+			}
+			return []db.OutboxTask{task}, nil
+		},
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrInsufficientFunds) || errors.Is(err, db.ErrAccountClosed) {
+			return nil, status.Errorf(codes.FailedPrecondition, "%s", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to create hold: %s", err)
+	}
+
+	rsp := &pb.CreateHoldResponse{
+		Hold:    convertHold(txResult.Hold),
+		Account: convertAccount(txResult.Account),
+	}
+	return rsp, nil
+}
+
+func validateCreateHoldRequest(req *pb.CreateHoldRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+
+	if err := val.ValidateID(req.GetToAccountId()); err != nil {
+		violations = append(violations, fieldViolation("to_account_id", err))
+	}
+
+	if req.GetAccountId() == req.GetToAccountId() {
+		violations = append(violations, fieldViolation("to_account_id", errors.New("must be different from account_id")))
+	}
+
+	if err := val.ValidateAmount(req.GetAmount()); err != nil {
+		violations = append(violations, fieldViolation("amount", err))
+	}
+
+	if err := val.ValidateCurrency(req.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolation("currency", err))
+	}
+
+	return violations
+}
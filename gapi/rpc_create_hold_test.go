@@ -0,0 +1,202 @@
+package gapi
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/yelaco/simple-bank/db/mock"
+	db "github.com/yelaco/simple-bank/db/sqlc"
+	"github.com/yelaco/simple-bank/gen/pb/v1"
+	"github.com/yelaco/simple-bank/token"
+	"github.com/yelaco/simple-bank/util"
+	"github.com/yelaco/simple-bank/worker"
+	mockwk "github.com/yelaco/simple-bank/worker/mock"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type eqHoldTxParamsMatcher struct {
+	arg  db.HoldTxParams
+	hold db.Hold
+}
+
+func eqHoldTxParams(arg db.HoldTxParams, hold db.Hold) gomock.Matcher {
+	return eqHoldTxParamsMatcher{arg, hold}
+}
+
+func (expected eqHoldTxParamsMatcher) String() string {
+	return "matches hold tx params"
+}
+
+func (expected eqHoldTxParamsMatcher) Matches(x any) bool {
+	actualArg, ok := x.(db.HoldTxParams)
+	if !ok {
+		return false
+	}
+
+	if actualArg.AccountID != expected.arg.AccountID ||
+		actualArg.ToAccountID != expected.arg.ToAccountID ||
+		actualArg.Amount != expected.arg.Amount {
+		return false
+	}
+
+	tasks, err := actualArg.AfterCreate(expected.hold)
+	if err != nil || len(tasks) != 1 {
+		return false
+	}
+
+	if tasks[0].TaskType != worker.TaskExpireHold || !tasks[0].ProcessAt.Equal(expected.hold.ExpiresAt) {
+		return false
+	}
+
+	var payload worker.PayloadExpireHold
+	err = json.Unmarshal(tasks[0].Payload, &payload)
+	return err == nil && payload.HoldID == expected.hold.ID
+}
+
+func TestCreateHoldAPI(t *testing.T) {
+	amount := int64(10)
+
+	user1, _ := randomUser(t)
+	user2, _ := randomUser(t)
+
+	account1 := randomAccount(user1.Username)
+	account2 := randomAccount(user2.Username)
+	account2.ID = account1.ID + 1
+	account1.Currency = util.USD
+	account2.Currency = util.USD
+
+	hold := db.Hold{
+		ID:          util.RandomInt(1, 1000),
+		AccountID:   account1.ID,
+		ToAccountID: account2.ID,
+		Amount:      amount,
+		Status:      db.HoldStatusPending,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}
+
+	testCases := []struct {
+		name          string
+		req           *pb.CreateHoldRequest
+		buildStubs    func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.CreateHoldResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &pb.CreateHoldRequest{
+				AccountId:   account1.ID,
+				ToAccountId: account2.ID,
+				Amount:      amount,
+				Currency:    util.USD,
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+
+				heldAccount := account1
+				heldAccount.HeldAmount = amount
+
+				arg := db.HoldTxParams{
+					AccountID:   account1.ID,
+					ToAccountID: account2.ID,
+					Amount:      amount,
+				}
+				store.EXPECT().
+					HoldTx(gomock.Any(), eqHoldTxParams(arg, hold)).
+					Times(1).
+					Return(db.HoldTxResult{Hold: hold, Account: heldAccount}, nil)
+
+				// The task is written to the outbox by the transaction
+				taskDistributor.EXPECT().
+					DistributeTaskExpireHold(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user1.Username, user1.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateHoldResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, hold.ID, res.GetHold().GetId())
+				require.Equal(t, db.HoldStatusPending, res.GetHold().GetStatus())
+				require.Equal(t, account1.Balance, res.GetAccount().GetBalance())
+				require.Equal(t, account1.Balance-amount, res.GetAccount().GetAvailableBalance())
+			},
+		},
+		{
+			name: "InsufficientFunds",
+			req: &pb.CreateHoldRequest{
+				AccountId:   account1.ID,
+				ToAccountId: account2.ID,
+				Amount:      amount,
+				Currency:    util.USD,
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().
+					HoldTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.HoldTxResult{}, db.ErrInsufficientFunds)
+				taskDistributor.EXPECT().
+					DistributeTaskExpireHold(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user1.Username, user1.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateHoldResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.FailedPrecondition, st.Code())
+			},
+		},
+		{
+			name: "UnauthorizedUser",
+			req: &pb.CreateHoldRequest{
+				AccountId:   account1.ID,
+				ToAccountId: account2.ID,
+				Amount:      amount,
+				Currency:    util.USD,
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().HoldTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user2.Username, user2.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateHoldResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.PermissionDenied, st.Code())
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+			store := mockdb.NewMockStore(storeCtrl)
+
+			taskCtrl := gomock.NewController(t)
+			defer taskCtrl.Finish()
+			taskDistributor := mockwk.NewMockTaskDistributor(taskCtrl)
+
+			tc.buildStubs(store, taskDistributor)
+
+			server := newTestServer(t, store, taskDistributor)
+
+			ctx := tc.buildContext(t, server.tokenMaker)
+			res, err := invoke(ctx, server, pb.SimpleBank_CreateHold_FullMethodName, tc.req, server.CreateHold)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
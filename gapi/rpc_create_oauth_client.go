@@ -0,0 +1,84 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+
+	db "github.com/yelaco/simple-bank/db/sqlc"
+	"github.com/yelaco/simple-bank/gen/pb/v1"
+	"github.com/yelaco/simple-bank/token"
+	"github.com/yelaco/simple-bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CreateOAuthClient(ctx context.Context, req *pb.CreateOAuthClientRequest) (*pb.CreateOAuthClientResponse, error) {
+	authPayload, err := authorizationFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateCreateOAuthClientRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	// A client can only ask for permissions its owner has
+	for _, scope := range req.GetScopes() {
+		if !authPayload.can(scope) {
+			return nil, status.Errorf(codes.PermissionDenied, "cannot grant scope %s", scope)
+		}
+	}
+
+	clientID, secret, hashedSecret, err := token.NewOAuthClient()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate client credentials: %s", err)
+	}
+
+	client, err := server.store.CreateOAuthClient(ctx, db.CreateOAuthClientParams{
+		ID:           clientID,
+		Owner:        authPayload.Username,
+		Name:         req.GetName(),
+		HashedSecret: hashedSecret,
+		RedirectUris: req.GetRedirectUris(),
+		Scopes:       req.GetScopes(),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create oauth client: %s", err)
+	}
+
+	rsp := &pb.CreateOAuthClientResponse{
+		Client:       convertOAuthClient(client),
+		ClientSecret: secret,
+	}
+	return rsp, nil
+}
+
+func validateCreateOAuthClientRequest(req *pb.CreateOAuthClientRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateOAuthClientName(req.GetName()); err != nil {
+		violations = append(violations, fieldViolation("name", err))
+	}
+
+	if len(req.GetRedirectUris()) == 0 {
+		violations = append(violations, fieldViolation("redirect_uris", fmt.Errorf("must not be empty")))
+	}
+
+	for _, redirectURI := range req.GetRedirectUris() {
+		if err := val.ValidateRedirectURI(redirectURI); err != nil {
+			violations = append(violations, fieldViolation("redirect_uris", err))
+		}
+	}
+
+	if len(req.GetScopes()) == 0 {
+		violations = append(violations, fieldViolation("scopes", fmt.Errorf("must not be empty")))
+	}
+
+	for _, scope := range req.GetScopes() {
+		if err := val.ValidatePermission(scope); err != nil {
+			violations = append(violations, fieldViolation("scopes", err))
+		}
+	}
+
+	return violations
+}
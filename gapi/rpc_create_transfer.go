@@ -0,0 +1,159 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	db "github.com/yelaco/simple-bank/db/sqlc"
+	"github.com/yelaco/simple-bank/fx"
+	"github.com/yelaco/simple-bank/gen/pb/v1"
+	"github.com/yelaco/simple-bank/metrics"
+	"github.com/yelaco/simple-bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CreateTransfer(ctx context.Context, req *pb.CreateTransferRequest) (*pb.CreateTransferResponse, error) {
+	authPayload, err := authorizationFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateCreateTransferRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	fromAccount, err := server.validateAccount(ctx, req.GetFromAccountId(), req.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+
+	if fromAccount.Owner != authPayload.Username {
+		return nil, status.Errorf(codes.PermissionDenied, "from account does not belong to the authenticated user")
+	}
+
+	// the receiving account may hold another currency, the amount is then converted at the current rate
+	toAccount, err := server.getOpenAccount(ctx, req.GetToAccountId())
+	if err != nil {
+		return nil, err
+	}
+
+	arg := db.TransferTxParams{
+		FromAccountID: req.GetFromAccountId(),
+		ToAccountID:   req.GetToAccountId(),
+		Amount:        req.GetAmount(),
+		Audit:         server.auditContext(ctx, authPayload.Username),
+	}
+
+	if toAccount.Currency != fromAccount.Currency {
+		rate, err := server.rateProvider.GetRate(ctx, fromAccount.Currency, toAccount.Currency)
+		if err != nil {
+			if errors.Is(err, fx.ErrRateNotFound) {
+				return nil, status.Errorf(codes.FailedPrecondition, "%s", err)
+			}
+			return nil, status.Errorf(codes.Internal, "failed to get exchange rate: %s", err)
+		}
+
+		arg.ToAmount = fx.Convert(req.GetAmount(), rate)
+		arg.ExchangeRate = rate
+
+		if arg.ToAmount <= 0 {
+			return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{
+				fieldViolation("amount", fmt.Errorf("is too small to convert from %s to %s", fromAccount.Currency, toAccount.Currency)),
+			})
+		}
+	}
+
+	txResult, err := server.store.IdempotentTransferTx(ctx, db.IdempotentTransferTxParams{
+		TransferTxParams: arg,
+		Currency:         req.GetCurrency(),
+		Username:         authPayload.Username,
+		IdempotencyKey:   req.GetIdempotencyKey(),
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrIdempotencyKeyMismatch) {
+			return nil, status.Errorf(codes.AlreadyExists, "%s", err)
+		}
+		if errors.Is(err, db.ErrInsufficientFunds) || errors.Is(err, db.ErrAccountClosed) {
+			return nil, status.Errorf(codes.FailedPrecondition, "%s", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to transfer money: %s", err)
+	}
+
+	if !txResult.Replayed {
+		metrics.ObserveTransfer(txResult.FromAccount.Currency, txResult.Transfer.Amount)
+	}
+
+	rsp := &pb.CreateTransferResponse{
+		Transfer:    convertTransfer(txResult.Transfer),
+		FromAccount: convertAccount(txResult.FromAccount),
+		FromEntry:   convertEntry(txResult.FromEntry),
+		ToEntry:     convertEntry(txResult.ToEntry),
+		Replayed:    txResult.Replayed,
+	}
+	return rsp, nil
+}
+
+// validateAccount checks that the account exists, is still open, and holds the given currency.
+// The returned error is already a gRPC status error.
+func (server *Server) validateAccount(ctx context.Context, accountID int64, currency string) (db.Account, error) {
+	account, err := server.getOpenAccount(ctx, accountID)
+	if err != nil {
+		return account, err
+	}
+
+	if account.Currency != currency {
+		return account, status.Errorf(codes.InvalidArgument, "account [%d] currency mismatch: %s vs %s", accountID, account.Currency, currency)
+	}
+
+	return account, nil
+}
+
+// getOpenAccount checks that the account exists and is still open.
+// The returned error is already a gRPC status error.
+func (server *Server) getOpenAccount(ctx context.Context, accountID int64) (db.Account, error) {
+	account, err := server.store.GetAccount(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return account, status.Errorf(codes.NotFound, "account [%d] not found", accountID)
+		}
+		return account, status.Errorf(codes.Internal, "failed to get account: %s", err)
+	}
+
+	if account.IsClosed {
+		return account, status.Errorf(codes.FailedPrecondition, "account [%d] is closed", accountID)
+	}
+
+	return account, nil
+}
+
+func validateCreateTransferRequest(req *pb.CreateTransferRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetFromAccountId()); err != nil {
+		violations = append(violations, fieldViolation("from_account_id", err))
+	}
+
+	if err := val.ValidateID(req.GetToAccountId()); err != nil {
+		violations = append(violations, fieldViolation("to_account_id", err))
+	}
+
+	if req.GetFromAccountId() == req.GetToAccountId() {
+		violations = append(violations, fieldViolation("to_account_id", errors.New("must be different from from_account_id")))
+	}
+
+	if err := val.ValidateAmount(req.GetAmount()); err != nil {
+		violations = append(violations, fieldViolation("amount", err))
+	}
+
+	if err := val.ValidateCurrency(req.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolation("currency", err))
+	}
+
+	if err := val.ValidateIdempotencyKey(req.GetIdempotencyKey()); err != nil {
+		violations = append(violations, fieldViolation("idempotency_key", err))
+	}
+
+	return violations
+}
@@ -33,7 +33,7 @@ func (server *Server) CreateUser(ctx context.Context, req *pb.CreateUserRequest)
 			FullName:       req.GetFullName(),
 			Email:          req.GetEmail(),
 		},
-		AfterCreate: func(user db.User) error {
+		AfterCreate: func(user db.User) ([]db.OutboxTask, error) {
 			taskPayload := &worker.PayloadSendVerifyEmail{
 				Username: user.Username,
 			}
@@ -42,7 +42,11 @@ func (server *Server) CreateUser(ctx context.Context, req *pb.CreateUserRequest)
 				asynq.ProcessIn(10 * time.Second),
 				asynq.Queue(worker.QueueCritical),
 			}
-			return server.taskDistributor.DistributeTaskSendVerifyEmail(ctx, taskPayload, opts...)
+			task, err := worker.NewOutboxTask(ctx, worker.TaskSendVerifyEmail, taskPayload, opts...)
+			if err != nil {
+				return nil, err
+			}
+			return []db.OutboxTask{task}, nil
 		},
 	}
 
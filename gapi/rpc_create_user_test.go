@@ -3,6 +3,7 @@ package gapi
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"testing"
@@ -50,8 +51,18 @@ func (expected eqCreateUserTxParamsMatcher) Matches(x any) bool {
 		return false
 	}
 
-	err = actualArg.AfterCreate(expected.user)
-	return err == nil
+	tasks, err := actualArg.AfterCreate(expected.user)
+	if err != nil || len(tasks) != 1 {
+		return false
+	}
+
+	if tasks[0].TaskType != worker.TaskSendVerifyEmail || tasks[0].Queue != worker.QueueCritical {
+		return false
+	}
+
+	var payload worker.PayloadSendVerifyEmail
+	err = json.Unmarshal(tasks[0].Payload, &payload)
+	return err == nil && payload.Username == expected.user.Username
 }
 
 func TestCreateUserAPI(t *testing.T) {
@@ -85,13 +96,10 @@ func TestCreateUserAPI(t *testing.T) {
 					Times(1).
 					Return(db.CreateUserTxResult{User: user}, nil)
 
-				taskPayload := &worker.PayloadSendVerifyEmail{
-					Username: user.Username,
-				}
+				// The task is written to the outbox by the transaction
 				taskDistributor.EXPECT().
-					DistributeTaskSendVerifyEmail(gomock.Any(), taskPayload, gomock.Any()).
-					Times(1).
-					Return(nil)
+					DistributeTaskSendVerifyEmail(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
 			},
 			checkResponse: func(t *testing.T, res *pb.CreateUserResponse, err error) {
 				require.NoError(t, err)
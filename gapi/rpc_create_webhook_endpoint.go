@@ -0,0 +1,67 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	db "github.com/yelaco/simple-bank/db/sqlc"
+	"github.com/yelaco/simple-bank/gen/pb/v1"
+	"github.com/yelaco/simple-bank/val"
+	"github.com/yelaco/simple-bank/webhook"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CreateWebhookEndpoint(ctx context.Context, req *pb.CreateWebhookEndpointRequest) (*pb.CreateWebhookEndpointResponse, error) {
+	authPayload, err := authorizationFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateCreateWebhookEndpointRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	secret, err := webhook.NewSecret()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate webhook secret: %s", err)
+	}
+
+	endpoint, err := server.store.CreateWebhookEndpoint(ctx, db.CreateWebhookEndpointParams{
+		Owner:      authPayload.Username,
+		Url:        req.GetUrl(),
+		Secret:     secret,
+		EventTypes: req.GetEventTypes(),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create webhook endpoint: %s", err)
+	}
+
+	// The secret is only returned here, the endpoint never exposes it again
+	rsp := &pb.CreateWebhookEndpointResponse{
+		Endpoint: convertWebhookEndpoint(endpoint),
+		Secret:   secret,
+	}
+	return rsp, nil
+}
+
+func validateCreateWebhookEndpointRequest(req *pb.CreateWebhookEndpointRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateWebhookURL(req.GetUrl()); err != nil {
+		violations = append(violations, fieldViolation("url", err))
+	}
+
+	if len(req.GetEventTypes()) == 0 {
+		violations = append(violations, fieldViolation("event_types", fmt.Errorf("must not be empty")))
+	}
+
+	for _, eventType := range req.GetEventTypes() {
+		if !slices.Contains(db.WebhookEventTypes, eventType) {
+			violations = append(violations, fieldViolation("event_types", fmt.Errorf("unsupported event type %q", eventType)))
+		}
+	}
+
+	return violations
+}
@@ -0,0 +1,193 @@
+package gapi
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/yelaco/simple-bank/db/mock"
+	db "github.com/yelaco/simple-bank/db/sqlc"
+	"github.com/yelaco/simple-bank/gen/pb/v1"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+)
+
+func TestCreateWebhookEndpointAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	testCases := []struct {
+		name          string
+		req           *pb.CreateWebhookEndpointRequest
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, res *pb.CreateWebhookEndpointResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &pb.CreateWebhookEndpointRequest{
+				Url:        "https://partner.example.com/hooks",
+				EventTypes: []string{db.WebhookEventTransferCreated},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateWebhookEndpoint(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.CreateWebhookEndpointParams) (db.WebhookEndpoint, error) {
+						require.Equal(t, user.Username, arg.Owner)
+						require.Equal(t, "https://partner.example.com/hooks", arg.Url)
+						require.Equal(t, []string{db.WebhookEventTransferCreated}, arg.EventTypes)
+						require.NotEmpty(t, arg.Secret)
+						return db.WebhookEndpoint{
+							ID:         1,
+							Owner:      arg.Owner,
+							Url:        arg.Url,
+							Secret:     arg.Secret,
+							EventTypes: arg.EventTypes,
+							CreatedAt:  time.Now(),
+						}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateWebhookEndpointResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, int64(1), res.GetEndpoint().GetId())
+				require.True(t, strings.HasPrefix(res.GetSecret(), "whsec_"))
+			},
+		},
+		{
+			name: "InsecureURL",
+			req: &pb.CreateWebhookEndpointRequest{
+				Url:        "http://partner.example.com/hooks",
+				EventTypes: []string{db.WebhookEventTransferCreated},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateWebhookEndpoint(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateWebhookEndpointResponse, err error) {
+				requireStatusCode(t, codes.InvalidArgument, err)
+			},
+		},
+		{
+			name: "LocalHost",
+			req: &pb.CreateWebhookEndpointRequest{
+				Url:        "https://localhost/hooks",
+				EventTypes: []string{db.WebhookEventTransferCreated},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateWebhookEndpoint(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateWebhookEndpointResponse, err error) {
+				requireStatusCode(t, codes.InvalidArgument, err)
+			},
+		},
+		{
+			name: "LoopbackAddress",
+			req: &pb.CreateWebhookEndpointRequest{
+				Url:        "https://127.0.0.1/hooks",
+				EventTypes: []string{db.WebhookEventTransferCreated},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateWebhookEndpoint(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateWebhookEndpointResponse, err error) {
+				requireStatusCode(t, codes.InvalidArgument, err)
+			},
+		},
+		{
+			name: "PrivateAddress",
+			req: &pb.CreateWebhookEndpointRequest{
+				Url:        "https://10.0.0.5/hooks",
+				EventTypes: []string{db.WebhookEventTransferCreated},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateWebhookEndpoint(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateWebhookEndpointResponse, err error) {
+				requireStatusCode(t, codes.InvalidArgument, err)
+			},
+		},
+		{
+			name: "LinkLocalAddress",
+			req: &pb.CreateWebhookEndpointRequest{
+				Url:        "https://169.254.169.254/latest/meta-data",
+				EventTypes: []string{db.WebhookEventTransferCreated},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateWebhookEndpoint(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateWebhookEndpointResponse, err error) {
+				requireStatusCode(t, codes.InvalidArgument, err)
+			},
+		},
+		{
+			name: "UnspecifiedAddress",
+			req: &pb.CreateWebhookEndpointRequest{
+				Url:        "https://[::]/hooks",
+				EventTypes: []string{db.WebhookEventTransferCreated},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateWebhookEndpoint(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateWebhookEndpointResponse, err error) {
+				requireStatusCode(t, codes.InvalidArgument, err)
+			},
+		},
+		{
+			name: "UnsupportedEventType",
+			req: &pb.CreateWebhookEndpointRequest{
+				Url:        "https://partner.example.com/hooks",
+				EventTypes: []string{"transfer.deleted"},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateWebhookEndpoint(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateWebhookEndpointResponse, err error) {
+				requireStatusCode(t, codes.InvalidArgument, err)
+			},
+		},
+		{
+			name: "NoEventTypes",
+			req: &pb.CreateWebhookEndpointRequest{
+				Url: "https://partner.example.com/hooks",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateWebhookEndpoint(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateWebhookEndpointResponse, err error) {
+				requireStatusCode(t, codes.InvalidArgument, err)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store, nil)
+
+			ctx := newContextWithBearerToken(t, server.tokenMaker, user.Username, user.Role, time.Minute)
+			res, err := invoke(ctx, server, pb.SimpleBank_CreateWebhookEndpoint_FullMethodName, tc.req, server.CreateWebhookEndpoint)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/yelaco/simple-bank/db/sqlc"
+	"github.com/yelaco/simple-bank/gen/pb/v1"
+	"github.com/yelaco/simple-bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) DeleteWebhookEndpoint(ctx context.Context, req *pb.DeleteWebhookEndpointRequest) (*pb.DeleteWebhookEndpointResponse, error) {
+	authPayload, err := authorizationFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateDeleteWebhookEndpointRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	// The endpoint is only disabled, so that its delivery log is kept.
+	// Endpoints of other users are reported as not found
+	endpoint, err := server.store.DisableWebhookEndpoint(ctx, db.DisableWebhookEndpointParams{
+		ID:    req.GetEndpointId(),
+		Owner: authPayload.Username,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "webhook endpoint not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to delete webhook endpoint: %s", err)
+	}
+
+	rsp := &pb.DeleteWebhookEndpointResponse{
+		Endpoint: convertWebhookEndpoint(endpoint),
+	}
+	return rsp, nil
+}
+
+func validateDeleteWebhookEndpointRequest(req *pb.DeleteWebhookEndpointRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetEndpointId()); err != nil {
+		violations = append(violations, fieldViolation("endpoint_id", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,60 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/yelaco/simple-bank/db/sqlc"
+	"github.com/yelaco/simple-bank/gen/pb/v1"
+	"github.com/yelaco/simple-bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) DisableTOTP(ctx context.Context, req *pb.DisableTOTPRequest) (*pb.DisableTOTPResponse, error) {
+	authPayload, err := authorizationFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateDisableTOTPRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	user, err := server.store.GetUser(ctx, authPayload.Username)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get user: %s", err)
+	}
+
+	if !user.IsTotpEnabled {
+		return nil, status.Errorf(codes.FailedPrecondition, "two-factor authentication is not enabled")
+	}
+
+	err = server.verifySecondFactor(ctx, user, req.GetCode())
+	if err != nil {
+		return nil, secondFactorError(err)
+	}
+
+	user, err = server.store.DisableTOTPTx(ctx, user.Username)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to disable totp: %s", err)
+	}
+
+	rsp := &pb.DisableTOTPResponse{
+		User: convertUser(user),
+	}
+	return rsp, nil
+}
+
+func validateDisableTOTPRequest(req *pb.DisableTOTPRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateMFACode(req.GetCode()); err != nil {
+		violations = append(violations, fieldViolation("code", err))
+	}
+
+	return violations
+}
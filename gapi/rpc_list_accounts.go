@@ -0,0 +1,83 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/yelaco/simple-bank/db/sqlc"
+	"github.com/yelaco/simple-bank/gen/pb/v1"
+	"github.com/yelaco/simple-bank/permission"
+	"github.com/yelaco/simple-bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListAccounts(ctx context.Context, req *pb.ListAccountsRequest) (*pb.ListAccountsResponse, error) {
+	authPayload, err := authorizationFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateListAccountsRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	if !authPayload.can(permission.AccountsReadAny) && req.Owner != nil && authPayload.Username != req.GetOwner() {
+		return nil, status.Errorf(codes.PermissionDenied, "cannot list other user's accounts")
+	}
+
+	limit := req.GetPageSize()
+	offset := (req.GetPageId() - 1) * req.GetPageSize()
+
+	var accounts []db.Account
+	switch {
+	case req.Owner != nil:
+		accounts, err = server.store.ListAccounts(ctx, db.ListAccountsParams{
+			Owner:  req.GetOwner(),
+			Limit:  limit,
+			Offset: offset,
+		})
+	case authPayload.can(permission.AccountsReadAny):
+		accounts, err = server.store.ListAllAccounts(ctx, db.ListAllAccountsParams{
+			Limit:  limit,
+			Offset: offset,
+		})
+	default:
+		accounts, err = server.store.ListAccounts(ctx, db.ListAccountsParams{
+			Owner:  authPayload.Username,
+			Limit:  limit,
+			Offset: offset,
+		})
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list accounts: %s", err)
+	}
+
+	rsp := &pb.ListAccountsResponse{
+		Accounts: make([]*pb.Account, 0, len(accounts)),
+	}
+	for _, account := range accounts {
+		rsp.Accounts = append(rsp.Accounts, convertAccount(account))
+	}
+
+	return rsp, nil
+}
+
+func validateListAccountsRequest(req *pb.ListAccountsRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if req.Owner != nil {
+		if err := val.ValidateUsername(req.GetOwner()); err != nil {
+			violations = append(violations, fieldViolation("owner", err))
+		}
+	}
+
+	if err := val.ValidatePageID(req.GetPageId()); err != nil {
+		violations = append(violations, fieldViolation("page_id", err))
+	}
+
+	if err := val.ValidatePageSize(req.GetPageSize()); err != nil {
+		violations = append(violations, fieldViolation("page_size", err))
+	}
+
+	return violations
+}
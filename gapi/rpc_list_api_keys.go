@@ -0,0 +1,30 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/yelaco/simple-bank/gen/pb/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListAPIKeys(ctx context.Context, req *pb.ListAPIKeysRequest) (*pb.ListAPIKeysResponse, error) {
+	authPayload, err := authorizationFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	apiKeys, err := server.store.ListAPIKeys(ctx, authPayload.Username)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list api keys: %s", err)
+	}
+
+	rsp := &pb.ListAPIKeysResponse{
+		ApiKeys: make([]*pb.APIKey, 0, len(apiKeys)),
+	}
+	for _, apiKey := range apiKeys {
+		rsp.ApiKeys = append(rsp.ApiKeys, convertAPIKey(apiKey))
+	}
+
+	return rsp, nil
+}
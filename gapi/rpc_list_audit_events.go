@@ -0,0 +1,84 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/yelaco/simple-bank/db/sqlc"
+	"github.com/yelaco/simple-bank/gen/pb/v1"
+	"github.com/yelaco/simple-bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListAuditEvents(ctx context.Context, req *pb.ListAuditEventsRequest) (*pb.ListAuditEventsResponse, error) {
+	_, err := authorizationFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateListAuditEventsRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	events, err := server.store.ListAuditEvents(ctx, db.ListAuditEventsParams{
+		Actor: pgtype.Text{
+			String: req.GetActor(),
+			Valid:  req.Actor != nil,
+		},
+		Action: pgtype.Text{
+			String: req.GetAction(),
+			Valid:  req.Action != nil,
+		},
+		Target: pgtype.Text{
+			String: req.GetTarget(),
+			Valid:  req.Target != nil,
+		},
+		Limit:  req.GetPageSize(),
+		Offset: (req.GetPageId() - 1) * req.GetPageSize(),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list audit events: %s", err)
+	}
+
+	rsp := &pb.ListAuditEventsResponse{
+		Events: make([]*pb.AuditEvent, 0, len(events)),
+	}
+	for _, event := range events {
+		rsp.Events = append(rsp.Events, convertAuditEvent(event))
+	}
+
+	return rsp, nil
+}
+
+func validateListAuditEventsRequest(req *pb.ListAuditEventsRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if req.Actor != nil {
+		if err := val.ValidateUsername(req.GetActor()); err != nil {
+			violations = append(violations, fieldViolation("actor", err))
+		}
+	}
+
+	if req.Action != nil {
+		if err := val.ValidateString(req.GetAction(), 1, 100); err != nil {
+			violations = append(violations, fieldViolation("action", err))
+		}
+	}
+
+	if req.Target != nil {
+		if err := val.ValidateString(req.GetTarget(), 1, 100); err != nil {
+			violations = append(violations, fieldViolation("target", err))
+		}
+	}
+
+	if err := val.ValidatePageID(req.GetPageId()); err != nil {
+		violations = append(violations, fieldViolation("page_id", err))
+	}
+
+	if err := val.ValidatePageSize(req.GetPageSize()); err != nil {
+		violations = append(violations, fieldViolation("page_size", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,30 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/yelaco/simple-bank/gen/pb/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListWebhookEndpoints(ctx context.Context, req *pb.ListWebhookEndpointsRequest) (*pb.ListWebhookEndpointsResponse, error) {
+	authPayload, err := authorizationFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints, err := server.store.ListWebhookEndpoints(ctx, authPayload.Username)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list webhook endpoints: %s", err)
+	}
+
+	rsp := &pb.ListWebhookEndpointsResponse{
+		Endpoints: make([]*pb.WebhookEndpoint, 0, len(endpoints)),
+	}
+	for _, endpoint := range endpoints {
+		rsp.Endpoints = append(rsp.Endpoints, convertWebhookEndpoint(endpoint))
+	}
+
+	return rsp, nil
+}
@@ -6,6 +6,7 @@ import (
 
 	db "github.com/yelaco/simple-bank/db/sqlc"
 	"github.com/yelaco/simple-bank/gen/pb/v1"
+	"github.com/yelaco/simple-bank/token"
 	"github.com/yelaco/simple-bank/util"
 	"github.com/yelaco/simple-bank/val"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
@@ -20,19 +21,84 @@ func (server *Server) LoginUser(ctx context.Context, req *pb.LoginUserRequest) (
 		return nil, invalidArgumentError(violations)
 	}
 
+	mtdt := server.extractMetadata(ctx)
+	if err := server.checkClientIP(ctx, mtdt.ClientIP); err != nil {
+		return nil, err
+	}
+
 	user, err := server.store.GetUser(ctx, req.GetUsername())
 	if err != nil {
 		if errors.Is(err, db.ErrRecordNotFound) {
+			if err := server.recordUnknownUserLogin(ctx, req.GetUsername(), mtdt.ClientIP); err != nil {
+				return nil, err
+			}
 			return nil, status.Errorf(codes.NotFound, "user not found")
 		}
 		return nil, status.Errorf(codes.Internal, "failed to find user: %s", err)
 	}
 
+	if err := checkUserLocked(user); err != nil {
+		return nil, err
+	}
+
 	err = util.CheckPassword(req.Password, user.HashedPassword)
 	if err != nil {
+		if err := server.recordFailedLogin(ctx, user, mtdt.ClientIP); err != nil {
+			return nil, err
+		}
 		return nil, status.Errorf(codes.Unauthenticated, "incorrect password")
 	}
 
+	if user.IsTotpEnabled {
+		mfaToken, mfaPayload, err := server.tokenMaker.CreateToken(
+			user.Username,
+			util.MFAChallengeRole,
+			server.config.MFATokenDuration,
+		)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to create mfa token: %s", err)
+		}
+
+		resp := &pb.LoginUserResponse{
+			User:              convertUser(user),
+			MfaRequired:       true,
+			MfaToken:          mfaToken,
+			MfaTokenExpiresAt: timestamppb.New(mfaPayload.ExpiredAt),
+		}
+		return resp, nil
+	}
+
+	login, err := server.createLoginSession(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.LoginUserResponse{
+		User:                  convertUser(user),
+		SessionId:             login.session.ID.String(),
+		AccessToken:           login.accessToken,
+		RefreshToken:          login.refreshToken,
+		AccessTokenExpiresAt:  timestamppb.New(login.accessPayload.ExpiredAt),
+		RefreshTokenExpiresAt: timestamppb.New(login.refreshPayload.ExpiredAt),
+	}
+	return resp, nil
+}
+
+type loginSession struct {
+	session        db.Session
+	accessToken    string
+	accessPayload  *token.Payload
+	refreshToken   string
+	refreshPayload *token.Payload
+}
+
+// createLoginSession issues the access and refresh tokens of a fully
+// authenticated user and records the session of the refresh token
+func (server *Server) createLoginSession(ctx context.Context, user db.User) (*loginSession, error) {
+	if err := server.resetFailedLogins(ctx, user); err != nil {
+		return nil, err
+	}
+
 	accessToken, accessPayload, err := server.tokenMaker.CreateToken(
 		user.Username,
 		user.Role,
@@ -54,6 +120,7 @@ func (server *Server) LoginUser(ctx context.Context, req *pb.LoginUserRequest) (
 	mtdt := server.extractMetadata(ctx)
 	session, err := server.store.CreateSession(ctx, db.CreateSessionParams{
 		ID:           refreshPayload.ID,
+		FamilyID:     refreshPayload.ID,
 		Username:     user.Username,
 		RefreshToken: refreshToken,
 		UserAgent:    mtdt.UserAgent,
@@ -65,15 +132,20 @@ func (server *Server) LoginUser(ctx context.Context, req *pb.LoginUserRequest) (
 		return nil, status.Errorf(codes.Internal, "failed to login user: %s", err)
 	}
 
-	resp := &pb.LoginUserResponse{
-		User:                  convertUser(user),
-		SessionId:             session.ID.String(),
-		AccessToken:           accessToken,
-		RefreshToken:          refreshToken,
-		AccessTokenExpiresAt:  timestamppb.New(accessPayload.ExpiredAt),
-		RefreshTokenExpiresAt: timestamppb.New(refreshPayload.ExpiredAt),
+	err = server.recordAuditEvent(ctx, server.auditContext(ctx, user.Username), db.AuditActionLogin, db.AuditTarget("user", user.Username), map[string]any{
+		"session_id": session.ID,
+	})
+	if err != nil {
+		return nil, err
 	}
-	return resp, nil
+
+	return &loginSession{
+		session:        session,
+		accessToken:    accessToken,
+		accessPayload:  accessPayload,
+		refreshToken:   refreshToken,
+		refreshPayload: refreshPayload,
+	}, nil
 }
 
 func validateLoginUserRequest(req *pb.LoginUserRequest) (violations []*errdetails.BadRequest_FieldViolation) {
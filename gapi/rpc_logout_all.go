@@ -0,0 +1,28 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/yelaco/simple-bank/gen/pb/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) LogoutAll(ctx context.Context, req *pb.LogoutAllRequest) (*pb.LogoutAllResponse, error) {
+	authPayload, err := authorizationFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// API keys are revoked too, as they are not checked against the revocation time of access tokens
+	result, err := server.store.LogoutAllTx(ctx, authPayload.Username)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to logout sessions: %s", err)
+	}
+	server.userState.Invalidate(authPayload.Username)
+
+	rsp := &pb.LogoutAllResponse{
+		RevokedSessions: result.RevokedSessions,
+	}
+	return rsp, nil
+}
@@ -0,0 +1,194 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/yelaco/simple-bank/db/mock"
+	db "github.com/yelaco/simple-bank/db/sqlc"
+	"github.com/yelaco/simple-bank/gen/pb/v1"
+	"github.com/yelaco/simple-bank/token"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestLogoutAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+
+	testCases := []struct {
+		name          string
+		tokenUsername string
+		buildStubs    func(store *mockdb.MockStore, session db.Session)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.LogoutResponse, err error)
+	}{
+		{
+			name:          "OK",
+			tokenUsername: user.Username,
+			buildStubs: func(store *mockdb.MockStore, session db.Session) {
+				store.EXPECT().
+					GetSession(gomock.Any(), gomock.Eq(session.ID)).
+					Times(1).
+					Return(session, nil)
+				store.EXPECT().
+					BlockSessionFamily(gomock.Any(), gomock.Eq(session.FamilyID)).
+					Times(1).
+					Return(int64(1), nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.LogoutResponse, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, res)
+			},
+		},
+		{
+			name:          "OtherUserToken",
+			tokenUsername: otherUser.Username,
+			buildStubs: func(store *mockdb.MockStore, session db.Session) {
+				store.EXPECT().
+					GetSession(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					BlockSessionFamily(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.LogoutResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.PermissionDenied, st.Code())
+			},
+		},
+		{
+			name:          "MismatchedSessionToken",
+			tokenUsername: user.Username,
+			buildStubs: func(store *mockdb.MockStore, session db.Session) {
+				session.RefreshToken = "other"
+				store.EXPECT().
+					GetSession(gomock.Any(), gomock.Eq(session.ID)).
+					Times(1).
+					Return(session, nil)
+				store.EXPECT().
+					BlockSessionFamily(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.LogoutResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+		{
+			name:          "AlreadyBlocked",
+			tokenUsername: user.Username,
+			buildStubs: func(store *mockdb.MockStore, session db.Session) {
+				session.IsBlocked = true
+				store.EXPECT().
+					GetSession(gomock.Any(), gomock.Eq(session.ID)).
+					Times(1).
+					Return(session, nil)
+				store.EXPECT().
+					BlockSessionFamily(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.LogoutResponse, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:          "NoAuthorization",
+			tokenUsername: user.Username,
+			buildStubs: func(store *mockdb.MockStore, session db.Session) {
+				store.EXPECT().
+					GetSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return context.Background()
+			},
+			checkResponse: func(t *testing.T, res *pb.LogoutResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+			store := mockdb.NewMockStore(storeCtrl)
+
+			server := newTestServer(t, store, nil)
+
+			refreshToken, refreshPayload, err := server.tokenMaker.CreateToken(tc.tokenUsername, user.Role, time.Hour)
+			require.NoError(t, err)
+
+			session := randomSession(tc.tokenUsername)
+			session.ID = refreshPayload.ID
+			session.RefreshToken = refreshToken
+
+			tc.buildStubs(store, session)
+
+			ctx := tc.buildContext(t, server.tokenMaker)
+			res, err := invoke(ctx, server, pb.SimpleBank_Logout_FullMethodName, &pb.LogoutRequest{RefreshToken: refreshToken}, server.Logout)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestLogoutAllAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	storeCtrl := gomock.NewController(t)
+	defer storeCtrl.Finish()
+	store := mockdb.NewMockStore(storeCtrl)
+
+	gomock.InOrder(
+		store.EXPECT().
+			GetUserTokenState(gomock.Any(), gomock.Eq(user.Username)).
+			Times(1).
+			Return(db.GetUserTokenStateRow{}, nil),
+		store.EXPECT().
+			LogoutAllTx(gomock.Any(), gomock.Eq(user.Username)).
+			Times(1).
+			Return(db.LogoutAllTxResult{User: user, RevokedSessions: 2, RevokedAPIKeys: 1}, nil),
+		// the cached state is dropped, so the revocation is seen right away
+		store.EXPECT().
+			GetUserTokenState(gomock.Any(), gomock.Eq(user.Username)).
+			Times(1).
+			Return(db.GetUserTokenStateRow{TokensRevokedAt: time.Now().Add(time.Second)}, nil),
+	)
+
+	server := newTestServer(t, store, nil)
+	server.config.UserStateCacheDuration = time.Minute
+	ctx := newContextWithBearerToken(t, server.tokenMaker, user.Username, user.Role, time.Minute)
+
+	res, err := invoke(ctx, server, pb.SimpleBank_LogoutAll_FullMethodName, &pb.LogoutAllRequest{}, server.LogoutAll)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), res.GetRevokedSessions())
+
+	_, err = invoke(ctx, server, pb.SimpleBank_LogoutAll_FullMethodName, &pb.LogoutAllRequest{}, server.LogoutAll)
+	require.Error(t, err)
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, codes.Unauthenticated, st.Code())
+}
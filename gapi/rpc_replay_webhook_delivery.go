@@ -0,0 +1,63 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/yelaco/simple-bank/db/sqlc"
+	"github.com/yelaco/simple-bank/gen/pb/v1"
+	"github.com/yelaco/simple-bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ReplayWebhookDelivery(ctx context.Context, req *pb.ReplayWebhookDeliveryRequest) (*pb.ReplayWebhookDeliveryResponse, error) {
+	authPayload, err := authorizationFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateReplayWebhookDeliveryRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	delivery, err := server.store.GetWebhookDelivery(ctx, req.GetDeliveryId())
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "webhook delivery not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get webhook delivery: %s", err)
+	}
+
+	endpoint, err := server.getOwnWebhookEndpoint(ctx, delivery.EndpointID, authPayload.Username)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, status.Errorf(codes.NotFound, "webhook delivery not found")
+		}
+		return nil, err
+	}
+
+	if endpoint.DisabledAt.Valid {
+		return nil, status.Errorf(codes.FailedPrecondition, "webhook endpoint is deleted")
+	}
+
+	replay, err := server.store.ReplayWebhookDeliveryTx(ctx, delivery.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to replay webhook delivery: %s", err)
+	}
+
+	rsp := &pb.ReplayWebhookDeliveryResponse{
+		Delivery: convertWebhookDelivery(replay),
+	}
+	return rsp, nil
+}
+
+func validateReplayWebhookDeliveryRequest(req *pb.ReplayWebhookDeliveryRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetDeliveryId()); err != nil {
+		violations = append(violations, fieldViolation("delivery_id", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,164 @@
+package gapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/yelaco/simple-bank/db/mock"
+	db "github.com/yelaco/simple-bank/db/sqlc"
+	"github.com/yelaco/simple-bank/gen/pb/v1"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+)
+
+func TestReplayWebhookDeliveryAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	endpoint := db.WebhookEndpoint{
+		ID:         1,
+		Owner:      user.Username,
+		Url:        "https://partner.example.com/hooks",
+		EventTypes: []string{db.WebhookEventTransferCreated},
+		CreatedAt:  time.Now(),
+	}
+	delivery := db.WebhookDelivery{
+		ID:         10,
+		EndpointID: endpoint.ID,
+		EventID:    uuid.New(),
+		EventType:  db.WebhookEventTransferCreated,
+		Payload:    []byte(`{}`),
+		Status:     db.WebhookDeliveryStatusFailed,
+		Attempts:   11,
+		CreatedAt:  time.Now(),
+	}
+
+	otherEndpoint := endpoint
+	otherEndpoint.Owner = "someone_else"
+
+	disabledEndpoint := endpoint
+	disabledEndpoint.DisabledAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+
+	testCases := []struct {
+		name          string
+		req           *pb.ReplayWebhookDeliveryRequest
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, res *pb.ReplayWebhookDeliveryResponse, err error)
+	}{
+		{
+			name: "OK",
+			req:  &pb.ReplayWebhookDeliveryRequest{DeliveryId: delivery.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWebhookDelivery(gomock.Any(), gomock.Eq(delivery.ID)).
+					Times(1).
+					Return(delivery, nil)
+				store.EXPECT().
+					GetWebhookEndpoint(gomock.Any(), gomock.Eq(endpoint.ID)).
+					Times(1).
+					Return(endpoint, nil)
+
+				replay := delivery
+				replay.ID = delivery.ID + 1
+				replay.Status = db.WebhookDeliveryStatusPending
+				replay.Attempts = 0
+				store.EXPECT().
+					ReplayWebhookDeliveryTx(gomock.Any(), gomock.Eq(delivery.ID)).
+					Times(1).
+					Return(replay, nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.ReplayWebhookDeliveryResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, delivery.ID+1, res.GetDelivery().GetId())
+				require.Equal(t, delivery.EventID.String(), res.GetDelivery().GetEventId())
+				require.Equal(t, db.WebhookDeliveryStatusPending, res.GetDelivery().GetStatus())
+			},
+		},
+		{
+			name: "DeliveryNotFound",
+			req:  &pb.ReplayWebhookDeliveryRequest{DeliveryId: delivery.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWebhookDelivery(gomock.Any(), gomock.Eq(delivery.ID)).
+					Times(1).
+					Return(db.WebhookDelivery{}, db.ErrRecordNotFound)
+				store.EXPECT().
+					ReplayWebhookDeliveryTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.ReplayWebhookDeliveryResponse, err error) {
+				requireStatusCode(t, codes.NotFound, err)
+			},
+		},
+		{
+			name: "OtherUsersEndpoint",
+			req:  &pb.ReplayWebhookDeliveryRequest{DeliveryId: delivery.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWebhookDelivery(gomock.Any(), gomock.Eq(delivery.ID)).
+					Times(1).
+					Return(delivery, nil)
+				store.EXPECT().
+					GetWebhookEndpoint(gomock.Any(), gomock.Eq(endpoint.ID)).
+					Times(1).
+					Return(otherEndpoint, nil)
+				store.EXPECT().
+					ReplayWebhookDeliveryTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.ReplayWebhookDeliveryResponse, err error) {
+				requireStatusCode(t, codes.NotFound, err)
+			},
+		},
+		{
+			name: "EndpointDisabled",
+			req:  &pb.ReplayWebhookDeliveryRequest{DeliveryId: delivery.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWebhookDelivery(gomock.Any(), gomock.Eq(delivery.ID)).
+					Times(1).
+					Return(delivery, nil)
+				store.EXPECT().
+					GetWebhookEndpoint(gomock.Any(), gomock.Eq(endpoint.ID)).
+					Times(1).
+					Return(disabledEndpoint, nil)
+				store.EXPECT().
+					ReplayWebhookDeliveryTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.ReplayWebhookDeliveryResponse, err error) {
+				requireStatusCode(t, codes.FailedPrecondition, err)
+			},
+		},
+		{
+			name: "InvalidID",
+			req:  &pb.ReplayWebhookDeliveryRequest{DeliveryId: 0},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWebhookDelivery(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.ReplayWebhookDeliveryResponse, err error) {
+				requireStatusCode(t, codes.InvalidArgument, err)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store, nil)
+
+			ctx := newContextWithBearerToken(t, server.tokenMaker, user.Username, user.Role, time.Minute)
+			res, err := invoke(ctx, server, pb.SimpleBank_ReplayWebhookDelivery_FullMethodName, tc.req, server.ReplayWebhookDelivery)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/hibiken/asynq"
+	db "github.com/yelaco/simple-bank/db/sqlc"
+	"github.com/yelaco/simple-bank/gen/pb/v1"
+	"github.com/yelaco/simple-bank/util"
+	"github.com/yelaco/simple-bank/val"
+	"github.com/yelaco/simple-bank/worker"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// passwordResetInterval is the minimum time between two reset emails for the same user
+const passwordResetInterval = time.Minute
+
+func (server *Server) RequestPasswordReset(ctx context.Context, req *pb.RequestPasswordResetRequest) (*pb.RequestPasswordResetResponse, error) {
+	violations := validateRequestPasswordResetRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	// Do not reveal whether an account exists for the email
+	user, err := server.store.GetUserByEmail(ctx, req.GetEmail())
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return &pb.RequestPasswordResetResponse{}, nil
+		}
+		return nil, status.Errorf(codes.Internal, "failed to find user: %s", err)
+	}
+
+	secretCode, err := util.GenerateSecretCode(32)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate secret code: %s", err)
+	}
+
+	_, err = server.store.RequestPasswordResetTx(ctx, db.RequestPasswordResetTxParams{
+		Username:    user.Username,
+		SecretCode:  secretCode,
+		MinInterval: passwordResetInterval,
+		AfterCreate: func(passwordReset db.PasswordReset) ([]db.OutboxTask, error) {
+			taskPayload := &worker.PayloadSendPasswordResetEmail{
+				PasswordResetID: passwordReset.ID,
+			}
+			opts := []asynq.Option{
+				asynq.MaxRetry(10),
+				asynq.Queue(worker.QueueCritical),
+			}
+			task, err := worker.NewOutboxTask(ctx, worker.TaskSendPasswordResetEmail, taskPayload, opts...)
+			if err != nil {
+				return nil, err
+			}
+			return []db.OutboxTask{task}, nil
+		},
+	})
+	// A reset email was sent recently, the user can use that one
+	if err != nil && !errors.Is(err, db.ErrPasswordResetTooSoon) {
+		return nil, status.Errorf(codes.Internal, "failed to create password reset: %s", err)
+	}
+
+	return &pb.RequestPasswordResetResponse{}, nil
+}
+
+func validateRequestPasswordResetRequest(req *pb.RequestPasswordResetRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateEmail(req.GetEmail()); err != nil {
+		violations = append(violations, fieldViolation("email", err))
+	}
+
+	return violations
+}
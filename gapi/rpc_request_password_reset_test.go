@@ -0,0 +1,160 @@
+package gapi
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/yelaco/simple-bank/db/mock"
+	db "github.com/yelaco/simple-bank/db/sqlc"
+	"github.com/yelaco/simple-bank/gen/pb/v1"
+	"github.com/yelaco/simple-bank/util"
+	"github.com/yelaco/simple-bank/worker"
+	mockwk "github.com/yelaco/simple-bank/worker/mock"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type eqRequestPasswordResetTxParamsMatcher struct {
+	username      string
+	passwordReset db.PasswordReset
+}
+
+func eqRequestPasswordResetTxParams(username string, passwordReset db.PasswordReset) gomock.Matcher {
+	return eqRequestPasswordResetTxParamsMatcher{username, passwordReset}
+}
+
+func (expected eqRequestPasswordResetTxParamsMatcher) String() string {
+	return "matches request password reset tx params"
+}
+
+func (expected eqRequestPasswordResetTxParamsMatcher) Matches(x any) bool {
+	actualArg, ok := x.(db.RequestPasswordResetTxParams)
+	if !ok {
+		return false
+	}
+
+	if actualArg.Username != expected.username || actualArg.SecretCode == "" || actualArg.MinInterval != passwordResetInterval {
+		return false
+	}
+
+	tasks, err := actualArg.AfterCreate(expected.passwordReset)
+	if err != nil || len(tasks) != 1 || tasks[0].TaskType != worker.TaskSendPasswordResetEmail {
+		return false
+	}
+
+	var payload worker.PayloadSendPasswordResetEmail
+	err = json.Unmarshal(tasks[0].Payload, &payload)
+	return err == nil && payload.PasswordResetID == expected.passwordReset.ID
+}
+
+func TestRequestPasswordResetAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	passwordReset := db.PasswordReset{
+		ID:       util.RandomInt(1, 1000),
+		Username: user.Username,
+		Email:    user.Email,
+	}
+
+	testCases := []struct {
+		name          string
+		req           *pb.RequestPasswordResetRequest
+		buildStubs    func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor)
+		checkResponse func(t *testing.T, res *pb.RequestPasswordResetResponse, err error)
+	}{
+		{
+			name: "OK",
+			req:  &pb.RequestPasswordResetRequest{Email: user.Email},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					RequestPasswordResetTx(gomock.Any(), eqRequestPasswordResetTxParams(user.Username, passwordReset)).
+					Times(1).
+					Return(passwordReset, nil)
+				// The task is written to the outbox by the transaction
+				taskDistributor.EXPECT().
+					DistributeTaskSendPasswordResetEmail(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.RequestPasswordResetResponse, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, res)
+			},
+		},
+		{
+			name: "UnknownEmail",
+			req:  &pb.RequestPasswordResetRequest{Email: user.Email},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(db.User{}, db.ErrRecordNotFound)
+				store.EXPECT().
+					RequestPasswordResetTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.RequestPasswordResetResponse, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, res)
+			},
+		},
+		{
+			name: "RecentlyRequested",
+			req:  &pb.RequestPasswordResetRequest{Email: user.Email},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					RequestPasswordResetTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.PasswordReset{}, db.ErrPasswordResetTooSoon)
+			},
+			checkResponse: func(t *testing.T, res *pb.RequestPasswordResetResponse, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, res)
+			},
+		},
+		{
+			name: "InvalidEmail",
+			req:  &pb.RequestPasswordResetRequest{Email: "invalid-email"},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.RequestPasswordResetResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+			store := mockdb.NewMockStore(storeCtrl)
+
+			taskCtrl := gomock.NewController(t)
+			defer taskCtrl.Finish()
+			taskDistributor := mockwk.NewMockTaskDistributor(taskCtrl)
+
+			tc.buildStubs(store, taskDistributor)
+
+			server := newTestServer(t, store, taskDistributor)
+
+			res, err := server.RequestPasswordReset(context.Background(), tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
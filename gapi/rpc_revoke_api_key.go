@@ -0,0 +1,52 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	db "github.com/yelaco/simple-bank/db/sqlc"
+	"github.com/yelaco/simple-bank/gen/pb/v1"
+	"github.com/yelaco/simple-bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) RevokeAPIKey(ctx context.Context, req *pb.RevokeAPIKeyRequest) (*pb.RevokeAPIKeyResponse, error) {
+	authPayload, err := authorizationFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateRevokeAPIKeyRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	// Keys of other users are reported as not found, so that their
+	// existence is not leaked
+	apiKey, err := server.store.RevokeAPIKey(ctx, db.RevokeAPIKeyParams{
+		ID:       uuid.MustParse(req.GetApiKeyId()),
+		Username: authPayload.Username,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "api key not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to revoke api key: %s", err)
+	}
+
+	rsp := &pb.RevokeAPIKeyResponse{
+		ApiKey: convertAPIKey(apiKey),
+	}
+	return rsp, nil
+}
+
+func validateRevokeAPIKeyRequest(req *pb.RevokeAPIKeyRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateAPIKeyID(req.GetApiKeyId()); err != nil {
+		violations = append(violations, fieldViolation("api_key_id", err))
+	}
+
+	return violations
+}
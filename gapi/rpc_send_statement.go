@@ -0,0 +1,100 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/hibiken/asynq"
+	db "github.com/yelaco/simple-bank/db/sqlc"
+	"github.com/yelaco/simple-bank/gen/pb/v1"
+	"github.com/yelaco/simple-bank/permission"
+	"github.com/yelaco/simple-bank/val"
+	"github.com/yelaco/simple-bank/worker"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxStatementPeriod limits how much history a single statement may cover
+const maxStatementPeriod = 366 * 24 * time.Hour
+
+func (server *Server) SendStatement(ctx context.Context, req *pb.SendStatementRequest) (*pb.SendStatementResponse, error) {
+	authPayload, err := authorizationFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateSendStatementRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := server.store.GetAccount(ctx, req.GetAccountId())
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "account not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get account: %s", err)
+	}
+
+	if !authPayload.can(permission.StatementsSendAny) && account.Owner != authPayload.Username {
+		return nil, status.Errorf(codes.PermissionDenied, "cannot get statement of other user's account")
+	}
+
+	// the statement always goes to the account owner, even when a banker asks for it
+	user, err := server.store.GetUser(ctx, account.Owner)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user: %s", err)
+	}
+
+	taskPayload := &worker.PayloadSendStatement{
+		AccountID: account.ID,
+		FromTime:  req.GetStartTime().AsTime(),
+		ToTime:    req.GetEndTime().AsTime(),
+	}
+	opts := []asynq.Option{
+		asynq.MaxRetry(10),
+		asynq.Queue(worker.QueueDefault),
+	}
+	task, err := worker.NewOutboxTask(ctx, worker.TaskSendStatement, taskPayload, opts...)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to prepare task to send statement: %s", err)
+	}
+
+	err = server.store.EnqueueOutboxTasksTx(ctx, task)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to enqueue task to send statement: %s", err)
+	}
+
+	return &pb.SendStatementResponse{Email: user.Email}, nil
+}
+
+func validateSendStatementRequest(req *pb.SendStatementRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+
+	if err := req.GetStartTime().CheckValid(); err != nil {
+		violations = append(violations, fieldViolation("start_time", err))
+	}
+
+	if err := req.GetEndTime().CheckValid(); err != nil {
+		violations = append(violations, fieldViolation("end_time", err))
+	}
+
+	if violations != nil {
+		return violations
+	}
+
+	startTime := req.GetStartTime().AsTime()
+	endTime := req.GetEndTime().AsTime()
+
+	if !endTime.After(startTime) {
+		violations = append(violations, fieldViolation("end_time", errors.New("must be after start_time")))
+	} else if endTime.Sub(startTime) > maxStatementPeriod {
+		violations = append(violations, fieldViolation("end_time", errors.New("must be at most one year after start_time")))
+	}
+
+	return violations
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 	db "github.com/yelaco/simple-bank/db/sqlc"
 	"github.com/yelaco/simple-bank/gen/pb/v1"
+	"github.com/yelaco/simple-bank/permission"
 	"github.com/yelaco/simple-bank/util"
 	"github.com/yelaco/simple-bank/val"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
@@ -16,9 +17,9 @@ import (
 )
 
 func (server *Server) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb.UpdateUserResponse, error) {
-	authPayload, err := server.authorizeUser(ctx, []string{util.BankerRole, util.DepositorRole})
+	authPayload, err := authorizationFromContext(ctx)
 	if err != nil {
-		return nil, unauthenticatedError(err)
+		return nil, err
 	}
 
 	violations := validateUpdateUserRequest(req)
@@ -26,7 +27,7 @@ func (server *Server) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest)
 		return nil, invalidArgumentError(violations)
 	}
 
-	if authPayload.Role != util.BankerRole && authPayload.Username != req.GetUsername() {
+	if !authPayload.can(permission.UsersUpdateAny) && authPayload.Username != req.GetUsername() {
 		return nil, status.Errorf(codes.PermissionDenied, "cannot update other user's info")
 	}
 
@@ -42,6 +43,24 @@ func (server *Server) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest)
 		},
 	}
 
+	if req.Password != nil && authPayload.Username == req.GetUsername() {
+		user, err := server.store.GetUser(ctx, req.GetUsername())
+		if err != nil {
+			if errors.Is(err, db.ErrRecordNotFound) {
+				return nil, status.Errorf(codes.NotFound, "user not found")
+			}
+			return nil, status.Errorf(codes.Internal, "failed to get user: %s", err)
+		}
+
+		// Changing the password is sensitive, so it also needs the second factor
+		if user.IsTotpEnabled {
+			err = server.verifySecondFactor(ctx, user, req.GetTotpCode())
+			if err != nil {
+				return nil, secondFactorError(err)
+			}
+		}
+	}
+
 	if req.Password != nil {
 		hashedPassword, err := util.HashPassword(req.GetPassword())
 		if err != nil {
@@ -59,13 +78,37 @@ func (server *Server) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest)
 		}
 	}
 
-	user, err := server.store.UpdateUser(ctx, arg)
+	result, err := server.store.UpdateUserTx(ctx, arg)
 	if err != nil {
 		if errors.Is(err, db.ErrRecordNotFound) {
 			return nil, status.Errorf(codes.NotFound, "user not found")
 		}
 		return nil, status.Errorf(codes.Internal, "failed to update user: %s", err)
 	}
+	user := result.User
+
+	if req.Password != nil {
+		server.userState.Invalidate(user.Username)
+	}
+
+	// Only the names of the updated fields are recorded, never their values
+	var fields []string
+	if req.FullName != nil {
+		fields = append(fields, "full_name")
+	}
+	if req.Email != nil {
+		fields = append(fields, "email")
+	}
+	if req.Password != nil {
+		fields = append(fields, "password")
+	}
+
+	err = server.recordAuditEvent(ctx, server.auditContext(ctx, authPayload.Username), db.AuditActionUserUpdate, db.AuditTarget("user", user.Username), map[string]any{
+		"fields": fields,
+	})
+	if err != nil {
+		return nil, err
+	}
 
 	return &pb.UpdateUserResponse{User: convertUser(user)}, nil
 }
@@ -93,5 +136,11 @@ func validateUpdateUserRequest(req *pb.UpdateUserRequest) (violations []*errdeta
 		}
 	}
 
+	if req.TotpCode != nil {
+		if err := val.ValidateMFACode(req.GetTotpCode()); err != nil {
+			violations = append(violations, fieldViolation("totpCode", err))
+		}
+	}
+
 	return
 }
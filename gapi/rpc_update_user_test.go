@@ -60,9 +60,18 @@ func TestUpdateUserAPI(t *testing.T) {
 					IsEmailVerified:   user.IsEmailVerified,
 				}
 				store.EXPECT().
-					UpdateUser(gomock.Any(), gomock.Eq(arg)).
+					UpdateUserTx(gomock.Any(), gomock.Eq(arg)).
 					Times(1).
-					Return(updatedUser, nil)
+					Return(db.UpdateUserTxResult{User: updatedUser}, nil)
+				store.EXPECT().
+					CreateAuditEventTx(gomock.Any(), gomock.Eq(db.CreateAuditEventTxParams{
+						AuditContext: db.AuditContext{Actor: user.Username},
+						Action:       db.AuditActionUserUpdate,
+						Target:       db.AuditTarget("user", user.Username),
+						Details:      map[string]any{"fields": []string{"full_name", "email"}},
+					})).
+					Times(1).
+					Return(db.AuditEvent{}, nil)
 			},
 			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
 				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
@@ -85,9 +94,9 @@ func TestUpdateUserAPI(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					UpdateUser(gomock.Any(), gomock.Any()).
+					UpdateUserTx(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(db.User{}, db.ErrRecordNotFound)
+					Return(db.UpdateUserTxResult{}, db.ErrRecordNotFound)
 			},
 			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
 				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
@@ -108,7 +117,7 @@ func TestUpdateUserAPI(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					UpdateUser(gomock.Any(), gomock.Any()).
+					UpdateUserTx(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
@@ -130,7 +139,7 @@ func TestUpdateUserAPI(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					UpdateUser(gomock.Any(), gomock.Any()).
+					UpdateUserTx(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
@@ -152,7 +161,7 @@ func TestUpdateUserAPI(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					UpdateUser(gomock.Any(), gomock.Any()).
+					UpdateUserTx(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
@@ -178,7 +187,7 @@ func TestUpdateUserAPI(t *testing.T) {
 			server := newTestServer(t, store, nil)
 
 			ctx := tc.buildContext(t, server.tokenMaker)
-			res, err := server.UpdateUser(ctx, tc.req)
+			res, err := invoke(ctx, server, pb.SimpleBank_UpdateUser_FullMethodName, tc.req, server.UpdateUser)
 			tc.checkResponse(t, res, err)
 		})
 	}
@@ -0,0 +1,85 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/yelaco/simple-bank/db/sqlc"
+	"github.com/yelaco/simple-bank/gen/pb/v1"
+	"github.com/yelaco/simple-bank/util"
+	"github.com/yelaco/simple-bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (server *Server) VerifyLoginMFA(ctx context.Context, req *pb.VerifyLoginMFARequest) (*pb.VerifyLoginMFAResponse, error) {
+	violations := validateVerifyLoginMFARequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	mfaPayload, err := server.tokenMaker.VerifyToken(req.GetMfaToken())
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid mfa token: %s", err)
+	}
+
+	if mfaPayload.Role != util.MFAChallengeRole {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid mfa token")
+	}
+
+	mtdt := server.extractMetadata(ctx)
+	if err := server.checkClientIP(ctx, mtdt.ClientIP); err != nil {
+		return nil, err
+	}
+
+	user, err := server.store.GetUser(ctx, mfaPayload.Username)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to find user: %s", err)
+	}
+
+	if err := checkUserLocked(user); err != nil {
+		return nil, err
+	}
+
+	err = server.verifySecondFactor(ctx, user, req.GetCode())
+	if err != nil {
+		if errors.Is(err, errInvalidSecondFactor) {
+			if err := server.recordFailedLogin(ctx, user, mtdt.ClientIP); err != nil {
+				return nil, err
+			}
+		}
+		return nil, secondFactorError(err)
+	}
+
+	login, err := server.createLoginSession(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	rsp := &pb.VerifyLoginMFAResponse{
+		User:                  convertUser(user),
+		SessionId:             login.session.ID.String(),
+		AccessToken:           login.accessToken,
+		RefreshToken:          login.refreshToken,
+		AccessTokenExpiresAt:  timestamppb.New(login.accessPayload.ExpiredAt),
+		RefreshTokenExpiresAt: timestamppb.New(login.refreshPayload.ExpiredAt),
+	}
+	return rsp, nil
+}
+
+func validateVerifyLoginMFARequest(req *pb.VerifyLoginMFARequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if req.GetMfaToken() == "" {
+		violations = append(violations, fieldViolation("mfa_token", errors.New("must not be empty")))
+	}
+
+	if err := val.ValidateMFACode(req.GetCode()); err != nil {
+		violations = append(violations, fieldViolation("code", err))
+	}
+
+	return violations
+}
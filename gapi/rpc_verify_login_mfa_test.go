@@ -0,0 +1,249 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pquerna/otp/totp"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/yelaco/simple-bank/db/mock"
+	db "github.com/yelaco/simple-bank/db/sqlc"
+	"github.com/yelaco/simple-bank/gen/pb/v1"
+	"github.com/yelaco/simple-bank/token"
+	"github.com/yelaco/simple-bank/util"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestVerifyLoginMFAAPI(t *testing.T) {
+	user := randomTOTPUser(t)
+
+	code, err := totp.GenerateCode(user.TotpSecret.String, time.Now())
+	require.NoError(t, err)
+
+	recoveryCode := "abcde-23456"
+	hashedRecoveryCode, err := util.HashPassword(recoveryCode)
+	require.NoError(t, err)
+	recoveryCodeRow := db.RecoveryCode{
+		ID:         util.RandomInt(1, 1000),
+		Username:   user.Username,
+		HashedCode: hashedRecoveryCode,
+	}
+
+	testCases := []struct {
+		name          string
+		code          string
+		buildToken    func(t *testing.T, tokenMaker token.Maker) string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, res *pb.VerifyLoginMFAResponse, err error)
+	}{
+		{
+			name: "OK",
+			code: code,
+			buildToken: func(t *testing.T, tokenMaker token.Maker) string {
+				return newMFAToken(t, tokenMaker, user.Username, util.MFAChallengeRole)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(randomSession(user.Username), nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.VerifyLoginMFAResponse, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, res)
+				require.NotEmpty(t, res.GetAccessToken())
+				require.NotEmpty(t, res.GetRefreshToken())
+				require.Equal(t, user.Username, res.GetUser().GetUsername())
+			},
+		},
+		{
+			name: "RecoveryCode",
+			code: recoveryCode,
+			buildToken: func(t *testing.T, tokenMaker token.Maker) string {
+				return newMFAToken(t, tokenMaker, user.Username, util.MFAChallengeRole)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					ListUnusedRecoveryCodes(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return([]db.RecoveryCode{recoveryCodeRow}, nil)
+				store.EXPECT().
+					UseRecoveryCode(gomock.Any(), gomock.Eq(recoveryCodeRow.ID)).
+					Times(1).
+					Return(recoveryCodeRow, nil)
+				store.EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(randomSession(user.Username), nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.VerifyLoginMFAResponse, err error) {
+				require.NoError(t, err)
+				require.NotEmpty(t, res.GetAccessToken())
+			},
+		},
+		{
+			name: "UsedRecoveryCode",
+			code: recoveryCode,
+			buildToken: func(t *testing.T, tokenMaker token.Maker) string {
+				return newMFAToken(t, tokenMaker, user.Username, util.MFAChallengeRole)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					ListUnusedRecoveryCodes(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return([]db.RecoveryCode{}, nil)
+				store.EXPECT().
+					RecordFailedLoginTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RecordFailedLoginTxResult{User: user}, nil)
+				store.EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.VerifyLoginMFAResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+		{
+			name: "WrongCode",
+			code: "000000",
+			buildToken: func(t *testing.T, tokenMaker token.Maker) string {
+				return newMFAToken(t, tokenMaker, user.Username, util.MFAChallengeRole)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					RecordFailedLoginTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RecordFailedLoginTxResult{User: user}, nil)
+				store.EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.VerifyLoginMFAResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+		{
+			name: "Locked",
+			code: code,
+			buildToken: func(t *testing.T, tokenMaker token.Maker) string {
+				return newMFAToken(t, tokenMaker, user.Username, util.MFAChallengeRole)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				lockedUser := user
+				lockedUser.LockedUntil = pgtype.Timestamptz{Time: time.Now().Add(time.Minute), Valid: true}
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(lockedUser, nil)
+				store.EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.VerifyLoginMFAResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.FailedPrecondition, st.Code())
+			},
+		},
+		{
+			name: "AccessTokenAsMFAToken",
+			code: code,
+			buildToken: func(t *testing.T, tokenMaker token.Maker) string {
+				return newMFAToken(t, tokenMaker, user.Username, user.Role)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.VerifyLoginMFAResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+		{
+			name: "InvalidCode",
+			code: "1",
+			buildToken: func(t *testing.T, tokenMaker token.Maker) string {
+				return newMFAToken(t, tokenMaker, user.Username, util.MFAChallengeRole)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.VerifyLoginMFAResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+			store := mockdb.NewMockStore(storeCtrl)
+
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store, nil)
+
+			req := &pb.VerifyLoginMFARequest{
+				MfaToken: tc.buildToken(t, server.tokenMaker),
+				Code:     tc.code,
+			}
+			res, err := server.VerifyLoginMFA(context.Background(), req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func randomTOTPUser(t *testing.T) db.User {
+	user, _ := randomUser(t)
+
+	secret, _, err := util.GenerateTOTPSecret(user.Email)
+	require.NoError(t, err)
+
+	user.TotpSecret = pgtype.Text{String: secret, Valid: true}
+	user.IsTotpEnabled = true
+	return user
+}
+
+func newMFAToken(t *testing.T, tokenMaker token.Maker, username string, role string) string {
+	mfaToken, _, err := tokenMaker.CreateToken(username, role, time.Minute)
+	require.NoError(t, err)
+	return mfaToken
+}
@@ -0,0 +1,77 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/yelaco/simple-bank/db/sqlc"
+	"github.com/yelaco/simple-bank/gen/pb/v1"
+	"github.com/yelaco/simple-bank/permission"
+	"github.com/yelaco/simple-bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) VoidHold(ctx context.Context, req *pb.VoidHoldRequest) (*pb.VoidHoldResponse, error) {
+	authPayload, err := authorizationFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateVoidHoldRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	hold, err := server.getHold(ctx, req.GetHoldId())
+	if err != nil {
+		return nil, err
+	}
+
+	if !authPayload.can(permission.HoldsVoidAny) {
+		isParty, err := server.isHoldParty(ctx, hold, authPayload.Username)
+		if err != nil {
+			return nil, err
+		}
+
+		if !isParty {
+			return nil, status.Errorf(codes.PermissionDenied, "cannot void hold of other user's account")
+		}
+	}
+
+	txResult, err := server.store.VoidHoldTx(ctx, hold.ID)
+	if err != nil {
+		if errors.Is(err, db.ErrHoldNotPending) {
+			return nil, status.Errorf(codes.FailedPrecondition, "%s", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to void hold: %s", err)
+	}
+
+	return &pb.VoidHoldResponse{Hold: convertHold(txResult.Hold)}, nil
+}
+
+// isHoldParty reports whether the user owns either side of the hold, since both of them may cancel it.
+// The returned error is already a gRPC status error.
+func (server *Server) isHoldParty(ctx context.Context, hold db.Hold, username string) (bool, error) {
+	for _, accountID := range []int64{hold.AccountID, hold.ToAccountID} {
+		account, err := server.store.GetAccount(ctx, accountID)
+		if err != nil {
+			return false, status.Errorf(codes.Internal, "failed to get account: %s", err)
+		}
+
+		if account.Owner == username {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func validateVoidHoldRequest(req *pb.VoidHoldRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetHoldId()); err != nil {
+		violations = append(violations, fieldViolation("hold_id", err))
+	}
+
+	return violations
+}
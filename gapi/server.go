@@ -6,8 +6,11 @@ import (
 
 	"github.com/gin-gonic/gin"
 	db "github.com/yelaco/simple-bank/db/sqlc"
+	"github.com/yelaco/simple-bank/fx"
 	"github.com/yelaco/simple-bank/gen/pb/v1"
+	"github.com/yelaco/simple-bank/permission"
 	"github.com/yelaco/simple-bank/token"
+	"github.com/yelaco/simple-bank/userstate"
 	"github.com/yelaco/simple-bank/util"
 	"github.com/yelaco/simple-bank/worker"
 )
@@ -19,15 +22,17 @@ type Server struct {
 	store           db.Store
 	tokenMaker      token.Maker
 	taskDistributor worker.TaskDistributor
+	rateProvider    fx.RateProvider
+	userState       *userstate.Cache
+	roles           *permission.Cache
 
 	// Not used anymore
 	router *gin.Engine
 }
 
 // NewServer creates a new gRPC server.
-func NewServer(config util.Config, store db.Store, taskDistributor worker.TaskDistributor) (*Server, error) {
-	tokenMaker, err := token.NewPasetoMaker(config.TokenSymmetricKey)
-	// tokenMaker, err := token.NewJwtMaker(config.TokenSymmetricKey)
+func NewServer(config util.Config, store db.Store, taskDistributor worker.TaskDistributor, rateProvider fx.RateProvider) (*Server, error) {
+	tokenMaker, err := newTokenMaker(config)
 	if err != nil {
 		return nil, fmt.Errorf("api.NewServer: cannot create token maker: %w", err)
 	}
@@ -37,11 +42,34 @@ func NewServer(config util.Config, store db.Store, taskDistributor worker.TaskDi
 		store:           store,
 		tokenMaker:      tokenMaker,
 		taskDistributor: taskDistributor,
+		rateProvider:    rateProvider,
+		userState:       userstate.NewCache(store, config.UserStateCacheDuration),
+		roles:           permission.NewCache(store, config.RoleCacheDuration),
 	}
 
 	return server, nil
 }
 
+// newTokenMaker creates a v4.public maker when a signing key is configured,
+// and falls back to the symmetric maker otherwise.
+func newTokenMaker(config util.Config) (token.Maker, error) {
+	if config.TokenPrivateKey == "" {
+		return token.NewPasetoMaker(config.TokenSymmetricKey)
+		// return token.NewJwtMaker(config.TokenSymmetricKey)
+	}
+
+	verificationKeys := make([]token.PublicKey, 0, len(config.TokenPublicKeys))
+	for _, value := range config.TokenPublicKeys {
+		key, err := token.ParsePublicKey(value)
+		if err != nil {
+			return nil, err
+		}
+		verificationKeys = append(verificationKeys, key)
+	}
+
+	return token.NewPasetoPublicMaker(config.TokenKeyID, config.TokenPrivateKey, verificationKeys)
+}
+
 func (server *Server) Start(address string) error {
 	return server.router.Run(address)
 }
@@ -0,0 +1,28 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/yelaco/simple-bank/db/sqlc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// getOwnWebhookEndpoint reports the endpoints of other users as not found,
+// so that their existence is not leaked
+func (server *Server) getOwnWebhookEndpoint(ctx context.Context, id int64, owner string) (db.WebhookEndpoint, error) {
+	endpoint, err := server.store.GetWebhookEndpoint(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return endpoint, status.Errorf(codes.NotFound, "webhook endpoint not found")
+		}
+		return endpoint, status.Errorf(codes.Internal, "failed to get webhook endpoint: %s", err)
+	}
+
+	if endpoint.Owner != owner {
+		return endpoint, status.Errorf(codes.NotFound, "webhook endpoint not found")
+	}
+
+	return endpoint, nil
+}
@@ -0,0 +1,190 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.31.1
+// source: pb/v1/rpc_assign_role.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type AssignRoleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Role          string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignRoleRequest) Reset() {
+	*x = AssignRoleRequest{}
+	mi := &file_pb_v1_rpc_assign_role_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignRoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignRoleRequest) ProtoMessage() {}
+
+func (x *AssignRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_v1_rpc_assign_role_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignRoleRequest.ProtoReflect.Descriptor instead.
+func (*AssignRoleRequest) Descriptor() ([]byte, []int) {
+	return file_pb_v1_rpc_assign_role_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AssignRoleRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *AssignRoleRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type AssignRoleResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	User            *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	RevokedSessions int64                  `protobuf:"varint,2,opt,name=revoked_sessions,json=revokedSessions,proto3" json:"revoked_sessions,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *AssignRoleResponse) Reset() {
+	*x = AssignRoleResponse{}
+	mi := &file_pb_v1_rpc_assign_role_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignRoleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignRoleResponse) ProtoMessage() {}
+
+func (x *AssignRoleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_v1_rpc_assign_role_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignRoleResponse.ProtoReflect.Descriptor instead.
+func (*AssignRoleResponse) Descriptor() ([]byte, []int) {
+	return file_pb_v1_rpc_assign_role_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AssignRoleResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *AssignRoleResponse) GetRevokedSessions() int64 {
+	if x != nil {
+		return x.RevokedSessions
+	}
+	return 0
+}
+
+var File_pb_v1_rpc_assign_role_proto protoreflect.FileDescriptor
+
+const file_pb_v1_rpc_assign_role_proto_rawDesc = "" +
+	"\n" +
+	"\x1bpb/v1/rpc_assign_role.proto\x12\x05pb.v1\x1a\x10pb/v1/user.proto\"C\n" +
+	"\x11AssignRoleRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\"`\n" +
+	"\x12AssignRoleResponse\x12\x1f\n" +
+	"\x04user\x18\x01 \x01(\v2\v.pb.v1.UserR\x04user\x12)\n" +
+	"\x10revoked_sessions\x18\x02 \x01(\x03R\x0frevokedSessionsB\"Z github.com/yelaco/simple-bank/pbb\x06proto3"
+
+var (
+	file_pb_v1_rpc_assign_role_proto_rawDescOnce sync.Once
+	file_pb_v1_rpc_assign_role_proto_rawDescData []byte
+)
+
+func file_pb_v1_rpc_assign_role_proto_rawDescGZIP() []byte {
+	file_pb_v1_rpc_assign_role_proto_rawDescOnce.Do(func() {
+		file_pb_v1_rpc_assign_role_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pb_v1_rpc_assign_role_proto_rawDesc), len(file_pb_v1_rpc_assign_role_proto_rawDesc)))
+	})
+	return file_pb_v1_rpc_assign_role_proto_rawDescData
+}
+
+var file_pb_v1_rpc_assign_role_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_pb_v1_rpc_assign_role_proto_goTypes = []any{
+	(*AssignRoleRequest)(nil),  // 0: pb.v1.AssignRoleRequest
+	(*AssignRoleResponse)(nil), // 1: pb.v1.AssignRoleResponse
+	(*User)(nil),               // 2: pb.v1.User
+}
+var file_pb_v1_rpc_assign_role_proto_depIdxs = []int32{
+	2, // 0: pb.v1.AssignRoleResponse.user:type_name -> pb.v1.User
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_pb_v1_rpc_assign_role_proto_init() }
+func file_pb_v1_rpc_assign_role_proto_init() {
+	if File_pb_v1_rpc_assign_role_proto != nil {
+		return
+	}
+	file_pb_v1_user_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pb_v1_rpc_assign_role_proto_rawDesc), len(file_pb_v1_rpc_assign_role_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_pb_v1_rpc_assign_role_proto_goTypes,
+		DependencyIndexes: file_pb_v1_rpc_assign_role_proto_depIdxs,
+		MessageInfos:      file_pb_v1_rpc_assign_role_proto_msgTypes,
+	}.Build()
+	File_pb_v1_rpc_assign_role_proto = out.File
+	file_pb_v1_rpc_assign_role_proto_goTypes = nil
+	file_pb_v1_rpc_assign_role_proto_depIdxs = nil
+}
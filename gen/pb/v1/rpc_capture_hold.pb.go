@@ -0,0 +1,207 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.31.1
+// source: pb/v1/rpc_capture_hold.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CaptureHoldRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	HoldId        int64                  `protobuf:"varint,1,opt,name=hold_id,json=holdId,proto3" json:"hold_id,omitempty"`
+	Amount        *int64                 `protobuf:"varint,2,opt,name=amount,proto3,oneof" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CaptureHoldRequest) Reset() {
+	*x = CaptureHoldRequest{}
+	mi := &file_pb_v1_rpc_capture_hold_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CaptureHoldRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CaptureHoldRequest) ProtoMessage() {}
+
+func (x *CaptureHoldRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_v1_rpc_capture_hold_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CaptureHoldRequest.ProtoReflect.Descriptor instead.
+func (*CaptureHoldRequest) Descriptor() ([]byte, []int) {
+	return file_pb_v1_rpc_capture_hold_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CaptureHoldRequest) GetHoldId() int64 {
+	if x != nil {
+		return x.HoldId
+	}
+	return 0
+}
+
+func (x *CaptureHoldRequest) GetAmount() int64 {
+	if x != nil && x.Amount != nil {
+		return *x.Amount
+	}
+	return 0
+}
+
+type CaptureHoldResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hold          *Hold                  `protobuf:"bytes,1,opt,name=hold,proto3" json:"hold,omitempty"`
+	Transfer      *Transfer              `protobuf:"bytes,2,opt,name=transfer,proto3" json:"transfer,omitempty"`
+	ToEntry       *Entry                 `protobuf:"bytes,3,opt,name=to_entry,json=toEntry,proto3" json:"to_entry,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CaptureHoldResponse) Reset() {
+	*x = CaptureHoldResponse{}
+	mi := &file_pb_v1_rpc_capture_hold_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CaptureHoldResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CaptureHoldResponse) ProtoMessage() {}
+
+func (x *CaptureHoldResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_v1_rpc_capture_hold_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CaptureHoldResponse.ProtoReflect.Descriptor instead.
+func (*CaptureHoldResponse) Descriptor() ([]byte, []int) {
+	return file_pb_v1_rpc_capture_hold_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CaptureHoldResponse) GetHold() *Hold {
+	if x != nil {
+		return x.Hold
+	}
+	return nil
+}
+
+func (x *CaptureHoldResponse) GetTransfer() *Transfer {
+	if x != nil {
+		return x.Transfer
+	}
+	return nil
+}
+
+func (x *CaptureHoldResponse) GetToEntry() *Entry {
+	if x != nil {
+		return x.ToEntry
+	}
+	return nil
+}
+
+var File_pb_v1_rpc_capture_hold_proto protoreflect.FileDescriptor
+
+const file_pb_v1_rpc_capture_hold_proto_rawDesc = "" +
+	"\n" +
+	"\x1cpb/v1/rpc_capture_hold.proto\x12\x05pb.v1\x1a\x11pb/v1/entry.proto\x1a\x10pb/v1/hold.proto\x1a\x14pb/v1/transfer.proto\"U\n" +
+	"\x12CaptureHoldRequest\x12\x17\n" +
+	"\ahold_id\x18\x01 \x01(\x03R\x06holdId\x12\x1b\n" +
+	"\x06amount\x18\x02 \x01(\x03H\x00R\x06amount\x88\x01\x01B\t\n" +
+	"\a_amount\"\x8c\x01\n" +
+	"\x13CaptureHoldResponse\x12\x1f\n" +
+	"\x04hold\x18\x01 \x01(\v2\v.pb.v1.HoldR\x04hold\x12+\n" +
+	"\btransfer\x18\x02 \x01(\v2\x0f.pb.v1.TransferR\btransfer\x12'\n" +
+	"\bto_entry\x18\x03 \x01(\v2\f.pb.v1.EntryR\atoEntryB\"Z github.com/yelaco/simple-bank/pbb\x06proto3"
+
+var (
+	file_pb_v1_rpc_capture_hold_proto_rawDescOnce sync.Once
+	file_pb_v1_rpc_capture_hold_proto_rawDescData []byte
+)
+
+func file_pb_v1_rpc_capture_hold_proto_rawDescGZIP() []byte {
+	file_pb_v1_rpc_capture_hold_proto_rawDescOnce.Do(func() {
+		file_pb_v1_rpc_capture_hold_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pb_v1_rpc_capture_hold_proto_rawDesc), len(file_pb_v1_rpc_capture_hold_proto_rawDesc)))
+	})
+	return file_pb_v1_rpc_capture_hold_proto_rawDescData
+}
+
+var file_pb_v1_rpc_capture_hold_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_pb_v1_rpc_capture_hold_proto_goTypes = []any{
+	(*CaptureHoldRequest)(nil),  // 0: pb.v1.CaptureHoldRequest
+	(*CaptureHoldResponse)(nil), // 1: pb.v1.CaptureHoldResponse
+	(*Hold)(nil),                // 2: pb.v1.Hold
+	(*Transfer)(nil),            // 3: pb.v1.Transfer
+	(*Entry)(nil),               // 4: pb.v1.Entry
+}
+var file_pb_v1_rpc_capture_hold_proto_depIdxs = []int32{
+	2, // 0: pb.v1.CaptureHoldResponse.hold:type_name -> pb.v1.Hold
+	3, // 1: pb.v1.CaptureHoldResponse.transfer:type_name -> pb.v1.Transfer
+	4, // 2: pb.v1.CaptureHoldResponse.to_entry:type_name -> pb.v1.Entry
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_pb_v1_rpc_capture_hold_proto_init() }
+func file_pb_v1_rpc_capture_hold_proto_init() {
+	if File_pb_v1_rpc_capture_hold_proto != nil {
+		return
+	}
+	file_pb_v1_entry_proto_init()
+	file_pb_v1_hold_proto_init()
+	file_pb_v1_transfer_proto_init()
+	file_pb_v1_rpc_capture_hold_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pb_v1_rpc_capture_hold_proto_rawDesc), len(file_pb_v1_rpc_capture_hold_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_pb_v1_rpc_capture_hold_proto_goTypes,
+		DependencyIndexes: file_pb_v1_rpc_capture_hold_proto_depIdxs,
+		MessageInfos:      file_pb_v1_rpc_capture_hold_proto_msgTypes,
+	}.Build()
+	File_pb_v1_rpc_capture_hold_proto = out.File
+	file_pb_v1_rpc_capture_hold_proto_goTypes = nil
+	file_pb_v1_rpc_capture_hold_proto_depIdxs = nil
+}
@@ -0,0 +1,172 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.31.1
+// source: pb/v1/rpc_close_account.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CloseAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CloseAccountRequest) Reset() {
+	*x = CloseAccountRequest{}
+	mi := &file_pb_v1_rpc_close_account_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloseAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloseAccountRequest) ProtoMessage() {}
+
+func (x *CloseAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_v1_rpc_close_account_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloseAccountRequest.ProtoReflect.Descriptor instead.
+func (*CloseAccountRequest) Descriptor() ([]byte, []int) {
+	return file_pb_v1_rpc_close_account_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CloseAccountRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type CloseAccountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Account       *Account               `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CloseAccountResponse) Reset() {
+	*x = CloseAccountResponse{}
+	mi := &file_pb_v1_rpc_close_account_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloseAccountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloseAccountResponse) ProtoMessage() {}
+
+func (x *CloseAccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_v1_rpc_close_account_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloseAccountResponse.ProtoReflect.Descriptor instead.
+func (*CloseAccountResponse) Descriptor() ([]byte, []int) {
+	return file_pb_v1_rpc_close_account_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CloseAccountResponse) GetAccount() *Account {
+	if x != nil {
+		return x.Account
+	}
+	return nil
+}
+
+var File_pb_v1_rpc_close_account_proto protoreflect.FileDescriptor
+
+const file_pb_v1_rpc_close_account_proto_rawDesc = "" +
+	"\n" +
+	"\x1dpb/v1/rpc_close_account.proto\x12\x05pb.v1\x1a\x13pb/v1/account.proto\"%\n" +
+	"\x13CloseAccountRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"@\n" +
+	"\x14CloseAccountResponse\x12(\n" +
+	"\aaccount\x18\x01 \x01(\v2\x0e.pb.v1.AccountR\aaccountB\"Z github.com/yelaco/simple-bank/pbb\x06proto3"
+
+var (
+	file_pb_v1_rpc_close_account_proto_rawDescOnce sync.Once
+	file_pb_v1_rpc_close_account_proto_rawDescData []byte
+)
+
+func file_pb_v1_rpc_close_account_proto_rawDescGZIP() []byte {
+	file_pb_v1_rpc_close_account_proto_rawDescOnce.Do(func() {
+		file_pb_v1_rpc_close_account_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pb_v1_rpc_close_account_proto_rawDesc), len(file_pb_v1_rpc_close_account_proto_rawDesc)))
+	})
+	return file_pb_v1_rpc_close_account_proto_rawDescData
+}
+
+var file_pb_v1_rpc_close_account_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_pb_v1_rpc_close_account_proto_goTypes = []any{
+	(*CloseAccountRequest)(nil),  // 0: pb.v1.CloseAccountRequest
+	(*CloseAccountResponse)(nil), // 1: pb.v1.CloseAccountResponse
+	(*Account)(nil),              // 2: pb.v1.Account
+}
+var file_pb_v1_rpc_close_account_proto_depIdxs = []int32{
+	2, // 0: pb.v1.CloseAccountResponse.account:type_name -> pb.v1.Account
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_pb_v1_rpc_close_account_proto_init() }
+func file_pb_v1_rpc_close_account_proto_init() {
+	if File_pb_v1_rpc_close_account_proto != nil {
+		return
+	}
+	file_pb_v1_account_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pb_v1_rpc_close_account_proto_rawDesc), len(file_pb_v1_rpc_close_account_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_pb_v1_rpc_close_account_proto_goTypes,
+		DependencyIndexes: file_pb_v1_rpc_close_account_proto_depIdxs,
+		MessageInfos:      file_pb_v1_rpc_close_account_proto_msgTypes,
+	}.Build()
+	File_pb_v1_rpc_close_account_proto = out.File
+	file_pb_v1_rpc_close_account_proto_goTypes = nil
+	file_pb_v1_rpc_close_account_proto_depIdxs = nil
+}
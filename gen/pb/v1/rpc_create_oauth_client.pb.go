@@ -0,0 +1,200 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.31.1
+// source: pb/v1/rpc_create_oauth_client.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CreateOAuthClientRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	RedirectUris  []string               `protobuf:"bytes,2,rep,name=redirect_uris,json=redirectUris,proto3" json:"redirect_uris,omitempty"`
+	Scopes        []string               `protobuf:"bytes,3,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOAuthClientRequest) Reset() {
+	*x = CreateOAuthClientRequest{}
+	mi := &file_pb_v1_rpc_create_oauth_client_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOAuthClientRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOAuthClientRequest) ProtoMessage() {}
+
+func (x *CreateOAuthClientRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_v1_rpc_create_oauth_client_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOAuthClientRequest.ProtoReflect.Descriptor instead.
+func (*CreateOAuthClientRequest) Descriptor() ([]byte, []int) {
+	return file_pb_v1_rpc_create_oauth_client_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CreateOAuthClientRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateOAuthClientRequest) GetRedirectUris() []string {
+	if x != nil {
+		return x.RedirectUris
+	}
+	return nil
+}
+
+func (x *CreateOAuthClientRequest) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+type CreateOAuthClientResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Client *OAuthClient           `protobuf:"bytes,1,opt,name=client,proto3" json:"client,omitempty"`
+	// client_secret is only returned once, it cannot be retrieved later
+	ClientSecret  string `protobuf:"bytes,2,opt,name=client_secret,json=clientSecret,proto3" json:"client_secret,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOAuthClientResponse) Reset() {
+	*x = CreateOAuthClientResponse{}
+	mi := &file_pb_v1_rpc_create_oauth_client_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOAuthClientResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOAuthClientResponse) ProtoMessage() {}
+
+func (x *CreateOAuthClientResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_v1_rpc_create_oauth_client_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOAuthClientResponse.ProtoReflect.Descriptor instead.
+func (*CreateOAuthClientResponse) Descriptor() ([]byte, []int) {
+	return file_pb_v1_rpc_create_oauth_client_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateOAuthClientResponse) GetClient() *OAuthClient {
+	if x != nil {
+		return x.Client
+	}
+	return nil
+}
+
+func (x *CreateOAuthClientResponse) GetClientSecret() string {
+	if x != nil {
+		return x.ClientSecret
+	}
+	return ""
+}
+
+var File_pb_v1_rpc_create_oauth_client_proto protoreflect.FileDescriptor
+
+const file_pb_v1_rpc_create_oauth_client_proto_rawDesc = "" +
+	"\n" +
+	"#pb/v1/rpc_create_oauth_client.proto\x12\x05pb.v1\x1a\x18pb/v1/oauth_client.proto\"k\n" +
+	"\x18CreateOAuthClientRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12#\n" +
+	"\rredirect_uris\x18\x02 \x03(\tR\fredirectUris\x12\x16\n" +
+	"\x06scopes\x18\x03 \x03(\tR\x06scopes\"l\n" +
+	"\x19CreateOAuthClientResponse\x12*\n" +
+	"\x06client\x18\x01 \x01(\v2\x12.pb.v1.OAuthClientR\x06client\x12#\n" +
+	"\rclient_secret\x18\x02 \x01(\tR\fclientSecretB\"Z github.com/yelaco/simple-bank/pbb\x06proto3"
+
+var (
+	file_pb_v1_rpc_create_oauth_client_proto_rawDescOnce sync.Once
+	file_pb_v1_rpc_create_oauth_client_proto_rawDescData []byte
+)
+
+func file_pb_v1_rpc_create_oauth_client_proto_rawDescGZIP() []byte {
+	file_pb_v1_rpc_create_oauth_client_proto_rawDescOnce.Do(func() {
+		file_pb_v1_rpc_create_oauth_client_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pb_v1_rpc_create_oauth_client_proto_rawDesc), len(file_pb_v1_rpc_create_oauth_client_proto_rawDesc)))
+	})
+	return file_pb_v1_rpc_create_oauth_client_proto_rawDescData
+}
+
+var file_pb_v1_rpc_create_oauth_client_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_pb_v1_rpc_create_oauth_client_proto_goTypes = []any{
+	(*CreateOAuthClientRequest)(nil),  // 0: pb.v1.CreateOAuthClientRequest
+	(*CreateOAuthClientResponse)(nil), // 1: pb.v1.CreateOAuthClientResponse
+	(*OAuthClient)(nil),               // 2: pb.v1.OAuthClient
+}
+var file_pb_v1_rpc_create_oauth_client_proto_depIdxs = []int32{
+	2, // 0: pb.v1.CreateOAuthClientResponse.client:type_name -> pb.v1.OAuthClient
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_pb_v1_rpc_create_oauth_client_proto_init() }
+func file_pb_v1_rpc_create_oauth_client_proto_init() {
+	if File_pb_v1_rpc_create_oauth_client_proto != nil {
+		return
+	}
+	file_pb_v1_oauth_client_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pb_v1_rpc_create_oauth_client_proto_rawDesc), len(file_pb_v1_rpc_create_oauth_client_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_pb_v1_rpc_create_oauth_client_proto_goTypes,
+		DependencyIndexes: file_pb_v1_rpc_create_oauth_client_proto_depIdxs,
+		MessageInfos:      file_pb_v1_rpc_create_oauth_client_proto_msgTypes,
+	}.Build()
+	File_pb_v1_rpc_create_oauth_client_proto = out.File
+	file_pb_v1_rpc_create_oauth_client_proto_goTypes = nil
+	file_pb_v1_rpc_create_oauth_client_proto_depIdxs = nil
+}
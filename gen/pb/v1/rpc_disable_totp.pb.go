@@ -0,0 +1,172 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.31.1
+// source: pb/v1/rpc_disable_totp.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type DisableTOTPRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisableTOTPRequest) Reset() {
+	*x = DisableTOTPRequest{}
+	mi := &file_pb_v1_rpc_disable_totp_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisableTOTPRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableTOTPRequest) ProtoMessage() {}
+
+func (x *DisableTOTPRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_v1_rpc_disable_totp_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableTOTPRequest.ProtoReflect.Descriptor instead.
+func (*DisableTOTPRequest) Descriptor() ([]byte, []int) {
+	return file_pb_v1_rpc_disable_totp_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *DisableTOTPRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type DisableTOTPResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisableTOTPResponse) Reset() {
+	*x = DisableTOTPResponse{}
+	mi := &file_pb_v1_rpc_disable_totp_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisableTOTPResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableTOTPResponse) ProtoMessage() {}
+
+func (x *DisableTOTPResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_v1_rpc_disable_totp_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableTOTPResponse.ProtoReflect.Descriptor instead.
+func (*DisableTOTPResponse) Descriptor() ([]byte, []int) {
+	return file_pb_v1_rpc_disable_totp_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DisableTOTPResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+var File_pb_v1_rpc_disable_totp_proto protoreflect.FileDescriptor
+
+const file_pb_v1_rpc_disable_totp_proto_rawDesc = "" +
+	"\n" +
+	"\x1cpb/v1/rpc_disable_totp.proto\x12\x05pb.v1\x1a\x10pb/v1/user.proto\"(\n" +
+	"\x12DisableTOTPRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\"6\n" +
+	"\x13DisableTOTPResponse\x12\x1f\n" +
+	"\x04user\x18\x01 \x01(\v2\v.pb.v1.UserR\x04userB\"Z github.com/yelaco/simple-bank/pbb\x06proto3"
+
+var (
+	file_pb_v1_rpc_disable_totp_proto_rawDescOnce sync.Once
+	file_pb_v1_rpc_disable_totp_proto_rawDescData []byte
+)
+
+func file_pb_v1_rpc_disable_totp_proto_rawDescGZIP() []byte {
+	file_pb_v1_rpc_disable_totp_proto_rawDescOnce.Do(func() {
+		file_pb_v1_rpc_disable_totp_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pb_v1_rpc_disable_totp_proto_rawDesc), len(file_pb_v1_rpc_disable_totp_proto_rawDesc)))
+	})
+	return file_pb_v1_rpc_disable_totp_proto_rawDescData
+}
+
+var file_pb_v1_rpc_disable_totp_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_pb_v1_rpc_disable_totp_proto_goTypes = []any{
+	(*DisableTOTPRequest)(nil),  // 0: pb.v1.DisableTOTPRequest
+	(*DisableTOTPResponse)(nil), // 1: pb.v1.DisableTOTPResponse
+	(*User)(nil),                // 2: pb.v1.User
+}
+var file_pb_v1_rpc_disable_totp_proto_depIdxs = []int32{
+	2, // 0: pb.v1.DisableTOTPResponse.user:type_name -> pb.v1.User
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_pb_v1_rpc_disable_totp_proto_init() }
+func file_pb_v1_rpc_disable_totp_proto_init() {
+	if File_pb_v1_rpc_disable_totp_proto != nil {
+		return
+	}
+	file_pb_v1_user_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pb_v1_rpc_disable_totp_proto_rawDesc), len(file_pb_v1_rpc_disable_totp_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_pb_v1_rpc_disable_totp_proto_goTypes,
+		DependencyIndexes: file_pb_v1_rpc_disable_totp_proto_depIdxs,
+		MessageInfos:      file_pb_v1_rpc_disable_totp_proto_msgTypes,
+	}.Build()
+	File_pb_v1_rpc_disable_totp_proto = out.File
+	file_pb_v1_rpc_disable_totp_proto_goTypes = nil
+	file_pb_v1_rpc_disable_totp_proto_depIdxs = nil
+}
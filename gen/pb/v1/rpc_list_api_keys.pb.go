@@ -0,0 +1,163 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.31.1
+// source: pb/v1/rpc_list_api_keys.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ListAPIKeysRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAPIKeysRequest) Reset() {
+	*x = ListAPIKeysRequest{}
+	mi := &file_pb_v1_rpc_list_api_keys_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAPIKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAPIKeysRequest) ProtoMessage() {}
+
+func (x *ListAPIKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_v1_rpc_list_api_keys_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAPIKeysRequest.ProtoReflect.Descriptor instead.
+func (*ListAPIKeysRequest) Descriptor() ([]byte, []int) {
+	return file_pb_v1_rpc_list_api_keys_proto_rawDescGZIP(), []int{0}
+}
+
+type ListAPIKeysResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApiKeys       []*APIKey              `protobuf:"bytes,1,rep,name=api_keys,json=apiKeys,proto3" json:"api_keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAPIKeysResponse) Reset() {
+	*x = ListAPIKeysResponse{}
+	mi := &file_pb_v1_rpc_list_api_keys_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAPIKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAPIKeysResponse) ProtoMessage() {}
+
+func (x *ListAPIKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_v1_rpc_list_api_keys_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAPIKeysResponse.ProtoReflect.Descriptor instead.
+func (*ListAPIKeysResponse) Descriptor() ([]byte, []int) {
+	return file_pb_v1_rpc_list_api_keys_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListAPIKeysResponse) GetApiKeys() []*APIKey {
+	if x != nil {
+		return x.ApiKeys
+	}
+	return nil
+}
+
+var File_pb_v1_rpc_list_api_keys_proto protoreflect.FileDescriptor
+
+const file_pb_v1_rpc_list_api_keys_proto_rawDesc = "" +
+	"\n" +
+	"\x1dpb/v1/rpc_list_api_keys.proto\x12\x05pb.v1\x1a\x13pb/v1/api_key.proto\"\x14\n" +
+	"\x12ListAPIKeysRequest\"?\n" +
+	"\x13ListAPIKeysResponse\x12(\n" +
+	"\bapi_keys\x18\x01 \x03(\v2\r.pb.v1.APIKeyR\aapiKeysB\"Z github.com/yelaco/simple-bank/pbb\x06proto3"
+
+var (
+	file_pb_v1_rpc_list_api_keys_proto_rawDescOnce sync.Once
+	file_pb_v1_rpc_list_api_keys_proto_rawDescData []byte
+)
+
+func file_pb_v1_rpc_list_api_keys_proto_rawDescGZIP() []byte {
+	file_pb_v1_rpc_list_api_keys_proto_rawDescOnce.Do(func() {
+		file_pb_v1_rpc_list_api_keys_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pb_v1_rpc_list_api_keys_proto_rawDesc), len(file_pb_v1_rpc_list_api_keys_proto_rawDesc)))
+	})
+	return file_pb_v1_rpc_list_api_keys_proto_rawDescData
+}
+
+var file_pb_v1_rpc_list_api_keys_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_pb_v1_rpc_list_api_keys_proto_goTypes = []any{
+	(*ListAPIKeysRequest)(nil),  // 0: pb.v1.ListAPIKeysRequest
+	(*ListAPIKeysResponse)(nil), // 1: pb.v1.ListAPIKeysResponse
+	(*APIKey)(nil),              // 2: pb.v1.APIKey
+}
+var file_pb_v1_rpc_list_api_keys_proto_depIdxs = []int32{
+	2, // 0: pb.v1.ListAPIKeysResponse.api_keys:type_name -> pb.v1.APIKey
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_pb_v1_rpc_list_api_keys_proto_init() }
+func file_pb_v1_rpc_list_api_keys_proto_init() {
+	if File_pb_v1_rpc_list_api_keys_proto != nil {
+		return
+	}
+	file_pb_v1_api_key_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pb_v1_rpc_list_api_keys_proto_rawDesc), len(file_pb_v1_rpc_list_api_keys_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_pb_v1_rpc_list_api_keys_proto_goTypes,
+		DependencyIndexes: file_pb_v1_rpc_list_api_keys_proto_depIdxs,
+		MessageInfos:      file_pb_v1_rpc_list_api_keys_proto_msgTypes,
+	}.Build()
+	File_pb_v1_rpc_list_api_keys_proto = out.File
+	file_pb_v1_rpc_list_api_keys_proto_goTypes = nil
+	file_pb_v1_rpc_list_api_keys_proto_depIdxs = nil
+}
@@ -0,0 +1,212 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.31.1
+// source: pb/v1/rpc_list_audit_events.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ListAuditEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Actor         *string                `protobuf:"bytes,1,opt,name=actor,proto3,oneof" json:"actor,omitempty"`
+	Action        *string                `protobuf:"bytes,2,opt,name=action,proto3,oneof" json:"action,omitempty"`
+	Target        *string                `protobuf:"bytes,3,opt,name=target,proto3,oneof" json:"target,omitempty"`
+	PageId        int32                  `protobuf:"varint,4,opt,name=page_id,json=pageId,proto3" json:"page_id,omitempty"`
+	PageSize      int32                  `protobuf:"varint,5,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditEventsRequest) Reset() {
+	*x = ListAuditEventsRequest{}
+	mi := &file_pb_v1_rpc_list_audit_events_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditEventsRequest) ProtoMessage() {}
+
+func (x *ListAuditEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_v1_rpc_list_audit_events_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListAuditEventsRequest) Descriptor() ([]byte, []int) {
+	return file_pb_v1_rpc_list_audit_events_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ListAuditEventsRequest) GetActor() string {
+	if x != nil && x.Actor != nil {
+		return *x.Actor
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetAction() string {
+	if x != nil && x.Action != nil {
+		return *x.Action
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetTarget() string {
+	if x != nil && x.Target != nil {
+		return *x.Target
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetPageId() int32 {
+	if x != nil {
+		return x.PageId
+	}
+	return 0
+}
+
+func (x *ListAuditEventsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListAuditEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*AuditEvent          `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditEventsResponse) Reset() {
+	*x = ListAuditEventsResponse{}
+	mi := &file_pb_v1_rpc_list_audit_events_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditEventsResponse) ProtoMessage() {}
+
+func (x *ListAuditEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_v1_rpc_list_audit_events_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListAuditEventsResponse) Descriptor() ([]byte, []int) {
+	return file_pb_v1_rpc_list_audit_events_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListAuditEventsResponse) GetEvents() []*AuditEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+var File_pb_v1_rpc_list_audit_events_proto protoreflect.FileDescriptor
+
+const file_pb_v1_rpc_list_audit_events_proto_rawDesc = "" +
+	"\n" +
+	"!pb/v1/rpc_list_audit_events.proto\x12\x05pb.v1\x1a\x17pb/v1/audit_event.proto\"\xc3\x01\n" +
+	"\x16ListAuditEventsRequest\x12\x19\n" +
+	"\x05actor\x18\x01 \x01(\tH\x00R\x05actor\x88\x01\x01\x12\x1b\n" +
+	"\x06action\x18\x02 \x01(\tH\x01R\x06action\x88\x01\x01\x12\x1b\n" +
+	"\x06target\x18\x03 \x01(\tH\x02R\x06target\x88\x01\x01\x12\x17\n" +
+	"\apage_id\x18\x04 \x01(\x05R\x06pageId\x12\x1b\n" +
+	"\tpage_size\x18\x05 \x01(\x05R\bpageSizeB\b\n" +
+	"\x06_actorB\t\n" +
+	"\a_actionB\t\n" +
+	"\a_target\"D\n" +
+	"\x17ListAuditEventsResponse\x12)\n" +
+	"\x06events\x18\x01 \x03(\v2\x11.pb.v1.AuditEventR\x06eventsB\"Z github.com/yelaco/simple-bank/pbb\x06proto3"
+
+var (
+	file_pb_v1_rpc_list_audit_events_proto_rawDescOnce sync.Once
+	file_pb_v1_rpc_list_audit_events_proto_rawDescData []byte
+)
+
+func file_pb_v1_rpc_list_audit_events_proto_rawDescGZIP() []byte {
+	file_pb_v1_rpc_list_audit_events_proto_rawDescOnce.Do(func() {
+		file_pb_v1_rpc_list_audit_events_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pb_v1_rpc_list_audit_events_proto_rawDesc), len(file_pb_v1_rpc_list_audit_events_proto_rawDesc)))
+	})
+	return file_pb_v1_rpc_list_audit_events_proto_rawDescData
+}
+
+var file_pb_v1_rpc_list_audit_events_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_pb_v1_rpc_list_audit_events_proto_goTypes = []any{
+	(*ListAuditEventsRequest)(nil),  // 0: pb.v1.ListAuditEventsRequest
+	(*ListAuditEventsResponse)(nil), // 1: pb.v1.ListAuditEventsResponse
+	(*AuditEvent)(nil),              // 2: pb.v1.AuditEvent
+}
+var file_pb_v1_rpc_list_audit_events_proto_depIdxs = []int32{
+	2, // 0: pb.v1.ListAuditEventsResponse.events:type_name -> pb.v1.AuditEvent
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_pb_v1_rpc_list_audit_events_proto_init() }
+func file_pb_v1_rpc_list_audit_events_proto_init() {
+	if File_pb_v1_rpc_list_audit_events_proto != nil {
+		return
+	}
+	file_pb_v1_audit_event_proto_init()
+	file_pb_v1_rpc_list_audit_events_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pb_v1_rpc_list_audit_events_proto_rawDesc), len(file_pb_v1_rpc_list_audit_events_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_pb_v1_rpc_list_audit_events_proto_goTypes,
+		DependencyIndexes: file_pb_v1_rpc_list_audit_events_proto_depIdxs,
+		MessageInfos:      file_pb_v1_rpc_list_audit_events_proto_msgTypes,
+	}.Build()
+	File_pb_v1_rpc_list_audit_events_proto = out.File
+	file_pb_v1_rpc_list_audit_events_proto_goTypes = nil
+	file_pb_v1_rpc_list_audit_events_proto_depIdxs = nil
+}
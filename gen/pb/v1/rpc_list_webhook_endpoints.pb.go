@@ -0,0 +1,163 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.31.1
+// source: pb/v1/rpc_list_webhook_endpoints.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ListWebhookEndpointsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhookEndpointsRequest) Reset() {
+	*x = ListWebhookEndpointsRequest{}
+	mi := &file_pb_v1_rpc_list_webhook_endpoints_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhookEndpointsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhookEndpointsRequest) ProtoMessage() {}
+
+func (x *ListWebhookEndpointsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_v1_rpc_list_webhook_endpoints_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhookEndpointsRequest.ProtoReflect.Descriptor instead.
+func (*ListWebhookEndpointsRequest) Descriptor() ([]byte, []int) {
+	return file_pb_v1_rpc_list_webhook_endpoints_proto_rawDescGZIP(), []int{0}
+}
+
+type ListWebhookEndpointsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Endpoints     []*WebhookEndpoint     `protobuf:"bytes,1,rep,name=endpoints,proto3" json:"endpoints,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhookEndpointsResponse) Reset() {
+	*x = ListWebhookEndpointsResponse{}
+	mi := &file_pb_v1_rpc_list_webhook_endpoints_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhookEndpointsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhookEndpointsResponse) ProtoMessage() {}
+
+func (x *ListWebhookEndpointsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_v1_rpc_list_webhook_endpoints_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhookEndpointsResponse.ProtoReflect.Descriptor instead.
+func (*ListWebhookEndpointsResponse) Descriptor() ([]byte, []int) {
+	return file_pb_v1_rpc_list_webhook_endpoints_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListWebhookEndpointsResponse) GetEndpoints() []*WebhookEndpoint {
+	if x != nil {
+		return x.Endpoints
+	}
+	return nil
+}
+
+var File_pb_v1_rpc_list_webhook_endpoints_proto protoreflect.FileDescriptor
+
+const file_pb_v1_rpc_list_webhook_endpoints_proto_rawDesc = "" +
+	"\n" +
+	"&pb/v1/rpc_list_webhook_endpoints.proto\x12\x05pb.v1\x1a\x13pb/v1/webhook.proto\"\x1d\n" +
+	"\x1bListWebhookEndpointsRequest\"T\n" +
+	"\x1cListWebhookEndpointsResponse\x124\n" +
+	"\tendpoints\x18\x01 \x03(\v2\x16.pb.v1.WebhookEndpointR\tendpointsB\"Z github.com/yelaco/simple-bank/pbb\x06proto3"
+
+var (
+	file_pb_v1_rpc_list_webhook_endpoints_proto_rawDescOnce sync.Once
+	file_pb_v1_rpc_list_webhook_endpoints_proto_rawDescData []byte
+)
+
+func file_pb_v1_rpc_list_webhook_endpoints_proto_rawDescGZIP() []byte {
+	file_pb_v1_rpc_list_webhook_endpoints_proto_rawDescOnce.Do(func() {
+		file_pb_v1_rpc_list_webhook_endpoints_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pb_v1_rpc_list_webhook_endpoints_proto_rawDesc), len(file_pb_v1_rpc_list_webhook_endpoints_proto_rawDesc)))
+	})
+	return file_pb_v1_rpc_list_webhook_endpoints_proto_rawDescData
+}
+
+var file_pb_v1_rpc_list_webhook_endpoints_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_pb_v1_rpc_list_webhook_endpoints_proto_goTypes = []any{
+	(*ListWebhookEndpointsRequest)(nil),  // 0: pb.v1.ListWebhookEndpointsRequest
+	(*ListWebhookEndpointsResponse)(nil), // 1: pb.v1.ListWebhookEndpointsResponse
+	(*WebhookEndpoint)(nil),              // 2: pb.v1.WebhookEndpoint
+}
+var file_pb_v1_rpc_list_webhook_endpoints_proto_depIdxs = []int32{
+	2, // 0: pb.v1.ListWebhookEndpointsResponse.endpoints:type_name -> pb.v1.WebhookEndpoint
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_pb_v1_rpc_list_webhook_endpoints_proto_init() }
+func file_pb_v1_rpc_list_webhook_endpoints_proto_init() {
+	if File_pb_v1_rpc_list_webhook_endpoints_proto != nil {
+		return
+	}
+	file_pb_v1_webhook_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pb_v1_rpc_list_webhook_endpoints_proto_rawDesc), len(file_pb_v1_rpc_list_webhook_endpoints_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_pb_v1_rpc_list_webhook_endpoints_proto_goTypes,
+		DependencyIndexes: file_pb_v1_rpc_list_webhook_endpoints_proto_depIdxs,
+		MessageInfos:      file_pb_v1_rpc_list_webhook_endpoints_proto_msgTypes,
+	}.Build()
+	File_pb_v1_rpc_list_webhook_endpoints_proto = out.File
+	file_pb_v1_rpc_list_webhook_endpoints_proto_goTypes = nil
+	file_pb_v1_rpc_list_webhook_endpoints_proto_depIdxs = nil
+}
@@ -82,6 +82,9 @@ type LoginUserResponse struct {
 	RefreshToken          string                 `protobuf:"bytes,4,opt,name=refreshToken,proto3" json:"refreshToken,omitempty"`
 	AccessTokenExpiresAt  *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=accessTokenExpiresAt,proto3" json:"accessTokenExpiresAt,omitempty"`
 	RefreshTokenExpiresAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=refreshTokenExpiresAt,proto3" json:"refreshTokenExpiresAt,omitempty"`
+	MfaRequired           bool                   `protobuf:"varint,7,opt,name=mfaRequired,proto3" json:"mfaRequired,omitempty"`
+	MfaToken              string                 `protobuf:"bytes,8,opt,name=mfaToken,proto3" json:"mfaToken,omitempty"`
+	MfaTokenExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=mfaTokenExpiresAt,proto3" json:"mfaTokenExpiresAt,omitempty"`
 	unknownFields         protoimpl.UnknownFields
 	sizeCache             protoimpl.SizeCache
 }
@@ -158,6 +161,27 @@ func (x *LoginUserResponse) GetRefreshTokenExpiresAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *LoginUserResponse) GetMfaRequired() bool {
+	if x != nil {
+		return x.MfaRequired
+	}
+	return false
+}
+
+func (x *LoginUserResponse) GetMfaToken() string {
+	if x != nil {
+		return x.MfaToken
+	}
+	return ""
+}
+
+func (x *LoginUserResponse) GetMfaTokenExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.MfaTokenExpiresAt
+	}
+	return nil
+}
+
 var File_pb_v1_rpc_login_user_proto protoreflect.FileDescriptor
 
 const file_pb_v1_rpc_login_user_proto_rawDesc = "" +
@@ -165,14 +189,17 @@ const file_pb_v1_rpc_login_user_proto_rawDesc = "" +
 	"\x1apb/v1/rpc_login_user.proto\x12\x05pb.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x10pb/v1/user.proto\"J\n" +
 	"\x10LoginUserRequest\x12\x1a\n" +
 	"\busername\x18\x01 \x01(\tR\busername\x12\x1a\n" +
-	"\bpassword\x18\x02 \x01(\tR\bpassword\"\xba\x02\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\"\xc2\x03\n" +
 	"\x11LoginUserResponse\x12\x1f\n" +
 	"\x04user\x18\x01 \x01(\v2\v.pb.v1.UserR\x04user\x12\x1c\n" +
 	"\tsessionId\x18\x02 \x01(\tR\tsessionId\x12 \n" +
 	"\vaccessToken\x18\x03 \x01(\tR\vaccessToken\x12\"\n" +
 	"\frefreshToken\x18\x04 \x01(\tR\frefreshToken\x12N\n" +
 	"\x14accessTokenExpiresAt\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x14accessTokenExpiresAt\x12P\n" +
-	"\x15refreshTokenExpiresAt\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x15refreshTokenExpiresAtB\"Z github.com/yelaco/simple-bank/pbb\x06proto3"
+	"\x15refreshTokenExpiresAt\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x15refreshTokenExpiresAt\x12 \n" +
+	"\vmfaRequired\x18\a \x01(\bR\vmfaRequired\x12\x1a\n" +
+	"\bmfaToken\x18\b \x01(\tR\bmfaToken\x12H\n" +
+	"\x11mfaTokenExpiresAt\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\x11mfaTokenExpiresAtB\"Z github.com/yelaco/simple-bank/pbb\x06proto3"
 
 var (
 	file_pb_v1_rpc_login_user_proto_rawDescOnce sync.Once
@@ -197,11 +224,12 @@ var file_pb_v1_rpc_login_user_proto_depIdxs = []int32{
 	2, // 0: pb.v1.LoginUserResponse.user:type_name -> pb.v1.User
 	3, // 1: pb.v1.LoginUserResponse.accessTokenExpiresAt:type_name -> google.protobuf.Timestamp
 	3, // 2: pb.v1.LoginUserResponse.refreshTokenExpiresAt:type_name -> google.protobuf.Timestamp
-	3, // [3:3] is the sub-list for method output_type
-	3, // [3:3] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	3, // 3: pb.v1.LoginUserResponse.mfaTokenExpiresAt:type_name -> google.protobuf.Timestamp
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_pb_v1_rpc_login_user_proto_init() }
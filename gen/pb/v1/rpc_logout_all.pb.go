@@ -0,0 +1,160 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.31.1
+// source: pb/v1/rpc_logout_all.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type LogoutAllRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogoutAllRequest) Reset() {
+	*x = LogoutAllRequest{}
+	mi := &file_pb_v1_rpc_logout_all_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogoutAllRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogoutAllRequest) ProtoMessage() {}
+
+func (x *LogoutAllRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_v1_rpc_logout_all_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogoutAllRequest.ProtoReflect.Descriptor instead.
+func (*LogoutAllRequest) Descriptor() ([]byte, []int) {
+	return file_pb_v1_rpc_logout_all_proto_rawDescGZIP(), []int{0}
+}
+
+type LogoutAllResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	RevokedSessions int64                  `protobuf:"varint,1,opt,name=revoked_sessions,json=revokedSessions,proto3" json:"revoked_sessions,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *LogoutAllResponse) Reset() {
+	*x = LogoutAllResponse{}
+	mi := &file_pb_v1_rpc_logout_all_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogoutAllResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogoutAllResponse) ProtoMessage() {}
+
+func (x *LogoutAllResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_v1_rpc_logout_all_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogoutAllResponse.ProtoReflect.Descriptor instead.
+func (*LogoutAllResponse) Descriptor() ([]byte, []int) {
+	return file_pb_v1_rpc_logout_all_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *LogoutAllResponse) GetRevokedSessions() int64 {
+	if x != nil {
+		return x.RevokedSessions
+	}
+	return 0
+}
+
+var File_pb_v1_rpc_logout_all_proto protoreflect.FileDescriptor
+
+const file_pb_v1_rpc_logout_all_proto_rawDesc = "" +
+	"\n" +
+	"\x1apb/v1/rpc_logout_all.proto\x12\x05pb.v1\"\x12\n" +
+	"\x10LogoutAllRequest\">\n" +
+	"\x11LogoutAllResponse\x12)\n" +
+	"\x10revoked_sessions\x18\x01 \x01(\x03R\x0frevokedSessionsB\"Z github.com/yelaco/simple-bank/pbb\x06proto3"
+
+var (
+	file_pb_v1_rpc_logout_all_proto_rawDescOnce sync.Once
+	file_pb_v1_rpc_logout_all_proto_rawDescData []byte
+)
+
+func file_pb_v1_rpc_logout_all_proto_rawDescGZIP() []byte {
+	file_pb_v1_rpc_logout_all_proto_rawDescOnce.Do(func() {
+		file_pb_v1_rpc_logout_all_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pb_v1_rpc_logout_all_proto_rawDesc), len(file_pb_v1_rpc_logout_all_proto_rawDesc)))
+	})
+	return file_pb_v1_rpc_logout_all_proto_rawDescData
+}
+
+var file_pb_v1_rpc_logout_all_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_pb_v1_rpc_logout_all_proto_goTypes = []any{
+	(*LogoutAllRequest)(nil),  // 0: pb.v1.LogoutAllRequest
+	(*LogoutAllResponse)(nil), // 1: pb.v1.LogoutAllResponse
+}
+var file_pb_v1_rpc_logout_all_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_pb_v1_rpc_logout_all_proto_init() }
+func file_pb_v1_rpc_logout_all_proto_init() {
+	if File_pb_v1_rpc_logout_all_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pb_v1_rpc_logout_all_proto_rawDesc), len(file_pb_v1_rpc_logout_all_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_pb_v1_rpc_logout_all_proto_goTypes,
+		DependencyIndexes: file_pb_v1_rpc_logout_all_proto_depIdxs,
+		MessageInfos:      file_pb_v1_rpc_logout_all_proto_msgTypes,
+	}.Build()
+	File_pb_v1_rpc_logout_all_proto = out.File
+	file_pb_v1_rpc_logout_all_proto_goTypes = nil
+	file_pb_v1_rpc_logout_all_proto_depIdxs = nil
+}
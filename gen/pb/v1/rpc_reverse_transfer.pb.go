@@ -0,0 +1,250 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.31.1
+// source: pb/v1/rpc_reverse_transfer.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ReverseTransferRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	TransferId int64                  `protobuf:"varint,1,opt,name=transfer_id,json=transferId,proto3" json:"transfer_id,omitempty"`
+	// defaults to the amount left to reverse
+	Amount        *int64 `protobuf:"varint,2,opt,name=amount,proto3,oneof" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReverseTransferRequest) Reset() {
+	*x = ReverseTransferRequest{}
+	mi := &file_pb_v1_rpc_reverse_transfer_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReverseTransferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReverseTransferRequest) ProtoMessage() {}
+
+func (x *ReverseTransferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_v1_rpc_reverse_transfer_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReverseTransferRequest.ProtoReflect.Descriptor instead.
+func (*ReverseTransferRequest) Descriptor() ([]byte, []int) {
+	return file_pb_v1_rpc_reverse_transfer_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ReverseTransferRequest) GetTransferId() int64 {
+	if x != nil {
+		return x.TransferId
+	}
+	return 0
+}
+
+func (x *ReverseTransferRequest) GetAmount() int64 {
+	if x != nil && x.Amount != nil {
+		return *x.Amount
+	}
+	return 0
+}
+
+type ReverseTransferResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Transfer         *Transfer              `protobuf:"bytes,1,opt,name=transfer,proto3" json:"transfer,omitempty"`
+	OriginalTransfer *Transfer              `protobuf:"bytes,2,opt,name=original_transfer,json=originalTransfer,proto3" json:"original_transfer,omitempty"`
+	ReversedAmount   int64                  `protobuf:"varint,3,opt,name=reversed_amount,json=reversedAmount,proto3" json:"reversed_amount,omitempty"`
+	FromAccount      *Account               `protobuf:"bytes,4,opt,name=from_account,json=fromAccount,proto3" json:"from_account,omitempty"`
+	ToAccount        *Account               `protobuf:"bytes,5,opt,name=to_account,json=toAccount,proto3" json:"to_account,omitempty"`
+	FromEntry        *Entry                 `protobuf:"bytes,6,opt,name=from_entry,json=fromEntry,proto3" json:"from_entry,omitempty"`
+	ToEntry          *Entry                 `protobuf:"bytes,7,opt,name=to_entry,json=toEntry,proto3" json:"to_entry,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ReverseTransferResponse) Reset() {
+	*x = ReverseTransferResponse{}
+	mi := &file_pb_v1_rpc_reverse_transfer_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReverseTransferResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReverseTransferResponse) ProtoMessage() {}
+
+func (x *ReverseTransferResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_v1_rpc_reverse_transfer_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReverseTransferResponse.ProtoReflect.Descriptor instead.
+func (*ReverseTransferResponse) Descriptor() ([]byte, []int) {
+	return file_pb_v1_rpc_reverse_transfer_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ReverseTransferResponse) GetTransfer() *Transfer {
+	if x != nil {
+		return x.Transfer
+	}
+	return nil
+}
+
+func (x *ReverseTransferResponse) GetOriginalTransfer() *Transfer {
+	if x != nil {
+		return x.OriginalTransfer
+	}
+	return nil
+}
+
+func (x *ReverseTransferResponse) GetReversedAmount() int64 {
+	if x != nil {
+		return x.ReversedAmount
+	}
+	return 0
+}
+
+func (x *ReverseTransferResponse) GetFromAccount() *Account {
+	if x != nil {
+		return x.FromAccount
+	}
+	return nil
+}
+
+func (x *ReverseTransferResponse) GetToAccount() *Account {
+	if x != nil {
+		return x.ToAccount
+	}
+	return nil
+}
+
+func (x *ReverseTransferResponse) GetFromEntry() *Entry {
+	if x != nil {
+		return x.FromEntry
+	}
+	return nil
+}
+
+func (x *ReverseTransferResponse) GetToEntry() *Entry {
+	if x != nil {
+		return x.ToEntry
+	}
+	return nil
+}
+
+var File_pb_v1_rpc_reverse_transfer_proto protoreflect.FileDescriptor
+
+const file_pb_v1_rpc_reverse_transfer_proto_rawDesc = "" +
+	"\n" +
+	" pb/v1/rpc_reverse_transfer.proto\x12\x05pb.v1\x1a\x13pb/v1/account.proto\x1a\x11pb/v1/entry.proto\x1a\x14pb/v1/transfer.proto\"a\n" +
+	"\x16ReverseTransferRequest\x12\x1f\n" +
+	"\vtransfer_id\x18\x01 \x01(\x03R\n" +
+	"transferId\x12\x1b\n" +
+	"\x06amount\x18\x02 \x01(\x03H\x00R\x06amount\x88\x01\x01B\t\n" +
+	"\a_amount\"\xe5\x02\n" +
+	"\x17ReverseTransferResponse\x12+\n" +
+	"\btransfer\x18\x01 \x01(\v2\x0f.pb.v1.TransferR\btransfer\x12<\n" +
+	"\x11original_transfer\x18\x02 \x01(\v2\x0f.pb.v1.TransferR\x10originalTransfer\x12'\n" +
+	"\x0freversed_amount\x18\x03 \x01(\x03R\x0ereversedAmount\x121\n" +
+	"\ffrom_account\x18\x04 \x01(\v2\x0e.pb.v1.AccountR\vfromAccount\x12-\n" +
+	"\n" +
+	"to_account\x18\x05 \x01(\v2\x0e.pb.v1.AccountR\ttoAccount\x12+\n" +
+	"\n" +
+	"from_entry\x18\x06 \x01(\v2\f.pb.v1.EntryR\tfromEntry\x12'\n" +
+	"\bto_entry\x18\a \x01(\v2\f.pb.v1.EntryR\atoEntryB\"Z github.com/yelaco/simple-bank/pbb\x06proto3"
+
+var (
+	file_pb_v1_rpc_reverse_transfer_proto_rawDescOnce sync.Once
+	file_pb_v1_rpc_reverse_transfer_proto_rawDescData []byte
+)
+
+func file_pb_v1_rpc_reverse_transfer_proto_rawDescGZIP() []byte {
+	file_pb_v1_rpc_reverse_transfer_proto_rawDescOnce.Do(func() {
+		file_pb_v1_rpc_reverse_transfer_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pb_v1_rpc_reverse_transfer_proto_rawDesc), len(file_pb_v1_rpc_reverse_transfer_proto_rawDesc)))
+	})
+	return file_pb_v1_rpc_reverse_transfer_proto_rawDescData
+}
+
+var file_pb_v1_rpc_reverse_transfer_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_pb_v1_rpc_reverse_transfer_proto_goTypes = []any{
+	(*ReverseTransferRequest)(nil),  // 0: pb.v1.ReverseTransferRequest
+	(*ReverseTransferResponse)(nil), // 1: pb.v1.ReverseTransferResponse
+	(*Transfer)(nil),                // 2: pb.v1.Transfer
+	(*Account)(nil),                 // 3: pb.v1.Account
+	(*Entry)(nil),                   // 4: pb.v1.Entry
+}
+var file_pb_v1_rpc_reverse_transfer_proto_depIdxs = []int32{
+	2, // 0: pb.v1.ReverseTransferResponse.transfer:type_name -> pb.v1.Transfer
+	2, // 1: pb.v1.ReverseTransferResponse.original_transfer:type_name -> pb.v1.Transfer
+	3, // 2: pb.v1.ReverseTransferResponse.from_account:type_name -> pb.v1.Account
+	3, // 3: pb.v1.ReverseTransferResponse.to_account:type_name -> pb.v1.Account
+	4, // 4: pb.v1.ReverseTransferResponse.from_entry:type_name -> pb.v1.Entry
+	4, // 5: pb.v1.ReverseTransferResponse.to_entry:type_name -> pb.v1.Entry
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_pb_v1_rpc_reverse_transfer_proto_init() }
+func file_pb_v1_rpc_reverse_transfer_proto_init() {
+	if File_pb_v1_rpc_reverse_transfer_proto != nil {
+		return
+	}
+	file_pb_v1_account_proto_init()
+	file_pb_v1_entry_proto_init()
+	file_pb_v1_transfer_proto_init()
+	file_pb_v1_rpc_reverse_transfer_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pb_v1_rpc_reverse_transfer_proto_rawDesc), len(file_pb_v1_rpc_reverse_transfer_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_pb_v1_rpc_reverse_transfer_proto_goTypes,
+		DependencyIndexes: file_pb_v1_rpc_reverse_transfer_proto_depIdxs,
+		MessageInfos:      file_pb_v1_rpc_reverse_transfer_proto_msgTypes,
+	}.Build()
+	File_pb_v1_rpc_reverse_transfer_proto = out.File
+	file_pb_v1_rpc_reverse_transfer_proto_goTypes = nil
+	file_pb_v1_rpc_reverse_transfer_proto_depIdxs = nil
+}
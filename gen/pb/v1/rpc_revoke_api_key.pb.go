@@ -0,0 +1,173 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.31.1
+// source: pb/v1/rpc_revoke_api_key.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type RevokeAPIKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApiKeyId      string                 `protobuf:"bytes,1,opt,name=api_key_id,json=apiKeyId,proto3" json:"api_key_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeAPIKeyRequest) Reset() {
+	*x = RevokeAPIKeyRequest{}
+	mi := &file_pb_v1_rpc_revoke_api_key_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeAPIKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeAPIKeyRequest) ProtoMessage() {}
+
+func (x *RevokeAPIKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_v1_rpc_revoke_api_key_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeAPIKeyRequest.ProtoReflect.Descriptor instead.
+func (*RevokeAPIKeyRequest) Descriptor() ([]byte, []int) {
+	return file_pb_v1_rpc_revoke_api_key_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RevokeAPIKeyRequest) GetApiKeyId() string {
+	if x != nil {
+		return x.ApiKeyId
+	}
+	return ""
+}
+
+type RevokeAPIKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApiKey        *APIKey                `protobuf:"bytes,1,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeAPIKeyResponse) Reset() {
+	*x = RevokeAPIKeyResponse{}
+	mi := &file_pb_v1_rpc_revoke_api_key_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeAPIKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeAPIKeyResponse) ProtoMessage() {}
+
+func (x *RevokeAPIKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_v1_rpc_revoke_api_key_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeAPIKeyResponse.ProtoReflect.Descriptor instead.
+func (*RevokeAPIKeyResponse) Descriptor() ([]byte, []int) {
+	return file_pb_v1_rpc_revoke_api_key_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RevokeAPIKeyResponse) GetApiKey() *APIKey {
+	if x != nil {
+		return x.ApiKey
+	}
+	return nil
+}
+
+var File_pb_v1_rpc_revoke_api_key_proto protoreflect.FileDescriptor
+
+const file_pb_v1_rpc_revoke_api_key_proto_rawDesc = "" +
+	"\n" +
+	"\x1epb/v1/rpc_revoke_api_key.proto\x12\x05pb.v1\x1a\x13pb/v1/api_key.proto\"3\n" +
+	"\x13RevokeAPIKeyRequest\x12\x1c\n" +
+	"\n" +
+	"api_key_id\x18\x01 \x01(\tR\bapiKeyId\">\n" +
+	"\x14RevokeAPIKeyResponse\x12&\n" +
+	"\aapi_key\x18\x01 \x01(\v2\r.pb.v1.APIKeyR\x06apiKeyB\"Z github.com/yelaco/simple-bank/pbb\x06proto3"
+
+var (
+	file_pb_v1_rpc_revoke_api_key_proto_rawDescOnce sync.Once
+	file_pb_v1_rpc_revoke_api_key_proto_rawDescData []byte
+)
+
+func file_pb_v1_rpc_revoke_api_key_proto_rawDescGZIP() []byte {
+	file_pb_v1_rpc_revoke_api_key_proto_rawDescOnce.Do(func() {
+		file_pb_v1_rpc_revoke_api_key_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pb_v1_rpc_revoke_api_key_proto_rawDesc), len(file_pb_v1_rpc_revoke_api_key_proto_rawDesc)))
+	})
+	return file_pb_v1_rpc_revoke_api_key_proto_rawDescData
+}
+
+var file_pb_v1_rpc_revoke_api_key_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_pb_v1_rpc_revoke_api_key_proto_goTypes = []any{
+	(*RevokeAPIKeyRequest)(nil),  // 0: pb.v1.RevokeAPIKeyRequest
+	(*RevokeAPIKeyResponse)(nil), // 1: pb.v1.RevokeAPIKeyResponse
+	(*APIKey)(nil),               // 2: pb.v1.APIKey
+}
+var file_pb_v1_rpc_revoke_api_key_proto_depIdxs = []int32{
+	2, // 0: pb.v1.RevokeAPIKeyResponse.api_key:type_name -> pb.v1.APIKey
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_pb_v1_rpc_revoke_api_key_proto_init() }
+func file_pb_v1_rpc_revoke_api_key_proto_init() {
+	if File_pb_v1_rpc_revoke_api_key_proto != nil {
+		return
+	}
+	file_pb_v1_api_key_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pb_v1_rpc_revoke_api_key_proto_rawDesc), len(file_pb_v1_rpc_revoke_api_key_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_pb_v1_rpc_revoke_api_key_proto_goTypes,
+		DependencyIndexes: file_pb_v1_rpc_revoke_api_key_proto_depIdxs,
+		MessageInfos:      file_pb_v1_rpc_revoke_api_key_proto_msgTypes,
+	}.Build()
+	File_pb_v1_rpc_revoke_api_key_proto = out.File
+	file_pb_v1_rpc_revoke_api_key_proto_goTypes = nil
+	file_pb_v1_rpc_revoke_api_key_proto_depIdxs = nil
+}
@@ -0,0 +1,195 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.31.1
+// source: pb/v1/rpc_send_statement.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SendStatementRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	AccountId int64                  `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	StartTime *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	// exclusive
+	EndTime       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendStatementRequest) Reset() {
+	*x = SendStatementRequest{}
+	mi := &file_pb_v1_rpc_send_statement_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendStatementRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendStatementRequest) ProtoMessage() {}
+
+func (x *SendStatementRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_v1_rpc_send_statement_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendStatementRequest.ProtoReflect.Descriptor instead.
+func (*SendStatementRequest) Descriptor() ([]byte, []int) {
+	return file_pb_v1_rpc_send_statement_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SendStatementRequest) GetAccountId() int64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *SendStatementRequest) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *SendStatementRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+type SendStatementResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// address the statement is sent to
+	Email         string `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendStatementResponse) Reset() {
+	*x = SendStatementResponse{}
+	mi := &file_pb_v1_rpc_send_statement_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendStatementResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendStatementResponse) ProtoMessage() {}
+
+func (x *SendStatementResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_v1_rpc_send_statement_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendStatementResponse.ProtoReflect.Descriptor instead.
+func (*SendStatementResponse) Descriptor() ([]byte, []int) {
+	return file_pb_v1_rpc_send_statement_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SendStatementResponse) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+var File_pb_v1_rpc_send_statement_proto protoreflect.FileDescriptor
+
+const file_pb_v1_rpc_send_statement_proto_rawDesc = "" +
+	"\n" +
+	"\x1epb/v1/rpc_send_statement.proto\x12\x05pb.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xa7\x01\n" +
+	"\x14SendStatementRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x03R\taccountId\x129\n" +
+	"\n" +
+	"start_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\"-\n" +
+	"\x15SendStatementResponse\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05emailB\"Z github.com/yelaco/simple-bank/pbb\x06proto3"
+
+var (
+	file_pb_v1_rpc_send_statement_proto_rawDescOnce sync.Once
+	file_pb_v1_rpc_send_statement_proto_rawDescData []byte
+)
+
+func file_pb_v1_rpc_send_statement_proto_rawDescGZIP() []byte {
+	file_pb_v1_rpc_send_statement_proto_rawDescOnce.Do(func() {
+		file_pb_v1_rpc_send_statement_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pb_v1_rpc_send_statement_proto_rawDesc), len(file_pb_v1_rpc_send_statement_proto_rawDesc)))
+	})
+	return file_pb_v1_rpc_send_statement_proto_rawDescData
+}
+
+var file_pb_v1_rpc_send_statement_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_pb_v1_rpc_send_statement_proto_goTypes = []any{
+	(*SendStatementRequest)(nil),  // 0: pb.v1.SendStatementRequest
+	(*SendStatementResponse)(nil), // 1: pb.v1.SendStatementResponse
+	(*timestamppb.Timestamp)(nil), // 2: google.protobuf.Timestamp
+}
+var file_pb_v1_rpc_send_statement_proto_depIdxs = []int32{
+	2, // 0: pb.v1.SendStatementRequest.start_time:type_name -> google.protobuf.Timestamp
+	2, // 1: pb.v1.SendStatementRequest.end_time:type_name -> google.protobuf.Timestamp
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_pb_v1_rpc_send_statement_proto_init() }
+func file_pb_v1_rpc_send_statement_proto_init() {
+	if File_pb_v1_rpc_send_statement_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pb_v1_rpc_send_statement_proto_rawDesc), len(file_pb_v1_rpc_send_statement_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_pb_v1_rpc_send_statement_proto_goTypes,
+		DependencyIndexes: file_pb_v1_rpc_send_statement_proto_depIdxs,
+		MessageInfos:      file_pb_v1_rpc_send_statement_proto_msgTypes,
+	}.Build()
+	File_pb_v1_rpc_send_statement_proto = out.File
+	file_pb_v1_rpc_send_statement_proto_goTypes = nil
+	file_pb_v1_rpc_send_statement_proto_depIdxs = nil
+}
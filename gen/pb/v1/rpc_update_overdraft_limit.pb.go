@@ -0,0 +1,182 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.31.1
+// source: pb/v1/rpc_update_overdraft_limit.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type UpdateOverdraftLimitRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	AccountId      int64                  `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	OverdraftLimit int64                  `protobuf:"varint,2,opt,name=overdraft_limit,json=overdraftLimit,proto3" json:"overdraft_limit,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UpdateOverdraftLimitRequest) Reset() {
+	*x = UpdateOverdraftLimitRequest{}
+	mi := &file_pb_v1_rpc_update_overdraft_limit_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOverdraftLimitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOverdraftLimitRequest) ProtoMessage() {}
+
+func (x *UpdateOverdraftLimitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_v1_rpc_update_overdraft_limit_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOverdraftLimitRequest.ProtoReflect.Descriptor instead.
+func (*UpdateOverdraftLimitRequest) Descriptor() ([]byte, []int) {
+	return file_pb_v1_rpc_update_overdraft_limit_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *UpdateOverdraftLimitRequest) GetAccountId() int64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *UpdateOverdraftLimitRequest) GetOverdraftLimit() int64 {
+	if x != nil {
+		return x.OverdraftLimit
+	}
+	return 0
+}
+
+type UpdateOverdraftLimitResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Account       *Account               `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateOverdraftLimitResponse) Reset() {
+	*x = UpdateOverdraftLimitResponse{}
+	mi := &file_pb_v1_rpc_update_overdraft_limit_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOverdraftLimitResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOverdraftLimitResponse) ProtoMessage() {}
+
+func (x *UpdateOverdraftLimitResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_v1_rpc_update_overdraft_limit_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOverdraftLimitResponse.ProtoReflect.Descriptor instead.
+func (*UpdateOverdraftLimitResponse) Descriptor() ([]byte, []int) {
+	return file_pb_v1_rpc_update_overdraft_limit_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *UpdateOverdraftLimitResponse) GetAccount() *Account {
+	if x != nil {
+		return x.Account
+	}
+	return nil
+}
+
+var File_pb_v1_rpc_update_overdraft_limit_proto protoreflect.FileDescriptor
+
+const file_pb_v1_rpc_update_overdraft_limit_proto_rawDesc = "" +
+	"\n" +
+	"&pb/v1/rpc_update_overdraft_limit.proto\x12\x05pb.v1\x1a\x13pb/v1/account.proto\"e\n" +
+	"\x1bUpdateOverdraftLimitRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x03R\taccountId\x12'\n" +
+	"\x0foverdraft_limit\x18\x02 \x01(\x03R\x0eoverdraftLimit\"H\n" +
+	"\x1cUpdateOverdraftLimitResponse\x12(\n" +
+	"\aaccount\x18\x01 \x01(\v2\x0e.pb.v1.AccountR\aaccountB\"Z github.com/yelaco/simple-bank/pbb\x06proto3"
+
+var (
+	file_pb_v1_rpc_update_overdraft_limit_proto_rawDescOnce sync.Once
+	file_pb_v1_rpc_update_overdraft_limit_proto_rawDescData []byte
+)
+
+func file_pb_v1_rpc_update_overdraft_limit_proto_rawDescGZIP() []byte {
+	file_pb_v1_rpc_update_overdraft_limit_proto_rawDescOnce.Do(func() {
+		file_pb_v1_rpc_update_overdraft_limit_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pb_v1_rpc_update_overdraft_limit_proto_rawDesc), len(file_pb_v1_rpc_update_overdraft_limit_proto_rawDesc)))
+	})
+	return file_pb_v1_rpc_update_overdraft_limit_proto_rawDescData
+}
+
+var file_pb_v1_rpc_update_overdraft_limit_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_pb_v1_rpc_update_overdraft_limit_proto_goTypes = []any{
+	(*UpdateOverdraftLimitRequest)(nil),  // 0: pb.v1.UpdateOverdraftLimitRequest
+	(*UpdateOverdraftLimitResponse)(nil), // 1: pb.v1.UpdateOverdraftLimitResponse
+	(*Account)(nil),                      // 2: pb.v1.Account
+}
+var file_pb_v1_rpc_update_overdraft_limit_proto_depIdxs = []int32{
+	2, // 0: pb.v1.UpdateOverdraftLimitResponse.account:type_name -> pb.v1.Account
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_pb_v1_rpc_update_overdraft_limit_proto_init() }
+func file_pb_v1_rpc_update_overdraft_limit_proto_init() {
+	if File_pb_v1_rpc_update_overdraft_limit_proto != nil {
+		return
+	}
+	file_pb_v1_account_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pb_v1_rpc_update_overdraft_limit_proto_rawDesc), len(file_pb_v1_rpc_update_overdraft_limit_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_pb_v1_rpc_update_overdraft_limit_proto_goTypes,
+		DependencyIndexes: file_pb_v1_rpc_update_overdraft_limit_proto_depIdxs,
+		MessageInfos:      file_pb_v1_rpc_update_overdraft_limit_proto_msgTypes,
+	}.Build()
+	File_pb_v1_rpc_update_overdraft_limit_proto = out.File
+	file_pb_v1_rpc_update_overdraft_limit_proto_goTypes = nil
+	file_pb_v1_rpc_update_overdraft_limit_proto_depIdxs = nil
+}
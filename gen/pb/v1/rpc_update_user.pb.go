@@ -27,6 +27,7 @@ type UpdateUserRequest struct {
 	FullName      *string                `protobuf:"bytes,2,opt,name=fullName,proto3,oneof" json:"fullName,omitempty"`
 	Email         *string                `protobuf:"bytes,3,opt,name=email,proto3,oneof" json:"email,omitempty"`
 	Password      *string                `protobuf:"bytes,4,opt,name=password,proto3,oneof" json:"password,omitempty"`
+	TotpCode      *string                `protobuf:"bytes,5,opt,name=totpCode,proto3,oneof" json:"totpCode,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -89,6 +90,13 @@ func (x *UpdateUserRequest) GetPassword() string {
 	return ""
 }
 
+func (x *UpdateUserRequest) GetTotpCode() string {
+	if x != nil && x.TotpCode != nil {
+		return *x.TotpCode
+	}
+	return ""
+}
+
 type UpdateUserResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
@@ -137,15 +145,17 @@ var File_pb_v1_rpc_update_user_proto protoreflect.FileDescriptor
 
 const file_pb_v1_rpc_update_user_proto_rawDesc = "" +
 	"\n" +
-	"\x1bpb/v1/rpc_update_user.proto\x12\x05pb.v1\x1a\x10pb/v1/user.proto\"\xb0\x01\n" +
+	"\x1bpb/v1/rpc_update_user.proto\x12\x05pb.v1\x1a\x10pb/v1/user.proto\"\xde\x01\n" +
 	"\x11UpdateUserRequest\x12\x1a\n" +
 	"\busername\x18\x01 \x01(\tR\busername\x12\x1f\n" +
 	"\bfullName\x18\x02 \x01(\tH\x00R\bfullName\x88\x01\x01\x12\x19\n" +
 	"\x05email\x18\x03 \x01(\tH\x01R\x05email\x88\x01\x01\x12\x1f\n" +
-	"\bpassword\x18\x04 \x01(\tH\x02R\bpassword\x88\x01\x01B\v\n" +
+	"\bpassword\x18\x04 \x01(\tH\x02R\bpassword\x88\x01\x01\x12\x1f\n" +
+	"\btotpCode\x18\x05 \x01(\tH\x03R\btotpCode\x88\x01\x01B\v\n" +
 	"\t_fullNameB\b\n" +
 	"\x06_emailB\v\n" +
-	"\t_password\"5\n" +
+	"\t_passwordB\v\n" +
+	"\t_totpCode\"5\n" +
 	"\x12UpdateUserResponse\x12\x1f\n" +
 	"\x04user\x18\x01 \x01(\v2\v.pb.v1.UserR\x04userB\"Z github.com/yelaco/simple-bank/pbb\x06proto3"
 
@@ -0,0 +1,172 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.31.1
+// source: pb/v1/rpc_void_hold.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type VoidHoldRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	HoldId        int64                  `protobuf:"varint,1,opt,name=hold_id,json=holdId,proto3" json:"hold_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VoidHoldRequest) Reset() {
+	*x = VoidHoldRequest{}
+	mi := &file_pb_v1_rpc_void_hold_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VoidHoldRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VoidHoldRequest) ProtoMessage() {}
+
+func (x *VoidHoldRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_v1_rpc_void_hold_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VoidHoldRequest.ProtoReflect.Descriptor instead.
+func (*VoidHoldRequest) Descriptor() ([]byte, []int) {
+	return file_pb_v1_rpc_void_hold_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *VoidHoldRequest) GetHoldId() int64 {
+	if x != nil {
+		return x.HoldId
+	}
+	return 0
+}
+
+type VoidHoldResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hold          *Hold                  `protobuf:"bytes,1,opt,name=hold,proto3" json:"hold,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VoidHoldResponse) Reset() {
+	*x = VoidHoldResponse{}
+	mi := &file_pb_v1_rpc_void_hold_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VoidHoldResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VoidHoldResponse) ProtoMessage() {}
+
+func (x *VoidHoldResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_v1_rpc_void_hold_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VoidHoldResponse.ProtoReflect.Descriptor instead.
+func (*VoidHoldResponse) Descriptor() ([]byte, []int) {
+	return file_pb_v1_rpc_void_hold_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *VoidHoldResponse) GetHold() *Hold {
+	if x != nil {
+		return x.Hold
+	}
+	return nil
+}
+
+var File_pb_v1_rpc_void_hold_proto protoreflect.FileDescriptor
+
+const file_pb_v1_rpc_void_hold_proto_rawDesc = "" +
+	"\n" +
+	"\x19pb/v1/rpc_void_hold.proto\x12\x05pb.v1\x1a\x10pb/v1/hold.proto\"*\n" +
+	"\x0fVoidHoldRequest\x12\x17\n" +
+	"\ahold_id\x18\x01 \x01(\x03R\x06holdId\"3\n" +
+	"\x10VoidHoldResponse\x12\x1f\n" +
+	"\x04hold\x18\x01 \x01(\v2\v.pb.v1.HoldR\x04holdB\"Z github.com/yelaco/simple-bank/pbb\x06proto3"
+
+var (
+	file_pb_v1_rpc_void_hold_proto_rawDescOnce sync.Once
+	file_pb_v1_rpc_void_hold_proto_rawDescData []byte
+)
+
+func file_pb_v1_rpc_void_hold_proto_rawDescGZIP() []byte {
+	file_pb_v1_rpc_void_hold_proto_rawDescOnce.Do(func() {
+		file_pb_v1_rpc_void_hold_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pb_v1_rpc_void_hold_proto_rawDesc), len(file_pb_v1_rpc_void_hold_proto_rawDesc)))
+	})
+	return file_pb_v1_rpc_void_hold_proto_rawDescData
+}
+
+var file_pb_v1_rpc_void_hold_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_pb_v1_rpc_void_hold_proto_goTypes = []any{
+	(*VoidHoldRequest)(nil),  // 0: pb.v1.VoidHoldRequest
+	(*VoidHoldResponse)(nil), // 1: pb.v1.VoidHoldResponse
+	(*Hold)(nil),             // 2: pb.v1.Hold
+}
+var file_pb_v1_rpc_void_hold_proto_depIdxs = []int32{
+	2, // 0: pb.v1.VoidHoldResponse.hold:type_name -> pb.v1.Hold
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_pb_v1_rpc_void_hold_proto_init() }
+func file_pb_v1_rpc_void_hold_proto_init() {
+	if File_pb_v1_rpc_void_hold_proto != nil {
+		return
+	}
+	file_pb_v1_hold_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pb_v1_rpc_void_hold_proto_rawDesc), len(file_pb_v1_rpc_void_hold_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_pb_v1_rpc_void_hold_proto_goTypes,
+		DependencyIndexes: file_pb_v1_rpc_void_hold_proto_depIdxs,
+		MessageInfos:      file_pb_v1_rpc_void_hold_proto_msgTypes,
+	}.Build()
+	File_pb_v1_rpc_void_hold_proto = out.File
+	file_pb_v1_rpc_void_hold_proto_goTypes = nil
+	file_pb_v1_rpc_void_hold_proto_depIdxs = nil
+}
@@ -26,44 +26,231 @@ var File_pb_v1_service_simple_bank_proto protoreflect.FileDescriptor
 
 const file_pb_v1_service_simple_bank_proto_rawDesc = "" +
 	"\n" +
-	"\x1fpb/v1/service_simple_bank.proto\x12\x05pb.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1bpb/v1/rpc_create_user.proto\x1a\x1apb/v1/rpc_login_user.proto\x1a\x1bpb/v1/rpc_update_user.proto\x1a\x1cpb/v1/rpc_verify_email.proto\x1a.protoc-gen-openapiv2/options/annotations.proto2\xfd\x04\n" +
+	"\x1fpb/v1/service_simple_bank.proto\x12\x05pb.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1bpb/v1/rpc_assign_role.proto\x1a)pb/v1/rpc_cancel_scheduled_transfer.proto\x1a\x1cpb/v1/rpc_capture_hold.proto\x1a\x1dpb/v1/rpc_close_account.proto\x1a\x1cpb/v1/rpc_confirm_totp.proto\x1a\x1epb/v1/rpc_create_account.proto\x1a\x1epb/v1/rpc_create_api_key.proto\x1a\x1bpb/v1/rpc_create_hold.proto\x1a#pb/v1/rpc_create_oauth_client.proto\x1a)pb/v1/rpc_create_scheduled_transfer.proto\x1a\x1fpb/v1/rpc_create_transfer.proto\x1a\x1bpb/v1/rpc_create_user.proto\x1a'pb/v1/rpc_create_webhook_endpoint.proto\x1a'pb/v1/rpc_delete_webhook_endpoint.proto\x1a\x1cpb/v1/rpc_disable_totp.proto\x1a\x1bpb/v1/rpc_enroll_totp.proto\x1a\x1bpb/v1/rpc_get_account.proto\x1a\x1dpb/v1/rpc_list_accounts.proto\x1a\x1dpb/v1/rpc_list_api_keys.proto\x1a!pb/v1/rpc_list_audit_events.proto\x1a\x1dpb/v1/rpc_list_sessions.proto\x1a'pb/v1/rpc_list_webhook_deliveries.proto\x1a&pb/v1/rpc_list_webhook_endpoints.proto\x1a\x1apb/v1/rpc_login_user.proto\x1a\x16pb/v1/rpc_logout.proto\x1a\x1apb/v1/rpc_logout_all.proto\x1a\"pb/v1/rpc_renew_access_token.proto\x1a'pb/v1/rpc_replay_webhook_delivery.proto\x1a&pb/v1/rpc_request_password_reset.proto\x1a\x1epb/v1/rpc_reset_password.proto\x1a pb/v1/rpc_reverse_transfer.proto\x1a\x1epb/v1/rpc_revoke_api_key.proto\x1a\x1epb/v1/rpc_revoke_session.proto\x1a\x1epb/v1/rpc_send_statement.proto\x1a\x1bpb/v1/rpc_unlock_user.proto\x1a&pb/v1/rpc_update_overdraft_limit.proto\x1a\x1bpb/v1/rpc_update_user.proto\x1a\x1cpb/v1/rpc_verify_email.proto\x1a pb/v1/rpc_verify_login_mfa.proto\x1a\x19pb/v1/rpc_void_hold.proto\x1a.protoc-gen-openapiv2/options/annotations.proto2\x90F\n" +
 	"\n" +
 	"SimpleBank\x12\x96\x01\n" +
 	"\n" +
 	"CreateUser\x12\x18.pb.v1.CreateUserRequest\x1a\x19.pb.v1.CreateUserResponse\"S\x92A6\x12\x11Create a new user\x1a!Use this API to create a new user\x82\xd3\xe4\x93\x02\x14:\x01*\"\x0f/v1/create_user\x12\x8a\x01\n" +
 	"\n" +
-	"UpdateUser\x12\x18.pb.v1.UpdateUserRequest\x1a\x19.pb.v1.UpdateUserResponse\"G\x92A*\x12\vUpdate user\x1a\x1bUse this API to update user\x82\xd3\xe4\x93\x02\x14:\x01*2\x0f/v1/update_user\x12\xa9\x01\n" +
+	"UpdateUser\x12\x18.pb.v1.UpdateUserRequest\x1a\x19.pb.v1.UpdateUserResponse\"G\x92A*\x12\vUpdate user\x1a\x1bUse this API to update user\x82\xd3\xe4\x93\x02\x14:\x01*2\x0f/v1/update_user\x12\xd6\x01\n" +
+	"\n" +
+	"UnlockUser\x12\x18.pb.v1.UnlockUserRequest\x1a\x19.pb.v1.UnlockUserResponse\"\x92\x01\x92Au\x12\vUnlock user\x1afUse this API to lift the lockout of a user after too many failed logins. Only bankers can unlock users\x82\xd3\xe4\x93\x02\x14:\x01*\"\x0f/v1/unlock_user\x12\xfc\x01\n" +
+	"\n" +
+	"AssignRole\x12\x18.pb.v1.AssignRoleRequest\x1a\x19.pb.v1.AssignRoleResponse\"\xb8\x01\x92A\x9a\x01\x12\vAssign role\x1a\x8a\x01Use this API to change the role, and so the permissions, of a user. The user has to log in again afterwards. Only bankers can assign roles\x82\xd3\xe4\x93\x02\x14:\x01*\"\x0f/v1/assign_role\x12\xa9\x01\n" +
 	"\tLoginUser\x12\x17.pb.v1.LoginUserRequest\x1a\x18.pb.v1.LoginUserResponse\"i\x92AM\x12\n" +
-	"Login user\x1a?Use this API to login user and get access token & refresh token\x82\xd3\xe4\x93\x02\x13:\x01*\"\x0e/v1/login_user\x12\x9c\x01\n" +
-	"\vVerifyEmail\x12\x19.pb.v1.VerifyEmailRequest\x1a\x1a.pb.v1.VerifyEmailResponse\"V\x92A;\x12\fVerify email\x1a+Use this API to verify user's email address\x82\xd3\xe4\x93\x02\x12\x12\x10/v1/verify_emailB\xb2\x01\x92A\x8c\x01\x12\x89\x01\n" +
+	"Login user\x1a?Use this API to login user and get access token & refresh token\x82\xd3\xe4\x93\x02\x13:\x01*\"\x0e/v1/login_user\x12\x9a\x02\n" +
+	"\x0eVerifyLoginMFA\x12\x1c.pb.v1.VerifyLoginMFARequest\x1a\x1d.pb.v1.VerifyLoginMFAResponse\"\xca\x01\x92A\xa7\x01\x12\x10Verify login MFA\x1a\x92\x01Use this API to complete the login of a user with two-factor authentication, using the mfa token returned by LoginUser and a TOTP or recovery code\x82\xd3\xe4\x93\x02\x19:\x01*\"\x14/v1/verify_login_mfa\x12\x9c\x01\n" +
+	"\vVerifyEmail\x12\x19.pb.v1.VerifyEmailRequest\x1a\x1a.pb.v1.VerifyEmailResponse\"V\x92A;\x12\fVerify email\x1a+Use this API to verify user's email address\x82\xd3\xe4\x93\x02\x12\x12\x10/v1/verify_email\x12\x85\x02\n" +
+	"\x14RequestPasswordReset\x12\".pb.v1.RequestPasswordResetRequest\x1a#.pb.v1.RequestPasswordResetResponse\"\xa3\x01\x92A{\x12\x16Request password reset\x1aaUse this API to receive a password reset link by email. It succeeds even if no user has the email\x82\xd3\xe4\x93\x02\x1f:\x01*\"\x1a/v1/request_password_reset\x12\xe9\x01\n" +
+	"\rResetPassword\x12\x1b.pb.v1.ResetPasswordRequest\x1a\x1c.pb.v1.ResetPasswordResponse\"\x9c\x01\x92A|\x12\x0eReset password\x1ajUse this API to set a new password with the code from the reset link. All sessions of the user are revoked\x82\xd3\xe4\x93\x02\x17:\x01*\"\x12/v1/reset_password\x12\xab\x01\n" +
+	"\rCreateAccount\x12\x1b.pb.v1.CreateAccountRequest\x1a\x1c.pb.v1.CreateAccountResponse\"_\x92A?\x12\x0eCreate account\x1a-Use this API to open a new account for a user\x82\xd3\xe4\x93\x02\x17:\x01*\"\x12/v1/create_account\x12\x94\x01\n" +
+	"\n" +
+	"GetAccount\x12\x18.pb.v1.GetAccountRequest\x1a\x19.pb.v1.GetAccountResponse\"Q\x92A7\x12\vGet account\x1a(Use this API to get an account by its ID\x82\xd3\xe4\x93\x02\x11\x12\x0f/v1/get_account\x12\xc0\x01\n" +
+	"\fListAccounts\x12\x1a.pb.v1.ListAccountsRequest\x1a\x1b.pb.v1.ListAccountsResponse\"w\x92A[\x12\rList accounts\x1aJUse this API to list accounts. Bankers can list the accounts of every user\x82\xd3\xe4\x93\x02\x13\x12\x11/v1/list_accounts\x12\xad\x01\n" +
+	"\fCloseAccount\x12\x1a.pb.v1.CloseAccountRequest\x1a\x1b.pb.v1.CloseAccountResponse\"d\x92AE\x12\rClose account\x1a4Use this API to close an account with a zero balance\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/v1/close_account\x12\x84\x03\n" +
+	"\x0eCreateTransfer\x12\x1c.pb.v1.CreateTransferRequest\x1a\x1d.pb.v1.CreateTransferResponse\"\xb4\x02\x92A\x92\x02\x12\x0fCreate transfer\x1a\xfe\x01Use this API to transfer money between two accounts. Retrying with the same idempotency key returns the original result instead of transferring again. When the receiving account holds another currency, the amount is converted at the current exchange rate\x82\xd3\xe4\x93\x02\x18:\x01*\"\x13/v1/create_transfer\x12\x83\x02\n" +
+	"\x14UpdateOverdraftLimit\x12\".pb.v1.UpdateOverdraftLimitRequest\x1a#.pb.v1.UpdateOverdraftLimitResponse\"\xa1\x01\x92Ay\x12\x16Update overdraft limit\x1a_Use this API to set how far below zero an account balance may go. Only bankers can use this API\x82\xd3\xe4\x93\x02\x1f:\x01*2\x1a/v1/update_overdraft_limit\x12\xc4\x02\n" +
+	"\x17CreateScheduledTransfer\x12%.pb.v1.CreateScheduledTransferRequest\x1a&.pb.v1.CreateScheduledTransferResponse\"\xd9\x01\x92A\xad\x01\x12\x19Create scheduled transfer\x1a\x8f\x01Use this API to schedule a one-off future transfer, or a daily, weekly or monthly recurring transfer, between two accounts of the same currency\x82\xd3\xe4\x93\x02\":\x01*\"\x1d/v1/create_scheduled_transfer\x12\xe3\x01\n" +
+	"\x17CancelScheduledTransfer\x12%.pb.v1.CancelScheduledTransferRequest\x1a&.pb.v1.CancelScheduledTransferResponse\"y\x92AN\x12\x19Cancel scheduled transfer\x1a1Use this API to stop an active scheduled transfer\x82\xd3\xe4\x93\x02\":\x01*\"\x1d/v1/cancel_scheduled_transfer\x12\xf8\x01\n" +
+	"\x0fReverseTransfer\x12\x1d.pb.v1.ReverseTransferRequest\x1a\x1e.pb.v1.ReverseTransferResponse\"\xa5\x01\x92A\x82\x01\x12\x10Reverse transfer\x1anUse this API to move money of a transfer back to the sender, fully or partially. Only bankers can use this API\x82\xd3\xe4\x93\x02\x19:\x01*\"\x14/v1/reverse_transfer\x12\xdc\x01\n" +
+	"\rSendStatement\x12\x1b.pb.v1.SendStatementRequest\x1a\x1c.pb.v1.SendStatementResponse\"\x8f\x01\x92Ao\x12\x0eSend statement\x1a]Use this API to receive the statement of an account for a date range by email, as PDF and CSV\x82\xd3\xe4\x93\x02\x17:\x01*\"\x12/v1/send_statement\x12\xce\x01\n" +
+	"\n" +
+	"CreateHold\x12\x18.pb.v1.CreateHoldRequest\x1a\x19.pb.v1.CreateHoldResponse\"\x8a\x01\x92Am\x12\vCreate hold\x1a^Use this API to reserve money on an account. The money is only moved when the hold is captured\x82\xd3\xe4\x93\x02\x14:\x01*\"\x0f/v1/create_hold\x12\xba\x01\n" +
+	"\vCaptureHold\x12\x19.pb.v1.CaptureHoldRequest\x1a\x1a.pb.v1.CaptureHoldResponse\"t\x92AV\x12\fCapture hold\x1aFUse this API to settle a pending hold and transfer the captured amount\x82\xd3\xe4\x93\x02\x15:\x01*\"\x10/v1/capture_hold\x12\xa9\x01\n" +
+	"\bVoidHold\x12\x16.pb.v1.VoidHoldRequest\x1a\x17.pb.v1.VoidHoldResponse\"l\x92AQ\x12\tVoid hold\x1aDUse this API to cancel a pending hold and release the reserved money\x82\xd3\xe4\x93\x02\x12:\x01*\"\r/v1/void_hold\x12\xdd\x01\n" +
+	"\n" +
+	"EnrollTOTP\x12\x18.pb.v1.EnrollTOTPRequest\x1a\x19.pb.v1.EnrollTOTPResponse\"\x99\x01\x92A|\x12\vEnroll TOTP\x1amUse this API to start enrolling a TOTP authenticator. The returned provisioning uri can be shown as a QR code\x82\xd3\xe4\x93\x02\x14:\x01*\"\x0f/v1/enroll_totp\x12\xea\x01\n" +
+	"\vConfirmTOTP\x12\x19.pb.v1.ConfirmTOTPRequest\x1a\x1a.pb.v1.ConfirmTOTPResponse\"\xa3\x01\x92A\x84\x01\x12\fConfirm TOTP\x1atUse this API to confirm TOTP enrolment with a code from the authenticator. The recovery codes are only returned once\x82\xd3\xe4\x93\x02\x15:\x01*\"\x10/v1/confirm_totp\x12\xc3\x01\n" +
+	"\vDisableTOTP\x12\x19.pb.v1.DisableTOTPRequest\x1a\x1a.pb.v1.DisableTOTPResponse\"}\x92A_\x12\fDisable TOTP\x1aOUse this API to turn off two-factor authentication with a TOTP or recovery code\x82\xd3\xe4\x93\x02\x15:\x01*\"\x10/v1/disable_totp\x12\x8b\x02\n" +
+	"\x10RenewAccessToken\x12\x1e.pb.v1.RenewAccessTokenRequest\x1a\x1f.pb.v1.RenewAccessTokenResponse\"\xb5\x01\x92A\x90\x01\x12\x12Renew access token\x1azUse this API to get a new access token. The refresh token is rotated on every use and must be replaced by the returned one\x82\xd3\xe4\x93\x02\x1b:\x01*\"\x16/v1/renew_access_token\x12\xb7\x01\n" +
+	"\fListSessions\x12\x1a.pb.v1.ListSessionsRequest\x1a\x1b.pb.v1.ListSessionsResponse\"n\x92AO\x12\rList sessions\x1a>Use this API to list the active sessions of the logged in user\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/v1/list_sessions\x12\x8c\x02\n" +
+	"\rRevokeSession\x12\x1b.pb.v1.RevokeSessionRequest\x1a\x1c.pb.v1.RevokeSessionResponse\"\xbf\x01\x92A\x9e\x01\x12\x0eRevoke session\x1a\x8b\x01Use this API to revoke one of your sessions, e.g. a lost or stolen device. Access tokens already issued for it stay valid until they expire\x82\xd3\xe4\x93\x02\x17:\x01*\"\x12/v1/revoke_session\x12\x96\x01\n" +
+	"\x06Logout\x12\x14.pb.v1.LogoutRequest\x1a\x15.pb.v1.LogoutResponse\"_\x92AG\x12\x06Logout\x1a=Use this API to revoke the session of the given refresh token\x82\xd3\xe4\x93\x02\x0f:\x01*\"\n" +
+	"/v1/logout\x12\xa5\x01\n" +
+	"\tLogoutAll\x12\x17.pb.v1.LogoutAllRequest\x1a\x18.pb.v1.LogoutAllResponse\"e\x92AI\x12\n" +
+	"Logout all\x1a;Use this API to revoke all of your sessions on every device\x82\xd3\xe4\x93\x02\x13:\x01*\"\x0e/v1/logout_all\x12\x80\x02\n" +
+	"\fCreateAPIKey\x12\x1a.pb.v1.CreateAPIKeyRequest\x1a\x1b.pb.v1.CreateAPIKeyResponse\"\xb6\x01\x92A\x95\x01\x12\x0eCreate API key\x1a\x82\x01Use this API to create a named API key for server-to-server clients. The key is limited to the given scopes and is only shown once\x82\xd3\xe4\x93\x02\x17:\x01*\"\x12/v1/create_api_key\x12\xad\x01\n" +
+	"\vListAPIKeys\x12\x19.pb.v1.ListAPIKeysRequest\x1a\x1a.pb.v1.ListAPIKeysResponse\"g\x92AH\x12\rList API keys\x1a7Use this API to list your API keys that are not revoked\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/v1/list_api_keys\x12\xa6\x01\n" +
+	"\fRevokeAPIKey\x12\x1a.pb.v1.RevokeAPIKeyRequest\x1a\x1b.pb.v1.RevokeAPIKeyResponse\"]\x92A=\x12\x0eRevoke API key\x1a+Use this API to revoke one of your API keys\x82\xd3\xe4\x93\x02\x17:\x01*\"\x12/v1/revoke_api_key\x12\xaf\x02\n" +
+	"\x11CreateOAuthClient\x12\x1f.pb.v1.CreateOAuthClientRequest\x1a .pb.v1.CreateOAuthClientResponse\"\xd6\x01\x92A\xb0\x01\x12\x14Create OAuth2 client\x1a\x97\x01Use this API to register a third-party application that can ask users for access to their accounts through OAuth2. The client secret is only shown once\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/v1/create_oauth_client\x12\xfd\x01\n" +
+	"\x0fListAuditEvents\x12\x1d.pb.v1.ListAuditEventsRequest\x1a\x1e.pb.v1.ListAuditEventsResponse\"\xaa\x01\x92A\x86\x01\x12\x11List audit events\x1aqUse this API to query the audit log of security and money movement events, newest first. Only bankers can call it\x82\xd3\xe4\x93\x02\x1a:\x01*\"\x15/v1/list_audit_events\x12\xa9\x02\n" +
+	"\x15CreateWebhookEndpoint\x12#.pb.v1.CreateWebhookEndpointRequest\x1a$.pb.v1.CreateWebhookEndpointResponse\"\xc4\x01\x92A\x9a\x01\x12\x17Create webhook endpoint\x1a\x7fUse this API to register an endpoint receiving the events of your accounts and transfers. The signing secret is only shown once\x82\xd3\xe4\x93\x02 :\x01*\"\x1b/v1/create_webhook_endpoint\x12\xce\x01\n" +
+	"\x14ListWebhookEndpoints\x12\".pb.v1.ListWebhookEndpointsRequest\x1a#.pb.v1.ListWebhookEndpointsResponse\"m\x92AE\x12\x16List webhook endpoints\x1a+Use this API to list your webhook endpoints\x82\xd3\xe4\x93\x02\x1f:\x01*\"\x1a/v1/list_webhook_endpoints\x12\xe1\x01\n" +
+	"\x15DeleteWebhookEndpoint\x12#.pb.v1.DeleteWebhookEndpointRequest\x1a$.pb.v1.DeleteWebhookEndpointResponse\"}\x92AT\x12\x17Delete webhook endpoint\x1a9Use this API to stop sending events to a webhook endpoint\x82\xd3\xe4\x93\x02 :\x01*\"\x1b/v1/delete_webhook_endpoint\x12\x99\x02\n" +
+	"\x15ListWebhookDeliveries\x12#.pb.v1.ListWebhookDeliveriesRequest\x1a$.pb.v1.ListWebhookDeliveriesResponse\"\xb4\x01\x92A\x8a\x01\x12\x17List webhook deliveries\x1aoUse this API to list the deliveries of a webhook endpoint, newest first, with the outcome of their last attempt\x82\xd3\xe4\x93\x02 :\x01*\"\x1b/v1/list_webhook_deliveries\x12\xeb\x01\n" +
+	"\x15ReplayWebhookDelivery\x12#.pb.v1.ReplayWebhookDeliveryRequest\x1a$.pb.v1.ReplayWebhookDeliveryResponse\"\x86\x01\x92A]\x12\x17Replay webhook delivery\x1aBUse this API to send the event of a delivery to its endpoint again\x82\xd3\xe4\x93\x02 :\x01*\"\x1b/v1/replay_webhook_deliveryB\xb2\x01\x92A\x8c\x01\x12\x89\x01\n" +
 	"\x0fSimple Bank API\")\n" +
 	"\fQuang M. Bui\x1a\x19minhquangbui053@gmail.com*F\n" +
 	"\vMIT License\x127https://github.com/yelaco/simple-bank/blob/main/LICENSE2\x031.2Z github.com/yelaco/simple-bank/pbb\x06proto3"
 
 var file_pb_v1_service_simple_bank_proto_goTypes = []any{
-	(*CreateUserRequest)(nil),   // 0: pb.v1.CreateUserRequest
-	(*UpdateUserRequest)(nil),   // 1: pb.v1.UpdateUserRequest
-	(*LoginUserRequest)(nil),    // 2: pb.v1.LoginUserRequest
-	(*VerifyEmailRequest)(nil),  // 3: pb.v1.VerifyEmailRequest
-	(*CreateUserResponse)(nil),  // 4: pb.v1.CreateUserResponse
-	(*UpdateUserResponse)(nil),  // 5: pb.v1.UpdateUserResponse
-	(*LoginUserResponse)(nil),   // 6: pb.v1.LoginUserResponse
-	(*VerifyEmailResponse)(nil), // 7: pb.v1.VerifyEmailResponse
+	(*CreateUserRequest)(nil),               // 0: pb.v1.CreateUserRequest
+	(*UpdateUserRequest)(nil),               // 1: pb.v1.UpdateUserRequest
+	(*UnlockUserRequest)(nil),               // 2: pb.v1.UnlockUserRequest
+	(*AssignRoleRequest)(nil),               // 3: pb.v1.AssignRoleRequest
+	(*LoginUserRequest)(nil),                // 4: pb.v1.LoginUserRequest
+	(*VerifyLoginMFARequest)(nil),           // 5: pb.v1.VerifyLoginMFARequest
+	(*VerifyEmailRequest)(nil),              // 6: pb.v1.VerifyEmailRequest
+	(*RequestPasswordResetRequest)(nil),     // 7: pb.v1.RequestPasswordResetRequest
+	(*ResetPasswordRequest)(nil),            // 8: pb.v1.ResetPasswordRequest
+	(*CreateAccountRequest)(nil),            // 9: pb.v1.CreateAccountRequest
+	(*GetAccountRequest)(nil),               // 10: pb.v1.GetAccountRequest
+	(*ListAccountsRequest)(nil),             // 11: pb.v1.ListAccountsRequest
+	(*CloseAccountRequest)(nil),             // 12: pb.v1.CloseAccountRequest
+	(*CreateTransferRequest)(nil),           // 13: pb.v1.CreateTransferRequest
+	(*UpdateOverdraftLimitRequest)(nil),     // 14: pb.v1.UpdateOverdraftLimitRequest
+	(*CreateScheduledTransferRequest)(nil),  // 15: pb.v1.CreateScheduledTransferRequest
+	(*CancelScheduledTransferRequest)(nil),  // 16: pb.v1.CancelScheduledTransferRequest
+	(*ReverseTransferRequest)(nil),          // 17: pb.v1.ReverseTransferRequest
+	(*SendStatementRequest)(nil),            // 18: pb.v1.SendStatementRequest
+	(*CreateHoldRequest)(nil),               // 19: pb.v1.CreateHoldRequest
+	(*CaptureHoldRequest)(nil),              // 20: pb.v1.CaptureHoldRequest
+	(*VoidHoldRequest)(nil),                 // 21: pb.v1.VoidHoldRequest
+	(*EnrollTOTPRequest)(nil),               // 22: pb.v1.EnrollTOTPRequest
+	(*ConfirmTOTPRequest)(nil),              // 23: pb.v1.ConfirmTOTPRequest
+	(*DisableTOTPRequest)(nil),              // 24: pb.v1.DisableTOTPRequest
+	(*RenewAccessTokenRequest)(nil),         // 25: pb.v1.RenewAccessTokenRequest
+	(*ListSessionsRequest)(nil),             // 26: pb.v1.ListSessionsRequest
+	(*RevokeSessionRequest)(nil),            // 27: pb.v1.RevokeSessionRequest
+	(*LogoutRequest)(nil),                   // 28: pb.v1.LogoutRequest
+	(*LogoutAllRequest)(nil),                // 29: pb.v1.LogoutAllRequest
+	(*CreateAPIKeyRequest)(nil),             // 30: pb.v1.CreateAPIKeyRequest
+	(*ListAPIKeysRequest)(nil),              // 31: pb.v1.ListAPIKeysRequest
+	(*RevokeAPIKeyRequest)(nil),             // 32: pb.v1.RevokeAPIKeyRequest
+	(*CreateOAuthClientRequest)(nil),        // 33: pb.v1.CreateOAuthClientRequest
+	(*ListAuditEventsRequest)(nil),          // 34: pb.v1.ListAuditEventsRequest
+	(*CreateWebhookEndpointRequest)(nil),    // 35: pb.v1.CreateWebhookEndpointRequest
+	(*ListWebhookEndpointsRequest)(nil),     // 36: pb.v1.ListWebhookEndpointsRequest
+	(*DeleteWebhookEndpointRequest)(nil),    // 37: pb.v1.DeleteWebhookEndpointRequest
+	(*ListWebhookDeliveriesRequest)(nil),    // 38: pb.v1.ListWebhookDeliveriesRequest
+	(*ReplayWebhookDeliveryRequest)(nil),    // 39: pb.v1.ReplayWebhookDeliveryRequest
+	(*CreateUserResponse)(nil),              // 40: pb.v1.CreateUserResponse
+	(*UpdateUserResponse)(nil),              // 41: pb.v1.UpdateUserResponse
+	(*UnlockUserResponse)(nil),              // 42: pb.v1.UnlockUserResponse
+	(*AssignRoleResponse)(nil),              // 43: pb.v1.AssignRoleResponse
+	(*LoginUserResponse)(nil),               // 44: pb.v1.LoginUserResponse
+	(*VerifyLoginMFAResponse)(nil),          // 45: pb.v1.VerifyLoginMFAResponse
+	(*VerifyEmailResponse)(nil),             // 46: pb.v1.VerifyEmailResponse
+	(*RequestPasswordResetResponse)(nil),    // 47: pb.v1.RequestPasswordResetResponse
+	(*ResetPasswordResponse)(nil),           // 48: pb.v1.ResetPasswordResponse
+	(*CreateAccountResponse)(nil),           // 49: pb.v1.CreateAccountResponse
+	(*GetAccountResponse)(nil),              // 50: pb.v1.GetAccountResponse
+	(*ListAccountsResponse)(nil),            // 51: pb.v1.ListAccountsResponse
+	(*CloseAccountResponse)(nil),            // 52: pb.v1.CloseAccountResponse
+	(*CreateTransferResponse)(nil),          // 53: pb.v1.CreateTransferResponse
+	(*UpdateOverdraftLimitResponse)(nil),    // 54: pb.v1.UpdateOverdraftLimitResponse
+	(*CreateScheduledTransferResponse)(nil), // 55: pb.v1.CreateScheduledTransferResponse
+	(*CancelScheduledTransferResponse)(nil), // 56: pb.v1.CancelScheduledTransferResponse
+	(*ReverseTransferResponse)(nil),         // 57: pb.v1.ReverseTransferResponse
+	(*SendStatementResponse)(nil),           // 58: pb.v1.SendStatementResponse
+	(*CreateHoldResponse)(nil),              // 59: pb.v1.CreateHoldResponse
+	(*CaptureHoldResponse)(nil),             // 60: pb.v1.CaptureHoldResponse
+	(*VoidHoldResponse)(nil),                // 61: pb.v1.VoidHoldResponse
+	(*EnrollTOTPResponse)(nil),              // 62: pb.v1.EnrollTOTPResponse
+	(*ConfirmTOTPResponse)(nil),             // 63: pb.v1.ConfirmTOTPResponse
+	(*DisableTOTPResponse)(nil),             // 64: pb.v1.DisableTOTPResponse
+	(*RenewAccessTokenResponse)(nil),        // 65: pb.v1.RenewAccessTokenResponse
+	(*ListSessionsResponse)(nil),            // 66: pb.v1.ListSessionsResponse
+	(*RevokeSessionResponse)(nil),           // 67: pb.v1.RevokeSessionResponse
+	(*LogoutResponse)(nil),                  // 68: pb.v1.LogoutResponse
+	(*LogoutAllResponse)(nil),               // 69: pb.v1.LogoutAllResponse
+	(*CreateAPIKeyResponse)(nil),            // 70: pb.v1.CreateAPIKeyResponse
+	(*ListAPIKeysResponse)(nil),             // 71: pb.v1.ListAPIKeysResponse
+	(*RevokeAPIKeyResponse)(nil),            // 72: pb.v1.RevokeAPIKeyResponse
+	(*CreateOAuthClientResponse)(nil),       // 73: pb.v1.CreateOAuthClientResponse
+	(*ListAuditEventsResponse)(nil),         // 74: pb.v1.ListAuditEventsResponse
+	(*CreateWebhookEndpointResponse)(nil),   // 75: pb.v1.CreateWebhookEndpointResponse
+	(*ListWebhookEndpointsResponse)(nil),    // 76: pb.v1.ListWebhookEndpointsResponse
+	(*DeleteWebhookEndpointResponse)(nil),   // 77: pb.v1.DeleteWebhookEndpointResponse
+	(*ListWebhookDeliveriesResponse)(nil),   // 78: pb.v1.ListWebhookDeliveriesResponse
+	(*ReplayWebhookDeliveryResponse)(nil),   // 79: pb.v1.ReplayWebhookDeliveryResponse
 }
 var file_pb_v1_service_simple_bank_proto_depIdxs = []int32{
-	0, // 0: pb.v1.SimpleBank.CreateUser:input_type -> pb.v1.CreateUserRequest
-	1, // 1: pb.v1.SimpleBank.UpdateUser:input_type -> pb.v1.UpdateUserRequest
-	2, // 2: pb.v1.SimpleBank.LoginUser:input_type -> pb.v1.LoginUserRequest
-	3, // 3: pb.v1.SimpleBank.VerifyEmail:input_type -> pb.v1.VerifyEmailRequest
-	4, // 4: pb.v1.SimpleBank.CreateUser:output_type -> pb.v1.CreateUserResponse
-	5, // 5: pb.v1.SimpleBank.UpdateUser:output_type -> pb.v1.UpdateUserResponse
-	6, // 6: pb.v1.SimpleBank.LoginUser:output_type -> pb.v1.LoginUserResponse
-	7, // 7: pb.v1.SimpleBank.VerifyEmail:output_type -> pb.v1.VerifyEmailResponse
-	4, // [4:8] is the sub-list for method output_type
-	0, // [0:4] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	0,  // 0: pb.v1.SimpleBank.CreateUser:input_type -> pb.v1.CreateUserRequest
+	1,  // 1: pb.v1.SimpleBank.UpdateUser:input_type -> pb.v1.UpdateUserRequest
+	2,  // 2: pb.v1.SimpleBank.UnlockUser:input_type -> pb.v1.UnlockUserRequest
+	3,  // 3: pb.v1.SimpleBank.AssignRole:input_type -> pb.v1.AssignRoleRequest
+	4,  // 4: pb.v1.SimpleBank.LoginUser:input_type -> pb.v1.LoginUserRequest
+	5,  // 5: pb.v1.SimpleBank.VerifyLoginMFA:input_type -> pb.v1.VerifyLoginMFARequest
+	6,  // 6: pb.v1.SimpleBank.VerifyEmail:input_type -> pb.v1.VerifyEmailRequest
+	7,  // 7: pb.v1.SimpleBank.RequestPasswordReset:input_type -> pb.v1.RequestPasswordResetRequest
+	8,  // 8: pb.v1.SimpleBank.ResetPassword:input_type -> pb.v1.ResetPasswordRequest
+	9,  // 9: pb.v1.SimpleBank.CreateAccount:input_type -> pb.v1.CreateAccountRequest
+	10, // 10: pb.v1.SimpleBank.GetAccount:input_type -> pb.v1.GetAccountRequest
+	11, // 11: pb.v1.SimpleBank.ListAccounts:input_type -> pb.v1.ListAccountsRequest
+	12, // 12: pb.v1.SimpleBank.CloseAccount:input_type -> pb.v1.CloseAccountRequest
+	13, // 13: pb.v1.SimpleBank.CreateTransfer:input_type -> pb.v1.CreateTransferRequest
+	14, // 14: pb.v1.SimpleBank.UpdateOverdraftLimit:input_type -> pb.v1.UpdateOverdraftLimitRequest
+	15, // 15: pb.v1.SimpleBank.CreateScheduledTransfer:input_type -> pb.v1.CreateScheduledTransferRequest
+	16, // 16: pb.v1.SimpleBank.CancelScheduledTransfer:input_type -> pb.v1.CancelScheduledTransferRequest
+	17, // 17: pb.v1.SimpleBank.ReverseTransfer:input_type -> pb.v1.ReverseTransferRequest
+	18, // 18: pb.v1.SimpleBank.SendStatement:input_type -> pb.v1.SendStatementRequest
+	19, // 19: pb.v1.SimpleBank.CreateHold:input_type -> pb.v1.CreateHoldRequest
+	20, // 20: pb.v1.SimpleBank.CaptureHold:input_type -> pb.v1.CaptureHoldRequest
+	21, // 21: pb.v1.SimpleBank.VoidHold:input_type -> pb.v1.VoidHoldRequest
+	22, // 22: pb.v1.SimpleBank.EnrollTOTP:input_type -> pb.v1.EnrollTOTPRequest
+	23, // 23: pb.v1.SimpleBank.ConfirmTOTP:input_type -> pb.v1.ConfirmTOTPRequest
+	24, // 24: pb.v1.SimpleBank.DisableTOTP:input_type -> pb.v1.DisableTOTPRequest
+	25, // 25: pb.v1.SimpleBank.RenewAccessToken:input_type -> pb.v1.RenewAccessTokenRequest
+	26, // 26: pb.v1.SimpleBank.ListSessions:input_type -> pb.v1.ListSessionsRequest
+	27, // 27: pb.v1.SimpleBank.RevokeSession:input_type -> pb.v1.RevokeSessionRequest
+	28, // 28: pb.v1.SimpleBank.Logout:input_type -> pb.v1.LogoutRequest
+	29, // 29: pb.v1.SimpleBank.LogoutAll:input_type -> pb.v1.LogoutAllRequest
+	30, // 30: pb.v1.SimpleBank.CreateAPIKey:input_type -> pb.v1.CreateAPIKeyRequest
+	31, // 31: pb.v1.SimpleBank.ListAPIKeys:input_type -> pb.v1.ListAPIKeysRequest
+	32, // 32: pb.v1.SimpleBank.RevokeAPIKey:input_type -> pb.v1.RevokeAPIKeyRequest
+	33, // 33: pb.v1.SimpleBank.CreateOAuthClient:input_type -> pb.v1.CreateOAuthClientRequest
+	34, // 34: pb.v1.SimpleBank.ListAuditEvents:input_type -> pb.v1.ListAuditEventsRequest
+	35, // 35: pb.v1.SimpleBank.CreateWebhookEndpoint:input_type -> pb.v1.CreateWebhookEndpointRequest
+	36, // 36: pb.v1.SimpleBank.ListWebhookEndpoints:input_type -> pb.v1.ListWebhookEndpointsRequest
+	37, // 37: pb.v1.SimpleBank.DeleteWebhookEndpoint:input_type -> pb.v1.DeleteWebhookEndpointRequest
+	38, // 38: pb.v1.SimpleBank.ListWebhookDeliveries:input_type -> pb.v1.ListWebhookDeliveriesRequest
+	39, // 39: pb.v1.SimpleBank.ReplayWebhookDelivery:input_type -> pb.v1.ReplayWebhookDeliveryRequest
+	40, // 40: pb.v1.SimpleBank.CreateUser:output_type -> pb.v1.CreateUserResponse
+	41, // 41: pb.v1.SimpleBank.UpdateUser:output_type -> pb.v1.UpdateUserResponse
+	42, // 42: pb.v1.SimpleBank.UnlockUser:output_type -> pb.v1.UnlockUserResponse
+	43, // 43: pb.v1.SimpleBank.AssignRole:output_type -> pb.v1.AssignRoleResponse
+	44, // 44: pb.v1.SimpleBank.LoginUser:output_type -> pb.v1.LoginUserResponse
+	45, // 45: pb.v1.SimpleBank.VerifyLoginMFA:output_type -> pb.v1.VerifyLoginMFAResponse
+	46, // 46: pb.v1.SimpleBank.VerifyEmail:output_type -> pb.v1.VerifyEmailResponse
+	47, // 47: pb.v1.SimpleBank.RequestPasswordReset:output_type -> pb.v1.RequestPasswordResetResponse
+	48, // 48: pb.v1.SimpleBank.ResetPassword:output_type -> pb.v1.ResetPasswordResponse
+	49, // 49: pb.v1.SimpleBank.CreateAccount:output_type -> pb.v1.CreateAccountResponse
+	50, // 50: pb.v1.SimpleBank.GetAccount:output_type -> pb.v1.GetAccountResponse
+	51, // 51: pb.v1.SimpleBank.ListAccounts:output_type -> pb.v1.ListAccountsResponse
+	52, // 52: pb.v1.SimpleBank.CloseAccount:output_type -> pb.v1.CloseAccountResponse
+	53, // 53: pb.v1.SimpleBank.CreateTransfer:output_type -> pb.v1.CreateTransferResponse
+	54, // 54: pb.v1.SimpleBank.UpdateOverdraftLimit:output_type -> pb.v1.UpdateOverdraftLimitResponse
+	55, // 55: pb.v1.SimpleBank.CreateScheduledTransfer:output_type -> pb.v1.CreateScheduledTransferResponse
+	56, // 56: pb.v1.SimpleBank.CancelScheduledTransfer:output_type -> pb.v1.CancelScheduledTransferResponse
+	57, // 57: pb.v1.SimpleBank.ReverseTransfer:output_type -> pb.v1.ReverseTransferResponse
+	58, // 58: pb.v1.SimpleBank.SendStatement:output_type -> pb.v1.SendStatementResponse
+	59, // 59: pb.v1.SimpleBank.CreateHold:output_type -> pb.v1.CreateHoldResponse
+	60, // 60: pb.v1.SimpleBank.CaptureHold:output_type -> pb.v1.CaptureHoldResponse
+	61, // 61: pb.v1.SimpleBank.VoidHold:output_type -> pb.v1.VoidHoldResponse
+	62, // 62: pb.v1.SimpleBank.EnrollTOTP:output_type -> pb.v1.EnrollTOTPResponse
+	63, // 63: pb.v1.SimpleBank.ConfirmTOTP:output_type -> pb.v1.ConfirmTOTPResponse
+	64, // 64: pb.v1.SimpleBank.DisableTOTP:output_type -> pb.v1.DisableTOTPResponse
+	65, // 65: pb.v1.SimpleBank.RenewAccessToken:output_type -> pb.v1.RenewAccessTokenResponse
+	66, // 66: pb.v1.SimpleBank.ListSessions:output_type -> pb.v1.ListSessionsResponse
+	67, // 67: pb.v1.SimpleBank.RevokeSession:output_type -> pb.v1.RevokeSessionResponse
+	68, // 68: pb.v1.SimpleBank.Logout:output_type -> pb.v1.LogoutResponse
+	69, // 69: pb.v1.SimpleBank.LogoutAll:output_type -> pb.v1.LogoutAllResponse
+	70, // 70: pb.v1.SimpleBank.CreateAPIKey:output_type -> pb.v1.CreateAPIKeyResponse
+	71, // 71: pb.v1.SimpleBank.ListAPIKeys:output_type -> pb.v1.ListAPIKeysResponse
+	72, // 72: pb.v1.SimpleBank.RevokeAPIKey:output_type -> pb.v1.RevokeAPIKeyResponse
+	73, // 73: pb.v1.SimpleBank.CreateOAuthClient:output_type -> pb.v1.CreateOAuthClientResponse
+	74, // 74: pb.v1.SimpleBank.ListAuditEvents:output_type -> pb.v1.ListAuditEventsResponse
+	75, // 75: pb.v1.SimpleBank.CreateWebhookEndpoint:output_type -> pb.v1.CreateWebhookEndpointResponse
+	76, // 76: pb.v1.SimpleBank.ListWebhookEndpoints:output_type -> pb.v1.ListWebhookEndpointsResponse
+	77, // 77: pb.v1.SimpleBank.DeleteWebhookEndpoint:output_type -> pb.v1.DeleteWebhookEndpointResponse
+	78, // 78: pb.v1.SimpleBank.ListWebhookDeliveries:output_type -> pb.v1.ListWebhookDeliveriesResponse
+	79, // 79: pb.v1.SimpleBank.ReplayWebhookDelivery:output_type -> pb.v1.ReplayWebhookDeliveryResponse
+	40, // [40:80] is the sub-list for method output_type
+	0,  // [0:40] is the sub-list for method input_type
+	0,  // [0:0] is the sub-list for extension type_name
+	0,  // [0:0] is the sub-list for extension extendee
+	0,  // [0:0] is the sub-list for field type_name
 }
 
 func init() { file_pb_v1_service_simple_bank_proto_init() }
@@ -71,10 +258,46 @@ func file_pb_v1_service_simple_bank_proto_init() {
 	if File_pb_v1_service_simple_bank_proto != nil {
 		return
 	}
+	file_pb_v1_rpc_assign_role_proto_init()
+	file_pb_v1_rpc_cancel_scheduled_transfer_proto_init()
+	file_pb_v1_rpc_capture_hold_proto_init()
+	file_pb_v1_rpc_close_account_proto_init()
+	file_pb_v1_rpc_confirm_totp_proto_init()
+	file_pb_v1_rpc_create_account_proto_init()
+	file_pb_v1_rpc_create_api_key_proto_init()
+	file_pb_v1_rpc_create_hold_proto_init()
+	file_pb_v1_rpc_create_oauth_client_proto_init()
+	file_pb_v1_rpc_create_scheduled_transfer_proto_init()
+	file_pb_v1_rpc_create_transfer_proto_init()
 	file_pb_v1_rpc_create_user_proto_init()
+	file_pb_v1_rpc_create_webhook_endpoint_proto_init()
+	file_pb_v1_rpc_delete_webhook_endpoint_proto_init()
+	file_pb_v1_rpc_disable_totp_proto_init()
+	file_pb_v1_rpc_enroll_totp_proto_init()
+	file_pb_v1_rpc_get_account_proto_init()
+	file_pb_v1_rpc_list_accounts_proto_init()
+	file_pb_v1_rpc_list_api_keys_proto_init()
+	file_pb_v1_rpc_list_audit_events_proto_init()
+	file_pb_v1_rpc_list_sessions_proto_init()
+	file_pb_v1_rpc_list_webhook_deliveries_proto_init()
+	file_pb_v1_rpc_list_webhook_endpoints_proto_init()
 	file_pb_v1_rpc_login_user_proto_init()
+	file_pb_v1_rpc_logout_proto_init()
+	file_pb_v1_rpc_logout_all_proto_init()
+	file_pb_v1_rpc_renew_access_token_proto_init()
+	file_pb_v1_rpc_replay_webhook_delivery_proto_init()
+	file_pb_v1_rpc_request_password_reset_proto_init()
+	file_pb_v1_rpc_reset_password_proto_init()
+	file_pb_v1_rpc_reverse_transfer_proto_init()
+	file_pb_v1_rpc_revoke_api_key_proto_init()
+	file_pb_v1_rpc_revoke_session_proto_init()
+	file_pb_v1_rpc_send_statement_proto_init()
+	file_pb_v1_rpc_unlock_user_proto_init()
+	file_pb_v1_rpc_update_overdraft_limit_proto_init()
 	file_pb_v1_rpc_update_user_proto_init()
 	file_pb_v1_rpc_verify_email_proto_init()
+	file_pb_v1_rpc_verify_login_mfa_proto_init()
+	file_pb_v1_rpc_void_hold_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
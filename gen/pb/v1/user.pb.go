@@ -29,6 +29,8 @@ type User struct {
 	Email             string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
 	PasswordChangedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=passwordChangedAt,proto3" json:"passwordChangedAt,omitempty"`
 	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	IsTotpEnabled     bool                   `protobuf:"varint,6,opt,name=isTotpEnabled,proto3" json:"isTotpEnabled,omitempty"`
+	LockedUntil       *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=lockedUntil,proto3,oneof" json:"lockedUntil,omitempty"`
 	unknownFields     protoimpl.UnknownFields
 	sizeCache         protoimpl.SizeCache
 }
@@ -98,17 +100,34 @@ func (x *User) GetCreatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *User) GetIsTotpEnabled() bool {
+	if x != nil {
+		return x.IsTotpEnabled
+	}
+	return false
+}
+
+func (x *User) GetLockedUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LockedUntil
+	}
+	return nil
+}
+
 var File_pb_v1_user_proto protoreflect.FileDescriptor
 
 const file_pb_v1_user_proto_rawDesc = "" +
 	"\n" +
-	"\x10pb/v1/user.proto\x12\x05pb.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xd8\x01\n" +
+	"\x10pb/v1/user.proto\x12\x05pb.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xd1\x02\n" +
 	"\x04User\x12\x1a\n" +
 	"\busername\x18\x01 \x01(\tR\busername\x12\x1a\n" +
 	"\bfullName\x18\x02 \x01(\tR\bfullName\x12\x14\n" +
 	"\x05email\x18\x03 \x01(\tR\x05email\x12H\n" +
 	"\x11passwordChangedAt\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x11passwordChangedAt\x128\n" +
-	"\tcreatedAt\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAtB\"Z github.com/yelaco/simple-bank/pbb\x06proto3"
+	"\tcreatedAt\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12$\n" +
+	"\risTotpEnabled\x18\x06 \x01(\bR\risTotpEnabled\x12A\n" +
+	"\vlockedUntil\x18\a \x01(\v2\x1a.google.protobuf.TimestampH\x00R\vlockedUntil\x88\x01\x01B\x0e\n" +
+	"\f_lockedUntilB\"Z github.com/yelaco/simple-bank/pbb\x06proto3"
 
 var (
 	file_pb_v1_user_proto_rawDescOnce sync.Once
@@ -130,11 +149,12 @@ var file_pb_v1_user_proto_goTypes = []any{
 var file_pb_v1_user_proto_depIdxs = []int32{
 	1, // 0: pb.v1.User.passwordChangedAt:type_name -> google.protobuf.Timestamp
 	1, // 1: pb.v1.User.createdAt:type_name -> google.protobuf.Timestamp
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	1, // 2: pb.v1.User.lockedUntil:type_name -> google.protobuf.Timestamp
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_pb_v1_user_proto_init() }
@@ -142,6 +162,7 @@ func file_pb_v1_user_proto_init() {
 	if File_pb_v1_user_proto != nil {
 		return
 	}
+	file_pb_v1_user_proto_msgTypes[0].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
@@ -0,0 +1,39 @@
+package health
+
+import (
+	"context"
+	"time"
+
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// UpdateGRPCHealth keeps the serving status of the gRPC health service in sync
+// with the readiness checks, for the overall server and the given services.
+// Everything is reported as not serving once the graceful shutdown starts.
+// It returns when ctx is done or the checker is shut down.
+func (checker *Checker) UpdateGRPCHealth(ctx context.Context, server *grpchealth.Server, interval time.Duration, services ...string) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		servingStatus := healthpb.HealthCheckResponse_SERVING
+		if !checker.Ready(ctx).Ready() {
+			servingStatus = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+
+		server.SetServingStatus("", servingStatus)
+		for _, service := range services {
+			server.SetServingStatus(service, servingStatus)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-checker.ShuttingDown():
+			server.Shutdown()
+			return
+		case <-ticker.C:
+		}
+	}
+}
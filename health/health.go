@@ -0,0 +1,154 @@
+// Package health reports whether the application is alive and ready to serve,
+// on the HTTP gateway for Kubernetes probes and through the standard gRPC
+// health service.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Endpoints of the liveness and readiness probes, served by the HTTP gateway
+const (
+	LivenessPath  = "/healthz"
+	ReadinessPath = "/readyz"
+)
+
+// Statuses of the probe responses and of the individual checks
+const (
+	StatusOK           = "ok"
+	StatusFailing      = "failing"
+	StatusShuttingDown = "shutting_down"
+)
+
+// Check reports whether a dependency of the application is usable
+type Check func(ctx context.Context) error
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// Report is the result of the readiness checks
+type Report struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+// Ready tells whether the application can serve traffic
+func (report Report) Ready() bool {
+	return report.Status == StatusOK
+}
+
+// Checker runs the readiness checks of the application. It reports not ready
+// once the graceful shutdown has started, so that no new traffic is routed to
+// the instance while it drains.
+type Checker struct {
+	timeout      time.Duration
+	checks       []namedCheck
+	shutdownOnce sync.Once
+	shutdown     chan struct{}
+}
+
+// NewChecker creates a checker whose checks fail when they take longer than
+// the timeout
+func NewChecker(timeout time.Duration) *Checker {
+	return &Checker{
+		timeout:  timeout,
+		shutdown: make(chan struct{}),
+	}
+}
+
+// AddCheck registers a readiness check. Checks must be added before the
+// checker is used.
+func (checker *Checker) AddCheck(name string, check Check) {
+	checker.checks = append(checker.checks, namedCheck{name: name, check: check})
+}
+
+// Shutdown marks the application as not ready for the rest of its lifetime
+func (checker *Checker) Shutdown() {
+	checker.shutdownOnce.Do(func() {
+		close(checker.shutdown)
+	})
+}
+
+// ShuttingDown is closed when the graceful shutdown starts
+func (checker *Checker) ShuttingDown() <-chan struct{} {
+	return checker.shutdown
+}
+
+// Ready runs all checks concurrently and reports which of them failed
+func (checker *Checker) Ready(ctx context.Context) Report {
+	select {
+	case <-checker.shutdown:
+		return Report{Status: StatusShuttingDown}
+	default:
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, checker.timeout)
+	defer cancel()
+
+	errs := make([]error, len(checker.checks))
+
+	var waitGroup sync.WaitGroup
+	for i, check := range checker.checks {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			errs[i] = check.check(ctx)
+		}()
+	}
+	waitGroup.Wait()
+
+	report := Report{
+		Status: StatusOK,
+		Checks: make(map[string]string, len(checker.checks)),
+	}
+	for i, check := range checker.checks {
+		if errs[i] != nil {
+			// The errors are only logged, as they may reveal internal addresses
+			log.Warn().Err(errs[i]).Str("check", check.name).Msg("readiness check failed")
+			report.Status = StatusFailing
+			report.Checks[check.name] = StatusFailing
+			continue
+		}
+		report.Checks[check.name] = StatusOK
+	}
+
+	return report
+}
+
+// RegisterHandlers serves the liveness and readiness probes. The liveness probe
+// succeeds as long as the gateway responds, the readiness probe only when every
+// check passes and the application is not shutting down.
+func (checker *Checker) RegisterHandlers(mux *http.ServeMux) {
+	mux.HandleFunc("GET "+LivenessPath, checker.handleLiveness)
+	mux.HandleFunc("GET "+ReadinessPath, checker.handleReadiness)
+}
+
+func (checker *Checker) handleLiveness(res http.ResponseWriter, req *http.Request) {
+	writeReport(res, http.StatusOK, Report{Status: StatusOK})
+}
+
+func (checker *Checker) handleReadiness(res http.ResponseWriter, req *http.Request) {
+	report := checker.Ready(req.Context())
+
+	statusCode := http.StatusOK
+	if !report.Ready() {
+		statusCode = http.StatusServiceUnavailable
+	}
+
+	writeReport(res, statusCode, report)
+}
+
+func writeReport(res http.ResponseWriter, statusCode int, report Report) {
+	res.Header().Set("Content-Type", "application/json")
+	res.Header().Set("Cache-Control", "no-store")
+	res.WriteHeader(statusCode)
+	_ = json.NewEncoder(res).Encode(report)
+}
@@ -0,0 +1,171 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/stretchr/testify/require"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func passingCheck(ctx context.Context) error {
+	return nil
+}
+
+func failingCheck(ctx context.Context) error {
+	return errors.New("connection refused")
+}
+
+func slowCheck(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestProbes(t *testing.T) {
+	testCases := []struct {
+		name          string
+		checks        map[string]Check
+		shutdown      bool
+		path          string
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder, report Report)
+	}{
+		{
+			name:   "Liveness",
+			checks: map[string]Check{"postgres": failingCheck},
+			path:   LivenessPath,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, report Report) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, StatusOK, report.Status)
+			},
+		},
+		{
+			name:   "Ready",
+			checks: map[string]Check{"postgres": passingCheck, "redis": passingCheck},
+			path:   ReadinessPath,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, report Report) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, StatusOK, report.Status)
+				require.Equal(t, map[string]string{"postgres": StatusOK, "redis": StatusOK}, report.Checks)
+			},
+		},
+		{
+			name:   "CheckFailing",
+			checks: map[string]Check{"postgres": passingCheck, "redis": failingCheck},
+			path:   ReadinessPath,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, report Report) {
+				require.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+				require.Equal(t, StatusFailing, report.Status)
+				require.Equal(t, map[string]string{"postgres": StatusOK, "redis": StatusFailing}, report.Checks)
+				require.NotContains(t, recorder.Body.String(), "connection refused")
+			},
+		},
+		{
+			name:   "CheckTimeout",
+			checks: map[string]Check{"postgres": slowCheck},
+			path:   ReadinessPath,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, report Report) {
+				require.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+				require.Equal(t, StatusFailing, report.Checks["postgres"])
+			},
+		},
+		{
+			name:     "ShuttingDown",
+			checks:   map[string]Check{"postgres": passingCheck},
+			shutdown: true,
+			path:     ReadinessPath,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, report Report) {
+				require.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+				require.Equal(t, StatusShuttingDown, report.Status)
+			},
+		},
+		{
+			name:     "LivenessWhileShuttingDown",
+			checks:   map[string]Check{"postgres": passingCheck},
+			shutdown: true,
+			path:     LivenessPath,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, report Report) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			checker := NewChecker(50 * time.Millisecond)
+			for name, check := range tc.checks {
+				checker.AddCheck(name, check)
+			}
+			if tc.shutdown {
+				checker.Shutdown()
+			}
+
+			mux := http.NewServeMux()
+			checker.RegisterHandlers(mux)
+
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodGet, tc.path, nil)
+			mux.ServeHTTP(recorder, request)
+
+			var report Report
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &report))
+			tc.checkResponse(t, recorder, report)
+		})
+	}
+}
+
+type fakeVersioner struct {
+	version uint
+	dirty   bool
+	err     error
+}
+
+func (versioner fakeVersioner) Version() (uint, bool, error) {
+	return versioner.version, versioner.dirty, versioner.err
+}
+
+func TestMigrationCheck(t *testing.T) {
+	latestVersion, err := LatestMigration("file://../db/migration")
+	require.NoError(t, err)
+	require.NotZero(t, latestVersion)
+
+	ctx := context.Background()
+
+	require.NoError(t, MigrationCheck(fakeVersioner{version: latestVersion}, latestVersion)(ctx))
+	require.Error(t, MigrationCheck(fakeVersioner{version: latestVersion - 1}, latestVersion)(ctx))
+	require.Error(t, MigrationCheck(fakeVersioner{version: latestVersion, dirty: true}, latestVersion)(ctx))
+	require.Error(t, MigrationCheck(fakeVersioner{err: errors.New("no migration")}, latestVersion)(ctx))
+}
+
+func TestUpdateGRPCHealth(t *testing.T) {
+	const service = "pb.SimpleBank"
+
+	checker := NewChecker(50 * time.Millisecond)
+	checker.AddCheck("postgres", passingCheck)
+
+	server := grpchealth.NewServer()
+	done := make(chan struct{})
+	go func() {
+		checker.UpdateGRPCHealth(context.Background(), server, time.Hour, service)
+		close(done)
+	}()
+
+	requireServingStatus := func(status healthpb.HealthCheckResponse_ServingStatus) {
+		require.Eventually(t, func() bool {
+			res, err := server.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+			return err == nil && res.Status == status
+		}, time.Second, 10*time.Millisecond)
+	}
+
+	requireServingStatus(healthpb.HealthCheckResponse_SERVING)
+
+	checker.Shutdown()
+	<-done
+	requireServingStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+}
@@ -0,0 +1,63 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/golang-migrate/migrate/v4/source"
+)
+
+// SchemaVersioner reports the current migration version of the database, as
+// *migrate.Migrate does
+type SchemaVersioner interface {
+	Version() (version uint, dirty bool, err error)
+}
+
+// LatestMigration returns the version of the last migration in the source.
+// The driver of the source URL must have been registered by the caller.
+func LatestMigration(migrationURL string) (uint, error) {
+	driver, err := source.Open(migrationURL)
+	if err != nil {
+		return 0, fmt.Errorf("cannot open migration source: %w", err)
+	}
+	defer driver.Close()
+
+	version, err := driver.First()
+	if err != nil {
+		return 0, fmt.Errorf("cannot read first migration: %w", err)
+	}
+
+	for {
+		next, err := driver.Next(version)
+		if errors.Is(err, os.ErrNotExist) {
+			return version, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("cannot read migration after %d: %w", version, err)
+		}
+		version = next
+	}
+}
+
+// MigrationCheck fails while the database is not migrated to the latest
+// version, or when the last migration did not complete
+func MigrationCheck(versioner SchemaVersioner, latestVersion uint) Check {
+	return func(ctx context.Context) error {
+		version, dirty, err := versioner.Version()
+		if err != nil {
+			return fmt.Errorf("cannot get migration version: %w", err)
+		}
+
+		if dirty {
+			return fmt.Errorf("migration %d is dirty", version)
+		}
+
+		if version < latestVersion {
+			return fmt.Errorf("migration %d is pending, database is at version %d", latestVersion, version)
+		}
+
+		return nil
+	}
+}
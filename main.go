@@ -10,16 +10,25 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
+	"github.com/exaring/otelpgx"
 	"github.com/go-chi/cors"
 	"github.com/hibiken/asynq"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc/filters"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/protobuf/encoding/protojson"
 
@@ -27,10 +36,15 @@ import (
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 	db "github.com/yelaco/simple-bank/db/sqlc"
+	"github.com/yelaco/simple-bank/fx"
 	"github.com/yelaco/simple-bank/gapi"
 	"github.com/yelaco/simple-bank/gen/pb/v1"
+	"github.com/yelaco/simple-bank/health"
 	"github.com/yelaco/simple-bank/mail"
+	"github.com/yelaco/simple-bank/metrics"
+	"github.com/yelaco/simple-bank/tracing"
 	"github.com/yelaco/simple-bank/util"
+	"github.com/yelaco/simple-bank/webhook"
 	"github.com/yelaco/simple-bank/worker"
 )
 
@@ -56,34 +70,74 @@ func main() {
 	ctx, stop := signal.NotifyContext(context.Background(), interruptSignals...)
 	defer stop()
 
-	runDBMigration(config.MigrationURL, config.DBSource)
+	shutdownTracing, err := tracing.Init(ctx, tracing.Config{
+		Exporter:     config.TraceExporter,
+		OTLPEndpoint: config.TraceOTLPEndpoint,
+		SampleRatio:  config.TraceSampleRatio,
+	})
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot initialize tracing")
+	}
+
+	migration := runDBMigration(config.MigrationURL, config.DBSource)
+
+	poolConfig, err := pgxpool.ParseConfig(config.DBSource)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot parse db source")
+	}
+	poolConfig.ConnConfig.Tracer = otelpgx.NewTracer()
 
-	conn, err := pgxpool.New(ctx, config.DBSource)
+	conn, err := pgxpool.NewWithConfig(ctx, poolConfig)
 	if err != nil {
 		log.Fatal().Err(err).Msg("cannot connect to db")
 	}
 
-	store := db.NewStore(conn)
+	store := db.NewStore(conn, worker.NewDeliverWebhookTask)
 
 	redisOpt := asynq.RedisClientOpt{
 		Addr: config.RedisAddress,
 	}
 
+	err = metrics.Register(
+		metrics.NewPoolCollector(conn),
+		metrics.NewQueueCollector(asynq.NewInspector(redisOpt)),
+	)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot register metrics")
+	}
+
+	checker := newHealthChecker(config, conn, redisOpt, migration)
+
 	taskDistributor := worker.NewRedisTaskDistributor(redisOpt)
 
+	rateProvider, err := fx.NewFileRateProvider(config.FXRatesFile)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot load exchange rates")
+	}
+
 	waitGroup, ctx := errgroup.WithContext(ctx)
 
-	runTaskProcessor(ctx, waitGroup, config, redisOpt, store)
-	runGatewayServer(ctx, waitGroup, config, store, taskDistributor)
-	runGrpcServer(ctx, waitGroup, config, store, taskDistributor)
+	serveCtx := runShutdownDrain(ctx, waitGroup, checker, config.ShutdownDrainDuration)
+
+	runTaskProcessor(serveCtx, waitGroup, config, redisOpt, store, taskDistributor)
+	runTaskScheduler(serveCtx, waitGroup, redisOpt)
+	runOutboxRelay(serveCtx, waitGroup, config, store, taskDistributor)
+	runGatewayServer(serveCtx, waitGroup, config, store, taskDistributor, rateProvider, checker)
+	runGrpcServer(serveCtx, waitGroup, config, store, taskDistributor, rateProvider, checker)
 
 	err = waitGroup.Wait()
 	if err != nil {
 		log.Fatal().Err(err).Msg("error from wait group")
 	}
+
+	// Flush the spans of the requests and tasks that were still in flight
+	err = shutdownTracing(context.Background())
+	if err != nil {
+		log.Error().Err(err).Msg("failed to shutdown tracing")
+	}
 }
 
-func runDBMigration(migrationURL string, dbSource string) {
+func runDBMigration(migrationURL string, dbSource string) *migrate.Migrate {
 	migration, err := migrate.New(migrationURL, dbSource)
 	if err != nil {
 		log.Fatal().Err(err).Msg("cannot create a new migrate instance")
@@ -94,6 +148,60 @@ func runDBMigration(migrationURL string, dbSource string) {
 	}
 
 	log.Info().Msg("db migrated successfully")
+	return migration
+}
+
+func newHealthChecker(
+	config util.Config,
+	conn *pgxpool.Pool,
+	redisOpt asynq.RedisClientOpt,
+	migration *migrate.Migrate,
+) *health.Checker {
+	latestMigration, err := health.LatestMigration(config.MigrationURL)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot get latest migration")
+	}
+
+	redisClient := redis.NewClient(&redis.Options{
+		Addr:     redisOpt.Addr,
+		Password: redisOpt.Password,
+		DB:       redisOpt.DB,
+	})
+
+	checker := health.NewChecker(config.HealthCheckTimeout)
+	checker.AddCheck("postgres", conn.Ping)
+	checker.AddCheck("redis", func(ctx context.Context) error {
+		return redisClient.Ping(ctx).Err()
+	})
+	checker.AddCheck("migrations", health.MigrationCheck(migration, latestMigration))
+
+	return checker
+}
+
+// runShutdownDrain reports not ready as soon as the shutdown starts, then keeps
+// the servers and the task processor running for the drain duration, so that
+// load balancers stop routing traffic here before connections are closed. The
+// returned context is done when the drain is over.
+func runShutdownDrain(
+	ctx context.Context,
+	waitGroup *errgroup.Group,
+	checker *health.Checker,
+	drainDuration time.Duration,
+) context.Context {
+	serveCtx, stopServing := context.WithCancel(context.Background())
+
+	waitGroup.Go(func() error {
+		<-ctx.Done()
+		log.Info().Msgf("mark not ready and drain for %s", drainDuration)
+		checker.Shutdown()
+
+		time.Sleep(drainDuration)
+		stopServing()
+
+		return nil
+	})
+
+	return serveCtx
 }
 
 func runTaskProcessor(
@@ -102,9 +210,11 @@ func runTaskProcessor(
 	config util.Config,
 	redisOpt asynq.RedisClientOpt,
 	store db.Store,
+	taskDistributor worker.TaskDistributor,
 ) {
 	mailer := mail.NewGmailSender(config.EmailSenderName, config.EmailSenderAddress, config.EmailSenderPassword)
-	taskProcessor := worker.NewRedisTaskProcessor(redisOpt, store, mailer)
+	webhookSender := webhook.NewHTTPSender(config.WebhookTimeout)
+	taskProcessor := worker.NewRedisTaskProcessor(redisOpt, store, mailer, taskDistributor, webhookSender, config.FrontendURL)
 
 	log.Info().Msg("start task processor")
 	err := taskProcessor.Start()
@@ -123,14 +233,58 @@ func runTaskProcessor(
 	})
 }
 
+func runTaskScheduler(
+	ctx context.Context,
+	waitGroup *errgroup.Group,
+	redisOpt asynq.RedisClientOpt,
+) {
+	taskScheduler := worker.NewRedisTaskScheduler(redisOpt)
+
+	log.Info().Msg("start task scheduler")
+	err := taskScheduler.Start()
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to start task scheduler")
+	}
+
+	waitGroup.Go(func() error {
+		<-ctx.Done()
+		log.Info().Msg("graceful shutdown task scheduler")
+
+		taskScheduler.Shutdown()
+		log.Info().Msg("task scheduler is stopped")
+
+		return nil
+	})
+}
+
+func runOutboxRelay(
+	ctx context.Context,
+	waitGroup *errgroup.Group,
+	config util.Config,
+	store db.Store,
+	taskDistributor worker.TaskDistributor,
+) {
+	outboxRelay := worker.NewOutboxRelay(store, taskDistributor, config.OutboxRelayInterval, config.OutboxRelayBatchSize, config.OutboxMaxAttempts)
+
+	log.Info().Msg("start outbox relay")
+	waitGroup.Go(func() error {
+		outboxRelay.Start(ctx)
+		log.Info().Msg("outbox relay is stopped")
+
+		return nil
+	})
+}
+
 func runGatewayServer(
 	ctx context.Context,
 	waitGroup *errgroup.Group,
 	config util.Config,
 	store db.Store,
 	taskDistributor worker.TaskDistributor,
+	rateProvider fx.RateProvider,
+	checker *health.Checker,
 ) {
-	server, err := gapi.NewServer(config, store, taskDistributor)
+	server, err := gapi.NewServer(config, store, taskDistributor, rateProvider)
 	if err != nil {
 		log.Fatal().Err(err).Msg("cannot create server")
 	}
@@ -144,15 +298,30 @@ func runGatewayServer(
 		},
 	})
 
-	grpcMux := runtime.NewServeMux(jsonOption)
-
-	err = pb.RegisterSimpleBankHandlerServer(ctx, grpcMux, server)
+	grpcMux := runtime.NewServeMux(
+		jsonOption,
+		runtime.WithIncomingHeaderMatcher(gapi.IncomingHeaderMatcher),
+		runtime.WithOutgoingHeaderMatcher(gapi.OutgoingHeaderMatcher),
+		runtime.WithMiddlewares(gapi.GatewayRoute),
+	)
+
+	// The gateway proxies to the gRPC server instead of calling the handlers
+	// directly, so that requests go through the same interceptors
+	dialOptions := []grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	}
+	err = pb.RegisterSimpleBankHandlerFromEndpoint(ctx, grpcMux, config.GRPCServerAddress, dialOptions)
 	if err != nil {
 		log.Fatal().Err(err).Msg("cannot register handler server")
 	}
 
 	mux := http.NewServeMux()
 	mux.Handle("/", grpcMux)
+	server.RegisterPublicKeysHandler(mux)
+	server.RegisterOAuthHandlers(mux)
+	mux.Handle("GET /metrics", metrics.Handler())
+	checker.RegisterHandlers(mux)
 
 	fs, err := fs.Sub(swaggerFS, "doc/swagger")
 	if err != nil {
@@ -175,7 +344,11 @@ func runGatewayServer(
 		AllowCredentials: false,
 		MaxAge:           300,
 	})
-	handler := c.Handler(gapi.HTTPLogger(mux))
+	// Probes are polled constantly and would drown the traces of real requests
+	traceFilter := otelhttp.WithFilter(func(req *http.Request) bool {
+		return req.URL.Path != health.LivenessPath && req.URL.Path != health.ReadinessPath
+	})
+	handler := c.Handler(otelhttp.NewHandler(gapi.HTTPLogger(gapi.HTTPMetrics(mux)), "gateway", traceFilter))
 
 	httpServer := &http.Server{
 		Handler: handler,
@@ -216,22 +389,47 @@ func runGrpcServer(
 	config util.Config,
 	store db.Store,
 	taskDistributor worker.TaskDistributor,
+	rateProvider fx.RateProvider,
+	checker *health.Checker,
 ) {
-	server, err := gapi.NewServer(config, store, taskDistributor)
+	server, err := gapi.NewServer(config, store, taskDistributor, rateProvider)
 	if err != nil {
 		log.Fatal().Err(err).Msg("cannot create server")
 	}
 
-	grpcLogger := grpc.UnaryInterceptor(gapi.GrpcLogger)
-	grpcServer := grpc.NewServer(grpcLogger)
+	unaryInterceptors := grpc.ChainUnaryInterceptor(
+		gapi.GrpcRequestID,
+		gapi.GrpcLogger,
+		gapi.GrpcMetrics,
+		server.AuthorizationInterceptor,
+	)
+	streamInterceptors := grpc.ChainStreamInterceptor(
+		gapi.GrpcStreamMetrics,
+		server.StreamAuthorizationInterceptor,
+	)
+	grpcServer := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler(
+			otelgrpc.WithFilter(filters.Not(filters.HealthCheck())),
+		)),
+		unaryInterceptors,
+		streamInterceptors,
+	)
 	pb.RegisterSimpleBankServer(grpcServer, server)
 	reflection.Register(grpcServer)
 
+	healthServer := grpchealth.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+
 	listener, err := net.Listen("tcp", config.GRPCServerAddress)
 	if err != nil {
 		log.Fatal().Err(err).Msg("cannot create listener")
 	}
 
+	waitGroup.Go(func() error {
+		checker.UpdateGRPCHealth(ctx, healthServer, config.HealthCheckInterval, pb.SimpleBank_ServiceDesc.ServiceName)
+		return nil
+	})
+
 	waitGroup.Go(func() error {
 		log.Info().Msgf("start gRPC server at %s", listener.Addr().String())
 		err = grpcServer.Serve(listener)
@@ -260,8 +458,8 @@ func runGrpcServer(
 *
 * Not used for now. Keep as reference
 *
-func runGinServer(config util.Config, store db.Store) {
-	server, err := api.NewServer(config, store)
+func runGinServer(config util.Config, store db.Store, rateProvider fx.RateProvider) {
+	server, err := api.NewServer(config, store, rateProvider)
 	if err != nil {
 		log.Fatal().Err(err).Msg("cannot create server")
 	}
@@ -0,0 +1,165 @@
+// Package metrics exports Prometheus metrics of the servers, the database
+// pool, the task queues and the banking operations.
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc/codes"
+)
+
+const namespace = "simple_bank"
+
+// Outcomes of a processed task
+const (
+	TaskSucceeded = "success"
+	TaskFailed    = "failure"
+	TaskSkipped   = "skip_retry"
+)
+
+var registry = prometheus.NewRegistry()
+
+var (
+	grpcRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "grpc",
+		Name:      "requests_total",
+		Help:      "Number of gRPC requests by method and status code.",
+	}, []string{"method", "code"})
+
+	grpcDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "grpc",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of gRPC requests by method and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "code"})
+
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "Number of HTTP gateway requests by route and status code.",
+	}, []string{"method", "route", "code"})
+
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of HTTP gateway requests by route and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "code"})
+
+	tasksProcessed = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "worker",
+		Name:      "tasks_processed_total",
+		Help:      "Number of processed tasks by type and outcome.",
+	}, []string{"type", "outcome"})
+
+	taskDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "worker",
+		Name:      "task_duration_seconds",
+		Help:      "Processing time of tasks by type.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"type"})
+
+	outboxAbandoned = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "outbox",
+		Name:      "messages_abandoned_total",
+		Help:      "Number of outbox messages given up after too many failed publishes, by task type.",
+	}, []string{"type"})
+
+	transfers = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "transfers_total",
+		Help:      "Number of money transfers by currency of the source account.",
+	}, []string{"currency"})
+
+	transferVolume = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "transfer_amount_total",
+		Help:      "Amount of money transferred by currency of the source account.",
+	}, []string{"currency"})
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		grpcRequests,
+		grpcDuration,
+		httpRequests,
+		httpDuration,
+		tasksProcessed,
+		taskDuration,
+		outboxAbandoned,
+		transfers,
+		transferVolume,
+	)
+}
+
+// Register adds collectors of components created at startup, such as the
+// database pool and the task queues
+func Register(cs ...prometheus.Collector) error {
+	for _, c := range cs {
+		if err := registry.Register(c); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Handler serves the metrics in the Prometheus text format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{Registry: registry})
+}
+
+// ObserveGRPCRequest records a handled gRPC request
+func ObserveGRPCRequest(method string, code codes.Code, duration time.Duration) {
+	grpcRequests.WithLabelValues(method, code.String()).Inc()
+	grpcDuration.WithLabelValues(method, code.String()).Observe(duration.Seconds())
+}
+
+// ObserveHTTPRequest records a served HTTP request. The route is the pattern
+// the request matched, so that path parameters don't create new series.
+func ObserveHTTPRequest(method string, route string, statusCode int, duration time.Duration) {
+	code := strconv.Itoa(statusCode)
+	httpRequests.WithLabelValues(method, route, code).Inc()
+	httpDuration.WithLabelValues(method, route, code).Observe(duration.Seconds())
+}
+
+// ObserveTask records a processed task and whether it will be retried
+func ObserveTask(taskType string, err error, duration time.Duration) {
+	outcome := TaskSucceeded
+	switch {
+	case errors.Is(err, asynq.SkipRetry):
+		outcome = TaskSkipped
+	case err != nil:
+		outcome = TaskFailed
+	}
+
+	tasksProcessed.WithLabelValues(taskType, outcome).Inc()
+	taskDuration.WithLabelValues(taskType).Observe(duration.Seconds())
+}
+
+// ObserveOutboxAbandoned records an outbox message that is not published again
+func ObserveOutboxAbandoned(taskType string) {
+	outboxAbandoned.WithLabelValues(taskType).Inc()
+}
+
+// ObserveTransfer records money moved out of an account of the given currency
+func ObserveTransfer(currency string, amount int64) {
+	transfers.WithLabelValues(currency).Inc()
+	transferVolume.WithLabelValues(currency).Add(float64(amount))
+}
@@ -0,0 +1,83 @@
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestObserveTask(t *testing.T) {
+	taskType := "task:test_observe"
+
+	ObserveTask(taskType, nil, time.Millisecond)
+	ObserveTask(taskType, errors.New("temporary"), time.Millisecond)
+	ObserveTask(taskType, fmt.Errorf("%w: bad payload", asynq.SkipRetry), time.Millisecond)
+	ObserveTask(taskType, nil, time.Millisecond)
+
+	require.Equal(t, 2.0, testutil.ToFloat64(tasksProcessed.WithLabelValues(taskType, TaskSucceeded)))
+	require.Equal(t, 1.0, testutil.ToFloat64(tasksProcessed.WithLabelValues(taskType, TaskFailed)))
+	require.Equal(t, 1.0, testutil.ToFloat64(tasksProcessed.WithLabelValues(taskType, TaskSkipped)))
+}
+
+func TestObserveTransfer(t *testing.T) {
+	ObserveTransfer("CAD", 100)
+	ObserveTransfer("CAD", 50)
+
+	require.Equal(t, 2.0, testutil.ToFloat64(transfers.WithLabelValues("CAD")))
+	require.Equal(t, 150.0, testutil.ToFloat64(transferVolume.WithLabelValues("CAD")))
+}
+
+type testInspector struct {
+	queues map[string]*asynq.QueueInfo
+}
+
+func (inspector *testInspector) Queues() ([]string, error) {
+	var queues []string
+	for queue := range inspector.queues {
+		queues = append(queues, queue)
+	}
+	return queues, nil
+}
+
+func (inspector *testInspector) GetQueueInfo(queue string) (*asynq.QueueInfo, error) {
+	return inspector.queues[queue], nil
+}
+
+func TestQueueCollector(t *testing.T) {
+	collector := NewQueueCollector(&testInspector{
+		queues: map[string]*asynq.QueueInfo{
+			"critical": {Queue: "critical", Pending: 3, Retry: 1, Latency: 2 * time.Second},
+		},
+	})
+
+	expected := `
+# HELP simple_bank_worker_queue_tasks Number of tasks in a queue by state.
+# TYPE simple_bank_worker_queue_tasks gauge
+simple_bank_worker_queue_tasks{queue="critical",state="active"} 0
+simple_bank_worker_queue_tasks{queue="critical",state="archived"} 0
+simple_bank_worker_queue_tasks{queue="critical",state="pending"} 3
+simple_bank_worker_queue_tasks{queue="critical",state="retry"} 1
+simple_bank_worker_queue_tasks{queue="critical",state="scheduled"} 0
+`
+	err := testutil.CollectAndCompare(collector, strings.NewReader(expected), "simple_bank_worker_queue_tasks")
+	require.NoError(t, err)
+}
+
+func TestHandler(t *testing.T) {
+	ObserveGRPCRequest("/pb.v1.SimpleBank/GetAccount", codes.NotFound, time.Millisecond)
+
+	recorder := httptest.NewRecorder()
+	Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Contains(t, recorder.Body.String(), `simple_bank_grpc_requests_total{code="NotFound",method="/pb.v1.SimpleBank/GetAccount"} 1`)
+}
@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"github.com/hibiken/asynq"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog/log"
+)
+
+// QueueInspector is the part of asynq.Inspector used to read the queue sizes
+type QueueInspector interface {
+	Queues() ([]string, error)
+	GetQueueInfo(queue string) (*asynq.QueueInfo, error)
+}
+
+// queueCollector exports the number of tasks in every asynq queue by state
+type queueCollector struct {
+	inspector QueueInspector
+	tasks     *prometheus.Desc
+	latency   *prometheus.Desc
+}
+
+// NewQueueCollector creates a collector of the depth of the task queues
+func NewQueueCollector(inspector QueueInspector) prometheus.Collector {
+	return &queueCollector{
+		inspector: inspector,
+		tasks: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "worker", "queue_tasks"),
+			"Number of tasks in a queue by state.",
+			[]string{"queue", "state"}, nil,
+		),
+		latency: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "worker", "queue_latency_seconds"),
+			"Time the oldest pending task of a queue has been waiting.",
+			[]string{"queue"}, nil,
+		),
+	}
+}
+
+func (c *queueCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.tasks
+	ch <- c.latency
+}
+
+func (c *queueCollector) Collect(ch chan<- prometheus.Metric) {
+	queues, err := c.inspector.Queues()
+	if err != nil {
+		log.Error().Err(err).Msg("cannot list task queues")
+		return
+	}
+
+	for _, queue := range queues {
+		info, err := c.inspector.GetQueueInfo(queue)
+		if err != nil {
+			log.Error().Err(err).Str("queue", queue).Msg("cannot get task queue info")
+			continue
+		}
+
+		for state, count := range map[string]int{
+			"pending":   info.Pending,
+			"active":    info.Active,
+			"scheduled": info.Scheduled,
+			"retry":     info.Retry,
+			"archived":  info.Archived,
+		} {
+			ch <- prometheus.MustNewConstMetric(c.tasks, prometheus.GaugeValue, float64(count), queue, state)
+		}
+		ch <- prometheus.MustNewConstMetric(c.latency, prometheus.GaugeValue, info.Latency.Seconds(), queue)
+	}
+}
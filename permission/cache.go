@@ -0,0 +1,65 @@
+package permission
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	db "github.com/yelaco/simple-bank/db/sqlc"
+)
+
+type entry struct {
+	permissions Set
+	expiresAt   time.Time
+}
+
+// Cache keeps the permissions of each role for a short duration, so that
+// authorizing a request does not cost a database query
+type Cache struct {
+	store    db.Store
+	duration time.Duration
+
+	mu      sync.Mutex
+	entries map[string]entry
+}
+
+// NewCache creates a new Cache whose entries live for the given duration
+func NewCache(store db.Store, duration time.Duration) *Cache {
+	return &Cache{
+		store:    store,
+		duration: duration,
+		entries:  make(map[string]entry),
+	}
+}
+
+// RolePermissions returns the permissions granted to a role. A role that is
+// not defined has no permissions.
+func (cache *Cache) RolePermissions(ctx context.Context, role string) (Set, error) {
+	now := time.Now()
+
+	cache.mu.Lock()
+	cached, ok := cache.entries[role]
+	cache.mu.Unlock()
+
+	if ok && now.Before(cached.expiresAt) {
+		return cached.permissions, nil
+	}
+
+	permissions, err := cache.store.ListRolePermissions(ctx, role)
+	if err != nil {
+		return nil, fmt.Errorf("permission.Cache.RolePermissions: %w", err)
+	}
+
+	set := NewSet(permissions...)
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	cache.entries[role] = entry{
+		permissions: set,
+		expiresAt:   now.Add(cache.duration),
+	}
+
+	return set, nil
+}
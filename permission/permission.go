@@ -0,0 +1,89 @@
+// Package permission defines the permissions that can be granted to a role.
+// A permission has the form "<resource>:<action>:<scope>", where the scope is
+// either "own" for resources of the user or "any" for resources of every user.
+package permission
+
+import "strings"
+
+const (
+	UsersUpdateOwn = "users:update:own"
+	UsersUpdateAny = "users:update:any"
+	UsersUnlockAny = "users:unlock:any"
+	RolesAssignAny = "roles:assign:any"
+
+	AccountsCreateOwn          = "accounts:create:own"
+	AccountsCreateAny          = "accounts:create:any"
+	AccountsReadOwn            = "accounts:read:own"
+	AccountsReadAny            = "accounts:read:any"
+	AccountsCloseOwn           = "accounts:close:own"
+	AccountsCloseAny           = "accounts:close:any"
+	AccountsUpdateOverdraftAny = "accounts:update_overdraft:any"
+	StatementsSendOwn          = "statements:send:own"
+	StatementsSendAny          = "statements:send:any"
+
+	TransfersCreateOwn          = "transfers:create:own"
+	TransfersReverseAny         = "transfers:reverse:any"
+	ScheduledTransfersCreateOwn = "scheduled_transfers:create:own"
+	ScheduledTransfersCancelOwn = "scheduled_transfers:cancel:own"
+	ScheduledTransfersCancelAny = "scheduled_transfers:cancel:any"
+
+	HoldsCreateOwn  = "holds:create:own"
+	HoldsCaptureOwn = "holds:capture:own"
+	HoldsCaptureAny = "holds:capture:any"
+	HoldsVoidOwn    = "holds:void:own"
+	HoldsVoidAny    = "holds:void:any"
+
+	MFAManageOwn          = "mfa:manage:own"
+	APIKeysManageOwn      = "api_keys:manage:own"
+	OAuthClientsManageOwn = "oauth_clients:manage:own"
+	SessionsReadOwn       = "sessions:read:own"
+	SessionsRevokeOwn     = "sessions:revoke:own"
+	WebhooksManageOwn     = "webhooks:manage:own"
+
+	AuditReadAny = "audit:read:any"
+)
+
+const (
+	ownScope = ":own"
+	anyScope = ":any"
+)
+
+// Set is the set of permissions granted to a role
+type Set map[string]struct{}
+
+// NewSet creates a new Set from a list of permissions
+func NewSet(permissions ...string) Set {
+	set := make(Set, len(permissions))
+	for _, permission := range permissions {
+		set[permission] = struct{}{}
+	}
+
+	return set
+}
+
+// Has reports whether the set grants the given permission. A permission on
+// any resource also grants the same permission on the user's own resources.
+func (set Set) Has(permission string) bool {
+	if _, ok := set[permission]; ok {
+		return true
+	}
+
+	if action, ok := strings.CutSuffix(permission, ownScope); ok {
+		_, ok = set[action+anyScope]
+		return ok
+	}
+
+	return false
+}
+
+// Restrict returns the permissions of the set that are also in scopes
+func (set Set) Restrict(scopes []string) Set {
+	restricted := make(Set, len(scopes))
+	for _, scope := range scopes {
+		if set.Has(scope) {
+			restricted[scope] = struct{}{}
+		}
+	}
+
+	return restricted
+}
@@ -0,0 +1,36 @@
+package permission
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetHas(t *testing.T) {
+	set := NewSet(AccountsReadAny, TransfersCreateOwn)
+
+	require.True(t, set.Has(AccountsReadAny))
+	require.True(t, set.Has(AccountsReadOwn))
+	require.True(t, set.Has(TransfersCreateOwn))
+
+	require.False(t, set.Has(AccountsCloseOwn))
+	require.False(t, set.Has(TransfersReverseAny))
+
+	// an own permission does not grant the same permission on any resource
+	require.False(t, NewSet(AccountsReadOwn).Has(AccountsReadAny))
+
+	require.False(t, NewSet().Has(AccountsReadOwn))
+}
+
+func TestSetRestrict(t *testing.T) {
+	set := NewSet(AccountsReadAny, TransfersCreateOwn, HoldsVoidOwn)
+
+	restricted := set.Restrict([]string{AccountsReadOwn, TransfersCreateOwn, TransfersReverseAny})
+	require.True(t, restricted.Has(AccountsReadOwn))
+	require.True(t, restricted.Has(TransfersCreateOwn))
+
+	// the scopes cannot widen the permissions of the set
+	require.False(t, restricted.Has(AccountsReadAny))
+	require.False(t, restricted.Has(TransfersReverseAny))
+	require.False(t, restricted.Has(HoldsVoidOwn))
+}
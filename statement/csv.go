@@ -0,0 +1,33 @@
+package statement
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+)
+
+// WriteCSV renders the statement as CSV, with the opening and closing balance as first and last rows
+func (statement Statement) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	firstDay, lastDay := statement.Period()
+
+	records := [][]string{
+		{"date", "entry_id", "description", "amount", "balance", "currency"},
+		{firstDay, "", "Opening balance", "", formatAmount(statement.OpeningBalance), statement.Account.Currency},
+	}
+
+	for _, line := range statement.Lines {
+		records = append(records, []string{
+			line.Date.UTC().Format(dateLayout),
+			strconv.FormatInt(line.EntryID, 10),
+			line.Description,
+			formatAmount(line.Amount),
+			formatAmount(line.Balance),
+			statement.Account.Currency,
+		})
+	}
+
+	records = append(records, []string{lastDay, "", "Closing balance", "", formatAmount(statement.ClosingBalance), statement.Account.Currency})
+
+	return writer.WriteAll(records)
+}
@@ -0,0 +1,95 @@
+package statement
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// column widths of the entries table in millimeters, they add up to the printable width of an A4 page
+var pdfColumns = []struct {
+	title string
+	width float64
+	align string
+}{
+	{"Date", 25, "L"},
+	{"Entry", 20, "L"},
+	{"Description", 75, "L"},
+	{"Amount", 30, "R"},
+	{"Balance", 30, "R"},
+}
+
+// WritePDF renders the statement as a PDF document
+func (statement Statement) WritePDF(w io.Writer) error {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetTitle(fmt.Sprintf("Statement of account #%d", statement.Account.ID), false)
+	pdf.SetAuthor("Simple Bank", false)
+	pdf.AliasNbPages("")
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-15)
+		pdf.SetFont("Helvetica", "I", 8)
+		pdf.CellFormat(0, 10, fmt.Sprintf("Page %d/{nb}", pdf.PageNo()), "", 0, "C", false, 0, "")
+	})
+	pdf.AddPage()
+
+	firstDay, lastDay := statement.Period()
+
+	pdf.SetFont("Helvetica", "B", 16)
+	pdf.CellFormat(0, 10, "Simple Bank - Account statement", "", 1, "L", false, 0, "")
+
+	pdf.SetFont("Helvetica", "", 10)
+	summary := [][2]string{
+		{"Account", fmt.Sprintf("#%d (%s)", statement.Account.ID, statement.Account.Currency)},
+		{"Owner", statement.Account.Owner},
+		{"Period", fmt.Sprintf("%s to %s", firstDay, lastDay)},
+		{"Opening balance", formatAmount(statement.OpeningBalance)},
+		{"Total credits", formatAmount(statement.TotalCredits)},
+		{"Total debits", formatAmount(-statement.TotalDebits)},
+		{"Closing balance", formatAmount(statement.ClosingBalance)},
+	}
+	for _, row := range summary {
+		pdf.CellFormat(40, 6, row[0], "", 0, "L", false, 0, "")
+		pdf.CellFormat(0, 6, row[1], "", 1, "L", false, 0, "")
+	}
+	pdf.Ln(4)
+
+	writeHeader := func() {
+		pdf.SetFont("Helvetica", "B", 10)
+		pdf.SetFillColor(230, 230, 230)
+		for _, column := range pdfColumns {
+			pdf.CellFormat(column.width, 7, column.title, "1", 0, column.align, true, 0, "")
+		}
+		pdf.Ln(-1)
+		pdf.SetFont("Helvetica", "", 9)
+	}
+	writeHeader()
+
+	_, pageHeight := pdf.GetPageSize()
+	_, _, _, bottomMargin := pdf.GetMargins()
+
+	for _, line := range statement.Lines {
+		if pdf.GetY()+6 > pageHeight-bottomMargin-15 {
+			pdf.AddPage()
+			writeHeader()
+		}
+
+		cells := []string{
+			line.Date.UTC().Format(dateLayout),
+			fmt.Sprintf("%d", line.EntryID),
+			line.Description,
+			formatAmount(line.Amount),
+			formatAmount(line.Balance),
+		}
+		for i, column := range pdfColumns {
+			pdf.CellFormat(column.width, 6, cells[i], "1", 0, column.align, false, 0, "")
+		}
+		pdf.Ln(-1)
+	}
+
+	if len(statement.Lines) == 0 {
+		pdf.CellFormat(0, 6, "No entries during this period", "1", 1, "C", false, 0, "")
+	}
+
+	return pdf.Output(w)
+}
@@ -0,0 +1,122 @@
+// Package statement builds account statements and renders them as CSV and PDF.
+package statement
+
+import (
+	"fmt"
+	"time"
+
+	db "github.com/yelaco/simple-bank/db/sqlc"
+)
+
+const dateLayout = "2006-01-02"
+
+// Line is a single booking on the statement
+type Line struct {
+	EntryID     int64
+	Date        time.Time
+	Description string
+	Amount      int64
+	// Balance is the running balance of the account after this line
+	Balance int64
+}
+
+// Statement lists every entry booked on an account during the period [FromTime, ToTime)
+type Statement struct {
+	Account        db.Account
+	FromTime       time.Time
+	ToTime         time.Time
+	OpeningBalance int64
+	ClosingBalance int64
+	TotalCredits   int64
+	TotalDebits    int64
+	Lines          []Line
+}
+
+// New builds the statement of the period from the data read by db.StatementTx
+func New(data db.StatementTxResult, fromTime time.Time, toTime time.Time) Statement {
+	statement := Statement{
+		Account:        data.Account,
+		FromTime:       fromTime,
+		ToTime:         toTime,
+		OpeningBalance: data.OpeningBalance,
+		Lines:          make([]Line, 0, len(data.Entries)),
+	}
+
+	matched := make(map[int64]bool, len(data.Transfers))
+	balance := data.OpeningBalance
+
+	for _, entry := range data.Entries {
+		balance += entry.Amount
+		if entry.Amount > 0 {
+			statement.TotalCredits += entry.Amount
+		} else {
+			statement.TotalDebits -= entry.Amount
+		}
+
+		description := fmt.Sprintf("Entry #%d", entry.ID)
+		if transfer, ok := findTransfer(data.Account.ID, entry, data.Transfers, matched); ok {
+			matched[transfer.ID] = true
+			description = describeTransfer(data.Account.ID, transfer)
+		}
+
+		statement.Lines = append(statement.Lines, Line{
+			EntryID:     entry.ID,
+			Date:        entry.CreatedAt,
+			Description: description,
+			Amount:      entry.Amount,
+			Balance:     balance,
+		})
+	}
+
+	statement.ClosingBalance = balance
+	return statement
+}
+
+// findTransfer returns the transfer an entry was booked for.
+// Entries don't reference their transfer, but both are created in the same database transaction,
+// so they share the same creation time.
+func findTransfer(accountID int64, entry db.Entry, transfers []db.Transfer, matched map[int64]bool) (db.Transfer, bool) {
+	for _, transfer := range transfers {
+		if matched[transfer.ID] || !transfer.CreatedAt.Equal(entry.CreatedAt) {
+			continue
+		}
+
+		if entry.Amount < 0 && transfer.FromAccountID == accountID && transfer.Amount == -entry.Amount {
+			return transfer, true
+		}
+
+		if entry.Amount > 0 && transfer.ToAccountID == accountID && transfer.ToAmount == entry.Amount {
+			return transfer, true
+		}
+	}
+
+	return db.Transfer{}, false
+}
+
+func describeTransfer(accountID int64, transfer db.Transfer) string {
+	kind := "Transfer"
+	if transfer.ReversalOf.Valid {
+		kind = fmt.Sprintf("Reversal of transfer #%d", transfer.ReversalOf.Int64)
+	}
+
+	if transfer.FromAccountID == accountID {
+		return fmt.Sprintf("%s to account #%d", kind, transfer.ToAccountID)
+	}
+	return fmt.Sprintf("%s from account #%d", kind, transfer.FromAccountID)
+}
+
+// Period returns the first and the last day covered by the statement
+func (statement Statement) Period() (string, string) {
+	lastDay := statement.ToTime.Add(-time.Nanosecond)
+	return statement.FromTime.UTC().Format(dateLayout), lastDay.UTC().Format(dateLayout)
+}
+
+// formatAmount formats an amount in minor units with two decimals
+func formatAmount(amount int64) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, amount/100, amount%100)
+}
@@ -0,0 +1,114 @@
+package statement
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	db "github.com/yelaco/simple-bank/db/sqlc"
+	"github.com/yelaco/simple-bank/util"
+)
+
+func randomStatementData() (db.StatementTxResult, time.Time, time.Time) {
+	fromTime := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+	toTime := fromTime.AddDate(0, 1, 0)
+
+	account := db.Account{
+		ID:       util.RandomInt(1, 1000),
+		Owner:    util.RandomOwner(),
+		Balance:  8000,
+		Currency: util.USD,
+	}
+
+	at1 := fromTime.Add(24 * time.Hour)
+	at2 := fromTime.Add(48 * time.Hour)
+	at3 := fromTime.Add(72 * time.Hour)
+
+	data := db.StatementTxResult{
+		Account:        account,
+		OpeningBalance: 10000,
+		Entries: []db.Entry{
+			{ID: 1, AccountID: account.ID, Amount: -2500, CreatedAt: at1},
+			{ID: 2, AccountID: account.ID, Amount: 1000, CreatedAt: at2},
+			{ID: 3, AccountID: account.ID, Amount: -550, CreatedAt: at3},
+		},
+		Transfers: []db.Transfer{
+			{ID: 10, FromAccountID: account.ID, ToAccountID: 99, Amount: 2500, ToAmount: 2500, CreatedAt: at1},
+			{ID: 11, FromAccountID: 99, ToAccountID: account.ID, Amount: 1000, ToAmount: 1000, CreatedAt: at2, ReversalOf: pgtype.Int8{Int64: 10, Valid: true}},
+		},
+	}
+
+	return data, fromTime, toTime
+}
+
+func TestNew(t *testing.T) {
+	data, fromTime, toTime := randomStatementData()
+
+	statement := New(data, fromTime, toTime)
+
+	require.Equal(t, int64(10000), statement.OpeningBalance)
+	require.Equal(t, int64(7950), statement.ClosingBalance)
+	require.Equal(t, int64(1000), statement.TotalCredits)
+	require.Equal(t, int64(3050), statement.TotalDebits)
+
+	require.Len(t, statement.Lines, 3)
+	require.Equal(t, int64(7500), statement.Lines[0].Balance)
+	require.Equal(t, int64(8500), statement.Lines[1].Balance)
+	require.Equal(t, int64(7950), statement.Lines[2].Balance)
+
+	require.Equal(t, "Transfer to account #99", statement.Lines[0].Description)
+	require.Equal(t, "Reversal of transfer #10 from account #99", statement.Lines[1].Description)
+	require.Equal(t, "Entry #3", statement.Lines[2].Description)
+
+	firstDay, lastDay := statement.Period()
+	require.Equal(t, "2025-03-01", firstDay)
+	require.Equal(t, "2025-03-31", lastDay)
+}
+
+func TestWriteCSV(t *testing.T) {
+	data, fromTime, toTime := randomStatementData()
+	statement := New(data, fromTime, toTime)
+
+	var buf bytes.Buffer
+	err := statement.WriteCSV(&buf)
+	require.NoError(t, err)
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+
+	// header, opening balance, one row per entry and closing balance
+	require.Len(t, records, len(statement.Lines)+3)
+	require.Equal(t, []string{"2025-03-01", "", "Opening balance", "", "100.00", util.USD}, records[1])
+	require.Equal(t, []string{"2025-03-02", "1", "Transfer to account #99", "-25.00", "75.00", util.USD}, records[2])
+	require.Equal(t, []string{"2025-03-31", "", "Closing balance", "", "79.50", util.USD}, records[len(records)-1])
+}
+
+func TestWritePDF(t *testing.T) {
+	data, fromTime, toTime := randomStatementData()
+
+	// enough entries to span several pages
+	for i := range 100 {
+		data.Entries = append(data.Entries, db.Entry{
+			ID:        int64(100 + i),
+			AccountID: data.Account.ID,
+			Amount:    1,
+			CreatedAt: fromTime.Add(time.Duration(i) * time.Hour),
+		})
+	}
+	statement := New(data, fromTime, toTime)
+
+	var buf bytes.Buffer
+	err := statement.WritePDF(&buf)
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(buf.Bytes(), []byte("%PDF-")))
+}
+
+func TestFormatAmount(t *testing.T) {
+	require.Equal(t, "0.00", formatAmount(0))
+	require.Equal(t, "0.05", formatAmount(5))
+	require.Equal(t, "12.34", formatAmount(1234))
+	require.Equal(t, "-12.34", formatAmount(-1234))
+}
@@ -0,0 +1,61 @@
+package token
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/yelaco/simple-bank/util"
+)
+
+const (
+	apiKeyPrefix     = "sb"
+	apiKeySecretSize = 40
+)
+
+// NewAPIKey generates a new API key. The key embeds its ID, so that it can be
+// looked up, followed by a random secret. Only the hash of the key is stored.
+func NewAPIKey() (id uuid.UUID, key string, hashedKey string, err error) {
+	id, err = uuid.NewRandom()
+	if err != nil {
+		return uuid.Nil, "", "", fmt.Errorf("token.NewAPIKey: %w", err)
+	}
+
+	secret, err := util.GenerateSecretCode(apiKeySecretSize)
+	if err != nil {
+		return uuid.Nil, "", "", fmt.Errorf("token.NewAPIKey: %w", err)
+	}
+
+	key = fmt.Sprintf("%s_%s_%s", apiKeyPrefix, hex.EncodeToString(id[:]), secret)
+	return id, key, HashAPIKey(key), nil
+}
+
+// ParseAPIKey returns the ID embedded in an API key
+func ParseAPIKey(key string) (uuid.UUID, error) {
+	fields := strings.Split(key, "_")
+	if len(fields) != 3 || fields[0] != apiKeyPrefix || len(fields[2]) != apiKeySecretSize {
+		return uuid.Nil, fmt.Errorf("token.ParseAPIKey: %w", ErrInvalidToken)
+	}
+
+	id, err := uuid.Parse(fields[1])
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("token.ParseAPIKey: %w", ErrInvalidToken)
+	}
+
+	return id, nil
+}
+
+// HashAPIKey hashes an API key for storage. Keys are long random strings, so a
+// fast hash is enough and keeps the verification cheap on every request.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// CheckAPIKey reports whether the key matches the stored hash
+func CheckAPIKey(key string, hashedKey string) bool {
+	return subtle.ConstantTimeCompare([]byte(HashAPIKey(key)), []byte(hashedKey)) == 1
+}
@@ -0,0 +1,40 @@
+package token
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKey(t *testing.T) {
+	id, key, hashedKey, err := NewAPIKey()
+	require.NoError(t, err)
+	require.NotEmpty(t, key)
+	require.NotEqual(t, key, hashedKey)
+
+	parsedID, err := ParseAPIKey(key)
+	require.NoError(t, err)
+	require.Equal(t, id, parsedID)
+
+	require.True(t, CheckAPIKey(key, hashedKey))
+
+	_, otherKey, _, err := NewAPIKey()
+	require.NoError(t, err)
+	require.False(t, CheckAPIKey(otherKey, hashedKey))
+}
+
+func TestParseInvalidAPIKey(t *testing.T) {
+	_, key, _, err := NewAPIKey()
+	require.NoError(t, err)
+
+	for _, invalid := range []string{
+		"",
+		"sb_invalid",
+		"xx" + key[2:],
+		key[:len(key)-1],
+		"sb_nothex_" + key[len(key)-apiKeySecretSize:],
+	} {
+		_, err := ParseAPIKey(invalid)
+		require.ErrorIs(t, err, ErrInvalidToken, invalid)
+	}
+}
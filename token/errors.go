@@ -6,4 +6,5 @@ var (
 	ErrInvalidToken            = fmt.Errorf("invalid token")
 	ErrUnexpectedSigningMethod = fmt.Errorf("unexpected signing method")
 	ErrExpiredToken            = fmt.Errorf("token is expired")
+	ErrUnknownKey              = fmt.Errorf("unknown token key")
 )
@@ -24,10 +24,15 @@ func NewJwtMaker(secretKey string) (Maker, error) {
 }
 
 func (maker *JwtMaker) CreateToken(username string, role string, duration time.Duration) (string, *Payload, error) {
+	return maker.CreateScopedToken(username, role, nil, duration)
+}
+
+func (maker *JwtMaker) CreateScopedToken(username string, role string, scopes []string, duration time.Duration) (string, *Payload, error) {
 	payload, err := NewPayload(username, role, duration)
 	if err != nil {
 		return "", nil, err
 	}
+	payload.Scopes = scopes
 
 	jwtToken := jwt.NewWithClaims(
 		jwt.SigningMethodHS256,
@@ -8,6 +8,9 @@ type Maker interface {
 	// CreateToken creates a new token for a specific username and duration
 	CreateToken(username string, role string, duration time.Duration) (string, *Payload, error)
 
+	// CreateScopedToken creates a new token whose permissions are limited to the given scopes
+	CreateScopedToken(username string, role string, scopes []string, duration time.Duration) (string, *Payload, error)
+
 	// VeriyToken checks if the token is valid or not
 	VerifyToken(token string) (*Payload, error)
 }
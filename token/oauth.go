@@ -0,0 +1,63 @@
+package token
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/yelaco/simple-bank/util"
+)
+
+const (
+	oauthClientIDPrefix   = "sbc_"
+	oauthClientIDSize     = 24
+	oauthSecretSize       = 48
+	authorizationCodeSize = 32
+)
+
+// NewOAuthClient generates the ID and secret of an OAuth2 client. Only the hash
+// of the secret is stored.
+func NewOAuthClient() (clientID string, secret string, hashedSecret string, err error) {
+	id, err := util.GenerateSecretCode(oauthClientIDSize)
+	if err != nil {
+		return "", "", "", fmt.Errorf("token.NewOAuthClient: %w", err)
+	}
+
+	secret, err = util.GenerateSecretCode(oauthSecretSize)
+	if err != nil {
+		return "", "", "", fmt.Errorf("token.NewOAuthClient: %w", err)
+	}
+
+	return oauthClientIDPrefix + id, secret, HashOAuthSecret(secret), nil
+}
+
+// NewAuthorizationCode generates a one-time OAuth2 authorization code
+func NewAuthorizationCode() (code string, hashedCode string, err error) {
+	code, err = util.GenerateSecretCode(authorizationCodeSize)
+	if err != nil {
+		return "", "", fmt.Errorf("token.NewAuthorizationCode: %w", err)
+	}
+
+	return code, HashOAuthSecret(code), nil
+}
+
+// HashOAuthSecret hashes a client secret or an authorization code for storage
+func HashOAuthSecret(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+// CheckOAuthSecret reports whether the value matches the stored hash
+func CheckOAuthSecret(value string, hashedValue string) bool {
+	return subtle.ConstantTimeCompare([]byte(HashOAuthSecret(value)), []byte(hashedValue)) == 1
+}
+
+// CheckCodeChallenge reports whether a PKCE code verifier matches the S256
+// code challenge sent with the authorization request
+func CheckCodeChallenge(verifier string, challenge string) bool {
+	sum := sha256.Sum256([]byte(verifier))
+	computed := base64.RawURLEncoding.EncodeToString(sum[:])
+	return subtle.ConstantTimeCompare([]byte(computed), []byte(challenge)) == 1
+}
@@ -0,0 +1,46 @@
+package token
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/yelaco/simple-bank/util"
+)
+
+func TestOAuthClient(t *testing.T) {
+	clientID, secret, hashedSecret, err := NewOAuthClient()
+	require.NoError(t, err)
+	require.NotEmpty(t, clientID)
+	require.True(t, CheckOAuthSecret(secret, hashedSecret))
+	require.False(t, CheckOAuthSecret(clientID, hashedSecret))
+}
+
+func TestCheckCodeChallenge(t *testing.T) {
+	// Example from RFC 7636 appendix B
+	verifier := "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	challenge := "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+
+	require.True(t, CheckCodeChallenge(verifier, challenge))
+	require.False(t, CheckCodeChallenge(verifier+"x", challenge))
+}
+
+func TestCreateScopedToken(t *testing.T) {
+	maker, err := NewPasetoMaker(util.RandomString(32))
+	require.NoError(t, err)
+
+	scopes := []string{"accounts:read:own"}
+	token, _, err := maker.CreateScopedToken(util.RandomOwner(), util.DepositorRole, scopes, time.Minute)
+	require.NoError(t, err)
+
+	payload, err := maker.VerifyToken(token)
+	require.NoError(t, err)
+	require.Equal(t, scopes, payload.Scopes)
+
+	token, _, err = maker.CreateToken(util.RandomOwner(), util.DepositorRole, time.Minute)
+	require.NoError(t, err)
+
+	payload, err = maker.VerifyToken(token)
+	require.NoError(t, err)
+	require.Nil(t, payload.Scopes)
+}
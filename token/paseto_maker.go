@@ -30,13 +30,20 @@ func NewPasetoMaker(symmetricKey string) (Maker, error) {
 
 // CreateToken creates a new token for a specific username and duration
 func (maker *PasetoMaker) CreateToken(username string, role string, duration time.Duration) (string, *Payload, error) {
+	return maker.CreateScopedToken(username, role, nil, duration)
+}
+
+// CreateScopedToken creates a new token whose permissions are limited to the given scopes
+func (maker *PasetoMaker) CreateScopedToken(username string, role string, scopes []string, duration time.Duration) (string, *Payload, error) {
 	payload, err := NewPayload(username, role, duration)
 	if err != nil {
-		return "", nil, fmt.Errorf("token.PasetoMaker.CreateToken: %w", err)
+		return "", nil, fmt.Errorf("token.PasetoMaker.CreateScopedToken: %w", err)
 	}
+	payload.Scopes = scopes
+
 	token, err := maker.paseto.Encrypt(maker.symmetricKey, payload, nil)
 	if err != nil {
-		return "", nil, fmt.Errorf("token.PasetoMaker.CreateToken: %w", err)
+		return "", nil, fmt.Errorf("token.PasetoMaker.CreateScopedToken: %w", err)
 	}
 	return token, payload, err
 }
@@ -0,0 +1,167 @@
+package token
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"aidanwoods.dev/go-paseto"
+)
+
+// PublicKey is a key used to verify v4.public tokens
+type PublicKey struct {
+	KeyID string
+	Key   paseto.V4AsymmetricPublicKey
+}
+
+// PublicKeyProvider is implemented by makers that sign tokens with an
+// asymmetric key, so that the verification keys can be published
+type PublicKeyProvider interface {
+	// PublicKeys returns every key that is currently accepted for verification
+	PublicKeys() []PublicKey
+}
+
+type footer struct {
+	KeyID string `json:"kid"`
+}
+
+// PasetoPublicMaker is a PASETO v4.public token maker. Tokens are signed with
+// a single Ed25519 key, while any number of public keys can be used for
+// verification, so that the signing key can be rotated.
+type PasetoPublicMaker struct {
+	keyID      string
+	secretKey  paseto.V4AsymmetricSecretKey
+	publicKeys map[string]PublicKey
+	parser     paseto.Parser
+}
+
+// NewPasetoPublicMaker creates a new PasetoPublicMaker which signs tokens with
+// the hex encoded Ed25519 seed of the given key ID. Retired public keys, which
+// still verify tokens issued before a rotation, are given in verificationKeys.
+func NewPasetoPublicMaker(keyID string, seedHex string, verificationKeys []PublicKey) (Maker, error) {
+	if keyID == "" {
+		return nil, fmt.Errorf("token.NewPasetoPublicMaker: key id must not be empty")
+	}
+
+	secretKey, err := paseto.NewV4AsymmetricSecretKeyFromSeed(seedHex)
+	if err != nil {
+		return nil, fmt.Errorf("token.NewPasetoPublicMaker: invalid signing key: %w", err)
+	}
+
+	maker := &PasetoPublicMaker{
+		keyID:     keyID,
+		secretKey: secretKey,
+		publicKeys: map[string]PublicKey{
+			keyID: {KeyID: keyID, Key: secretKey.Public()},
+		},
+		parser: paseto.NewParserWithoutExpiryCheck(),
+	}
+
+	for _, key := range verificationKeys {
+		if _, ok := maker.publicKeys[key.KeyID]; ok {
+			return nil, fmt.Errorf("token.NewPasetoPublicMaker: duplicate key id %q", key.KeyID)
+		}
+		maker.publicKeys[key.KeyID] = key
+	}
+
+	return maker, nil
+}
+
+// ParsePublicKey parses a verification key in the form "<key id>:<hex encoded key>"
+func ParsePublicKey(value string) (PublicKey, error) {
+	keyID, keyHex, ok := strings.Cut(value, ":")
+	if !ok || keyID == "" {
+		return PublicKey{}, fmt.Errorf("token.ParsePublicKey: must be in the form <key id>:<hex encoded key>")
+	}
+
+	key, err := paseto.NewV4AsymmetricPublicKeyFromHex(keyHex)
+	if err != nil {
+		return PublicKey{}, fmt.Errorf("token.ParsePublicKey: invalid key %q: %w", keyID, err)
+	}
+
+	return PublicKey{KeyID: keyID, Key: key}, nil
+}
+
+// CreateToken creates a new token for a specific username and duration
+func (maker *PasetoPublicMaker) CreateToken(username string, role string, duration time.Duration) (string, *Payload, error) {
+	return maker.CreateScopedToken(username, role, nil, duration)
+}
+
+// CreateScopedToken creates a new token whose permissions are limited to the given scopes
+func (maker *PasetoPublicMaker) CreateScopedToken(username string, role string, scopes []string, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewPayload(username, role, duration)
+	if err != nil {
+		return "", nil, fmt.Errorf("token.PasetoPublicMaker.CreateScopedToken: %w", err)
+	}
+	payload.Scopes = scopes
+
+	claims, err := json.Marshal(payload)
+	if err != nil {
+		return "", nil, fmt.Errorf("token.PasetoPublicMaker.CreateScopedToken: %w", err)
+	}
+
+	f, err := json.Marshal(footer{KeyID: maker.keyID})
+	if err != nil {
+		return "", nil, fmt.Errorf("token.PasetoPublicMaker.CreateScopedToken: %w", err)
+	}
+
+	token, err := paseto.NewTokenFromClaimsJSON(claims, f)
+	if err != nil {
+		return "", nil, fmt.Errorf("token.PasetoPublicMaker.CreateScopedToken: %w", err)
+	}
+
+	return token.V4Sign(maker.secretKey, nil), payload, nil
+}
+
+// VerifyToken checks if the token is valid or not
+func (maker *PasetoPublicMaker) VerifyToken(token string) (*Payload, error) {
+	// The footer is only used to pick the verification key, it is
+	// authenticated together with the rest of the token below
+	rawFooter, err := maker.parser.UnsafeParseFooter(paseto.V4Public, token)
+	if err != nil {
+		return nil, fmt.Errorf("token.PasetoPublicMaker.VerifyToken: %w", ErrInvalidToken)
+	}
+
+	var f footer
+	if err := json.Unmarshal(rawFooter, &f); err != nil {
+		return nil, fmt.Errorf("token.PasetoPublicMaker.VerifyToken: %w", ErrInvalidToken)
+	}
+
+	key, ok := maker.publicKeys[f.KeyID]
+	if !ok {
+		return nil, fmt.Errorf("token.PasetoPublicMaker.VerifyToken: %w", ErrUnknownKey)
+	}
+
+	parsed, err := maker.parser.ParseV4Public(key.Key, token, nil)
+	if err != nil {
+		return nil, fmt.Errorf("token.PasetoPublicMaker.VerifyToken: %w", ErrInvalidToken)
+	}
+
+	payload := &Payload{}
+	if err := json.Unmarshal(parsed.ClaimsJSON(), payload); err != nil {
+		return nil, fmt.Errorf("token.PasetoPublicMaker.VerifyToken: %w", ErrInvalidToken)
+	}
+
+	if payload.ExpiredAt.Before(time.Now()) {
+		return nil, fmt.Errorf("token.PasetoPublicMaker.VerifyToken: %w", ErrExpiredToken)
+	}
+
+	return payload, nil
+}
+
+// PublicKeys returns the signing public key followed by the retired ones
+func (maker *PasetoPublicMaker) PublicKeys() []PublicKey {
+	var retired []PublicKey
+	for keyID, key := range maker.publicKeys {
+		if keyID != maker.keyID {
+			retired = append(retired, key)
+		}
+	}
+	slices.SortFunc(retired, func(a, b PublicKey) int {
+		return strings.Compare(a.KeyID, b.KeyID)
+	})
+
+	return append([]PublicKey{maker.publicKeys[maker.keyID]}, retired...)
+}
@@ -0,0 +1,145 @@
+package token
+
+import (
+	"testing"
+	"time"
+
+	"aidanwoods.dev/go-paseto"
+	"github.com/stretchr/testify/require"
+	"github.com/yelaco/simple-bank/util"
+)
+
+func TestNewPasetoPublicMaker(t *testing.T) {
+	secretKey := paseto.NewV4AsymmetricSecretKey()
+	maker, err := NewPasetoPublicMaker("key-1", secretKey.ExportSeedHex(), nil)
+	require.NoError(t, err)
+
+	username := util.RandomOwner()
+	duration := time.Minute
+	role := util.DepositorRole
+
+	issuedAt := time.Now()
+	expiredAt := issuedAt.Add(duration)
+
+	token, payload, err := maker.CreateToken(username, role, duration)
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+	require.NotEmpty(t, payload)
+	require.Regexp(t, `^v4\.public\.`, token)
+
+	payload, err = maker.VerifyToken(token)
+	require.NoError(t, err)
+	require.NotEmpty(t, payload)
+
+	require.NotZero(t, payload.ID)
+	require.Equal(t, username, payload.Username)
+	require.Equal(t, role, payload.Role)
+	require.WithinDuration(t, issuedAt, payload.IssuedAt, time.Second)
+	require.WithinDuration(t, expiredAt, payload.ExpiredAt, time.Second)
+
+	keys := maker.(PublicKeyProvider).PublicKeys()
+	require.Len(t, keys, 1)
+	require.Equal(t, "key-1", keys[0].KeyID)
+	require.Equal(t, secretKey.Public().ExportHex(), keys[0].Key.ExportHex())
+}
+
+func TestExpiredPasetoPublicToken(t *testing.T) {
+	maker, err := NewPasetoPublicMaker("key-1", paseto.NewV4AsymmetricSecretKey().ExportSeedHex(), nil)
+	require.NoError(t, err)
+
+	token, payload, err := maker.CreateToken(util.RandomOwner(), util.DepositorRole, -time.Minute)
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+	require.NotEmpty(t, payload)
+
+	payload, err = maker.VerifyToken(token)
+	require.ErrorIs(t, err, ErrExpiredToken)
+	require.Nil(t, payload)
+}
+
+func TestPasetoPublicKeyRotation(t *testing.T) {
+	oldKey := paseto.NewV4AsymmetricSecretKey()
+	oldMaker, err := NewPasetoPublicMaker("key-1", oldKey.ExportSeedHex(), nil)
+	require.NoError(t, err)
+
+	oldToken, _, err := oldMaker.CreateToken(util.RandomOwner(), util.DepositorRole, time.Minute)
+	require.NoError(t, err)
+
+	// the old key is kept for verification after the rotation
+	retiredKey, err := ParsePublicKey("key-1:" + oldKey.Public().ExportHex())
+	require.NoError(t, err)
+
+	newKey := paseto.NewV4AsymmetricSecretKey()
+	maker, err := NewPasetoPublicMaker("key-2", newKey.ExportSeedHex(), []PublicKey{retiredKey})
+	require.NoError(t, err)
+
+	payload, err := maker.VerifyToken(oldToken)
+	require.NoError(t, err)
+	require.NotEmpty(t, payload)
+
+	newToken, _, err := maker.CreateToken(util.RandomOwner(), util.DepositorRole, time.Minute)
+	require.NoError(t, err)
+
+	// the old maker does not know the new key
+	payload, err = oldMaker.VerifyToken(newToken)
+	require.ErrorIs(t, err, ErrUnknownKey)
+	require.Nil(t, payload)
+
+	keys := maker.(PublicKeyProvider).PublicKeys()
+	require.Len(t, keys, 2)
+	require.Equal(t, "key-2", keys[0].KeyID)
+	require.Equal(t, "key-1", keys[1].KeyID)
+
+	// once the old key is dropped its tokens are rejected
+	maker, err = NewPasetoPublicMaker("key-2", newKey.ExportSeedHex(), nil)
+	require.NoError(t, err)
+
+	payload, err = maker.VerifyToken(oldToken)
+	require.ErrorIs(t, err, ErrUnknownKey)
+	require.Nil(t, payload)
+}
+
+func TestInvalidPasetoPublicTokenWrongKey(t *testing.T) {
+	maker, err := NewPasetoPublicMaker("key-1", paseto.NewV4AsymmetricSecretKey().ExportSeedHex(), nil)
+	require.NoError(t, err)
+
+	// a token signed by another key that claims the same key id
+	otherMaker, err := NewPasetoPublicMaker("key-1", paseto.NewV4AsymmetricSecretKey().ExportSeedHex(), nil)
+	require.NoError(t, err)
+
+	token, _, err := otherMaker.CreateToken(util.RandomOwner(), util.BankerRole, time.Minute)
+	require.NoError(t, err)
+
+	payload, err := maker.VerifyToken(token)
+	require.ErrorIs(t, err, ErrInvalidToken)
+	require.Nil(t, payload)
+
+	// a symmetric token is not accepted either
+	symmetricMaker, err := NewPasetoMaker(util.RandomString(32))
+	require.NoError(t, err)
+
+	token, _, err = symmetricMaker.CreateToken(util.RandomOwner(), util.BankerRole, time.Minute)
+	require.NoError(t, err)
+
+	payload, err = maker.VerifyToken(token)
+	require.ErrorIs(t, err, ErrInvalidToken)
+	require.Nil(t, payload)
+}
+
+func TestParsePublicKey(t *testing.T) {
+	publicKey := paseto.NewV4AsymmetricSecretKey().Public()
+
+	key, err := ParsePublicKey("key-1:" + publicKey.ExportHex())
+	require.NoError(t, err)
+	require.Equal(t, "key-1", key.KeyID)
+	require.Equal(t, publicKey.ExportHex(), key.Key.ExportHex())
+
+	for _, value := range []string{
+		publicKey.ExportHex(),
+		":" + publicKey.ExportHex(),
+		"key-1:not-hex",
+	} {
+		_, err := ParsePublicKey(value)
+		require.Error(t, err, value)
+	}
+}
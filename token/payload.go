@@ -19,6 +19,9 @@ type Payload struct {
 	Role      string    `json:"role"`
 	IssuedAt  time.Time `json:"issued_at"`
 	ExpiredAt time.Time `json:"expired_at"`
+	// Scopes limits the permissions of the role, it is only set for API keys
+	// and tokens issued to OAuth2 clients
+	Scopes []string `json:"scopes,omitempty"`
 }
 
 // NewPayload creates a new token payload with a specific username and duration
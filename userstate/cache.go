@@ -0,0 +1,109 @@
+// Package userstate caches the per-user state that decides whether an access
+// token has been revoked, so that verifying a token does not cost a database
+// query on every request.
+package userstate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	db "github.com/yelaco/simple-bank/db/sqlc"
+	"github.com/yelaco/simple-bank/token"
+)
+
+// ErrTokenRevoked is returned for tokens issued before the last password change
+// or before all tokens of the user were revoked
+var ErrTokenRevoked = errors.New("token has been revoked")
+
+type entry struct {
+	state     db.GetUserTokenStateRow
+	expiresAt time.Time
+}
+
+// Cache keeps the token state of recently seen users for a short duration.
+// Changes made through this instance are seen immediately after Invalidate,
+// changes made elsewhere are picked up once the cached entry expires.
+type Cache struct {
+	store    db.Store
+	duration time.Duration
+
+	mu      sync.Mutex
+	entries map[string]entry
+	sweptAt time.Time
+}
+
+// NewCache creates a new Cache whose entries live for the given duration
+func NewCache(store db.Store, duration time.Duration) *Cache {
+	return &Cache{
+		store:    store,
+		duration: duration,
+		entries:  make(map[string]entry),
+	}
+}
+
+// CheckToken returns ErrTokenRevoked if the token was issued before the user
+// last changed their password or revoked all of their tokens
+func (cache *Cache) CheckToken(ctx context.Context, payload *token.Payload) error {
+	state, err := cache.get(ctx, payload.Username)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return fmt.Errorf("userstate.Cache.CheckToken: %w", ErrTokenRevoked)
+		}
+		return fmt.Errorf("userstate.Cache.CheckToken: %w", err)
+	}
+
+	if payload.IssuedAt.Before(state.PasswordChangedAt) || payload.IssuedAt.Before(state.TokensRevokedAt) {
+		return fmt.Errorf("userstate.Cache.CheckToken: %w", ErrTokenRevoked)
+	}
+
+	return nil
+}
+
+// Invalidate drops the cached state of a user, it must be called after the
+// password or the token revocation time of the user is changed
+func (cache *Cache) Invalidate(username string) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	delete(cache.entries, username)
+}
+
+func (cache *Cache) get(ctx context.Context, username string) (db.GetUserTokenStateRow, error) {
+	now := time.Now()
+
+	cache.mu.Lock()
+	cached, ok := cache.entries[username]
+	cache.mu.Unlock()
+
+	if ok && now.Before(cached.expiresAt) {
+		return cached.state, nil
+	}
+
+	state, err := cache.store.GetUserTokenState(ctx, username)
+	if err != nil {
+		return db.GetUserTokenStateRow{}, err
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	// drop expired entries once in a while, so that the cache does not grow
+	// with every user that ever logged in
+	if now.Sub(cache.sweptAt) >= cache.duration {
+		for key, e := range cache.entries {
+			if !now.Before(e.expiresAt) {
+				delete(cache.entries, key)
+			}
+		}
+		cache.sweptAt = now
+	}
+	cache.entries[username] = entry{
+		state:     state,
+		expiresAt: now.Add(cache.duration),
+	}
+
+	return state, nil
+}
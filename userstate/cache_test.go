@@ -0,0 +1,137 @@
+package userstate
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/yelaco/simple-bank/db/mock"
+	db "github.com/yelaco/simple-bank/db/sqlc"
+	"github.com/yelaco/simple-bank/token"
+	"github.com/yelaco/simple-bank/util"
+	"go.uber.org/mock/gomock"
+)
+
+func newPayload(t *testing.T, username string) *token.Payload {
+	payload, err := token.NewPayload(username, util.DepositorRole, time.Minute)
+	require.NoError(t, err)
+	return payload
+}
+
+func TestCheckToken(t *testing.T) {
+	username := util.RandomOwner()
+
+	testCases := []struct {
+		name       string
+		buildStubs func(store *mockdb.MockStore)
+		checkError func(t *testing.T, err error)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserTokenState(gomock.Any(), gomock.Eq(username)).
+					Times(1).
+					Return(db.GetUserTokenStateRow{PasswordChangedAt: time.Now().Add(-time.Minute)}, nil)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "PasswordChanged",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserTokenState(gomock.Any(), gomock.Eq(username)).
+					Times(1).
+					Return(db.GetUserTokenStateRow{PasswordChangedAt: time.Now().Add(time.Second)}, nil)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrTokenRevoked)
+			},
+		},
+		{
+			name: "TokensRevoked",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserTokenState(gomock.Any(), gomock.Eq(username)).
+					Times(1).
+					Return(db.GetUserTokenStateRow{TokensRevokedAt: time.Now().Add(time.Second)}, nil)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrTokenRevoked)
+			},
+		},
+		{
+			name: "UserNotFound",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserTokenState(gomock.Any(), gomock.Eq(username)).
+					Times(1).
+					Return(db.GetUserTokenStateRow{}, db.ErrRecordNotFound)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrTokenRevoked)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserTokenState(gomock.Any(), gomock.Eq(username)).
+					Times(1).
+					Return(db.GetUserTokenStateRow{}, sql.ErrConnDone)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.Error(t, err)
+				require.NotErrorIs(t, err, ErrTokenRevoked)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			cache := NewCache(store, time.Minute)
+			err := cache.CheckToken(context.Background(), newPayload(t, username))
+			tc.checkError(t, err)
+		})
+	}
+}
+
+func TestCacheInvalidate(t *testing.T) {
+	username := util.RandomOwner()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	gomock.InOrder(
+		store.EXPECT().
+			GetUserTokenState(gomock.Any(), gomock.Eq(username)).
+			Times(1).
+			Return(db.GetUserTokenStateRow{}, nil),
+		store.EXPECT().
+			GetUserTokenState(gomock.Any(), gomock.Eq(username)).
+			Times(1).
+			Return(db.GetUserTokenStateRow{PasswordChangedAt: time.Now().Add(time.Second)}, nil),
+	)
+
+	cache := NewCache(store, time.Minute)
+	payload := newPayload(t, username)
+
+	// the state is only loaded once while it is cached
+	for range 3 {
+		require.NoError(t, cache.CheckToken(context.Background(), payload))
+	}
+
+	cache.Invalidate(username)
+	require.ErrorIs(t, cache.CheckToken(context.Background(), payload), ErrTokenRevoked)
+}
@@ -9,19 +9,42 @@ import (
 // Config stores all confdigurations of the application
 // The values are read by viper from a config file or environment variables.
 type Config struct {
-	Environment          string        `mapstructure:"ENVIRONMENT"`
-	AllowedOrigins       []string      `mapstructure:"ALLOWED_ORIGINS"`
-	DBSource             string        `mapstructure:"DB_SOURCE"`
-	MigrationURL         string        `mapstructure:"MIGRATION_URL"`
-	RedisAddress         string        `mapstructure:"REDIS_ADDRESS"`
-	HTTPServerAddress    string        `mapstructure:"HTTP_SERVER_ADDRESS"`
-	GRPCServerAddress    string        `mapstructure:"GRPC_SERVER_ADDRESS"`
-	TokenSymmetricKey    string        `mapstructure:"TOKEN_SYMMETRIC_KEY"`
-	AccessTokenDuration  time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
-	RefreshTokenDuration time.Duration `mapstructure:"REFRESH_TOKEN_DURATION"`
-	EmailSenderName      string        `mapstructure:"EMAIL_SENDER_NAME"`
-	EmailSenderAddress   string        `mapstructure:"EMAIL_SENDER_ADDRESS"`
-	EmailSenderPassword  string        `mapstructure:"EMAIL_SENDER_PASSWORD"`
+	Environment            string        `mapstructure:"ENVIRONMENT"`
+	AllowedOrigins         []string      `mapstructure:"ALLOWED_ORIGINS"`
+	FrontendURL            string        `mapstructure:"FRONTEND_URL"`
+	DBSource               string        `mapstructure:"DB_SOURCE"`
+	MigrationURL           string        `mapstructure:"MIGRATION_URL"`
+	RedisAddress           string        `mapstructure:"REDIS_ADDRESS"`
+	HTTPServerAddress      string        `mapstructure:"HTTP_SERVER_ADDRESS"`
+	GRPCServerAddress      string        `mapstructure:"GRPC_SERVER_ADDRESS"`
+	TokenSymmetricKey      string        `mapstructure:"TOKEN_SYMMETRIC_KEY"`
+	TokenKeyID             string        `mapstructure:"TOKEN_KEY_ID"`
+	TokenPrivateKey        string        `mapstructure:"TOKEN_PRIVATE_KEY"`
+	TokenPublicKeys        []string      `mapstructure:"TOKEN_PUBLIC_KEYS"`
+	AccessTokenDuration    time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
+	RefreshTokenDuration   time.Duration `mapstructure:"REFRESH_TOKEN_DURATION"`
+	MFATokenDuration       time.Duration `mapstructure:"MFA_TOKEN_DURATION"`
+	UserStateCacheDuration time.Duration `mapstructure:"USER_STATE_CACHE_DURATION"`
+	RoleCacheDuration      time.Duration `mapstructure:"ROLE_CACHE_DURATION"`
+	LoginMaxAttempts       int32         `mapstructure:"LOGIN_MAX_ATTEMPTS"`
+	LoginLockoutDuration   time.Duration `mapstructure:"LOGIN_LOCKOUT_DURATION"`
+	LoginMaxIPAttempts     int64         `mapstructure:"LOGIN_MAX_IP_ATTEMPTS"`
+	LoginAttemptWindow     time.Duration `mapstructure:"LOGIN_ATTEMPT_WINDOW"`
+	HoldDuration           time.Duration `mapstructure:"HOLD_DURATION"`
+	FXRatesFile            string        `mapstructure:"FX_RATES_FILE"`
+	EmailSenderName        string        `mapstructure:"EMAIL_SENDER_NAME"`
+	EmailSenderAddress     string        `mapstructure:"EMAIL_SENDER_ADDRESS"`
+	EmailSenderPassword    string        `mapstructure:"EMAIL_SENDER_PASSWORD"`
+	TraceExporter          string        `mapstructure:"TRACE_EXPORTER"`
+	TraceOTLPEndpoint      string        `mapstructure:"TRACE_OTLP_ENDPOINT"`
+	TraceSampleRatio       float64       `mapstructure:"TRACE_SAMPLE_RATIO"`
+	HealthCheckTimeout     time.Duration `mapstructure:"HEALTH_CHECK_TIMEOUT"`
+	HealthCheckInterval    time.Duration `mapstructure:"HEALTH_CHECK_INTERVAL"`
+	ShutdownDrainDuration  time.Duration `mapstructure:"SHUTDOWN_DRAIN_DURATION"`
+	OutboxRelayInterval    time.Duration `mapstructure:"OUTBOX_RELAY_INTERVAL"`
+	OutboxRelayBatchSize   int32         `mapstructure:"OUTBOX_RELAY_BATCH_SIZE"`
+	OutboxMaxAttempts      int32         `mapstructure:"OUTBOX_MAX_ATTEMPTS"`
+	WebhookTimeout         time.Duration `mapstructure:"WEBHOOK_TIMEOUT"`
 }
 
 // LoadConfig reads configurations from file or environment variables
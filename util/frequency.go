@@ -0,0 +1,18 @@
+package util
+
+// Frequencies of scheduled transfers
+const (
+	FrequencyOnce    = "once"
+	FrequencyDaily   = "daily"
+	FrequencyWeekly  = "weekly"
+	FrequencyMonthly = "monthly"
+)
+
+// IsSupportedFrequency returns true if the frequency is supported
+func IsSupportedFrequency(frequency string) bool {
+	switch frequency {
+	case FrequencyOnce, FrequencyDaily, FrequencyWeekly, FrequencyMonthly:
+		return true
+	}
+	return false
+}
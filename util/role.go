@@ -4,3 +4,8 @@ const (
 	DepositorRole = "depositor"
 	BankerRole    = "banker"
 )
+
+// MFAChallengeRole is the role of the short-lived token issued after a correct
+// password for a user with two-factor authentication. No RPC accepts it except
+// the second step of the login.
+const MFAChallengeRole = "mfa_challenge"
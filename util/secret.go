@@ -0,0 +1,25 @@
+package util
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// GenerateSecretCode generates a random string of length n from a
+// cryptographically secure source, for codes sent in single-use links
+func GenerateSecretCode(n int) (string, error) {
+	var sb strings.Builder
+	k := big.NewInt(int64(len(alphabet)))
+
+	for range n {
+		i, err := rand.Int(rand.Reader, k)
+		if err != nil {
+			return "", fmt.Errorf("failed to generate secret code: %w", err)
+		}
+		sb.WriteByte(alphabet[i.Int64()])
+	}
+
+	return sb.String(), nil
+}
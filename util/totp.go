@@ -0,0 +1,67 @@
+package util
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/pquerna/otp/totp"
+)
+
+const (
+	// TOTPIssuer is the name shown next to the account in authenticator apps
+	TOTPIssuer = "Simple Bank"
+
+	// RecoveryCodeCount is the number of recovery codes issued on TOTP enrolment
+	RecoveryCodeCount = 10
+
+	recoveryCodeAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+	recoveryCodeHalf     = 5
+)
+
+// GenerateTOTPSecret generates a new TOTP secret for the account and the
+// otpauth:// provisioning URI to be shown to the user as a QR code
+func GenerateTOTPSecret(accountName string) (secret string, uri string, err error) {
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      TOTPIssuer,
+		AccountName: accountName,
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate totp secret: %w", err)
+	}
+
+	return key.Secret(), key.URL(), nil
+}
+
+// ValidateTOTPCode checks if the code is valid for the secret at the current time
+func ValidateTOTPCode(code string, secret string) bool {
+	return totp.Validate(code, secret)
+}
+
+// GenerateRecoveryCodes generates n single-use recovery codes in the form xxxxx-xxxxx
+func GenerateRecoveryCodes(n int) ([]string, error) {
+	codes := make([]string, 0, n)
+	for range n {
+		var sb strings.Builder
+		for i := range 2 * recoveryCodeHalf {
+			if i == recoveryCodeHalf {
+				sb.WriteByte('-')
+			}
+
+			k, err := rand.Int(rand.Reader, big.NewInt(int64(len(recoveryCodeAlphabet))))
+			if err != nil {
+				return nil, fmt.Errorf("failed to generate recovery code: %w", err)
+			}
+			sb.WriteByte(recoveryCodeAlphabet[k.Int64()])
+		}
+		codes = append(codes, sb.String())
+	}
+
+	return codes, nil
+}
+
+// IsRecoveryCode reports whether the value has the shape of a recovery code
+func IsRecoveryCode(value string) bool {
+	return len(value) == 2*recoveryCodeHalf+1 && value[recoveryCodeHalf] == '-'
+}
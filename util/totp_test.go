@@ -0,0 +1,43 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pquerna/otp/totp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTOTP(t *testing.T) {
+	account := RandomEmail()
+
+	secret, uri, err := GenerateTOTPSecret(account)
+	require.NoError(t, err)
+	require.NotEmpty(t, secret)
+	require.Contains(t, uri, "otpauth://totp/")
+	require.Contains(t, uri, secret)
+
+	code, err := totp.GenerateCode(secret, time.Now())
+	require.NoError(t, err)
+	require.True(t, ValidateTOTPCode(code, secret))
+
+	otherSecret, _, err := GenerateTOTPSecret(account)
+	require.NoError(t, err)
+	require.False(t, ValidateTOTPCode(code, otherSecret))
+	require.False(t, ValidateTOTPCode("", secret))
+}
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	codes, err := GenerateRecoveryCodes(RecoveryCodeCount)
+	require.NoError(t, err)
+	require.Len(t, codes, RecoveryCodeCount)
+
+	seen := make(map[string]bool)
+	for _, code := range codes {
+		require.True(t, IsRecoveryCode(code))
+		require.False(t, seen[code])
+		seen[code] = true
+	}
+
+	require.False(t, IsRecoveryCode("123456"))
+}
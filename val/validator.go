@@ -3,12 +3,22 @@ package val
 import (
 	"fmt"
 	"net/mail"
+	"net/netip"
+	"net/url"
 	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/yelaco/simple-bank/util"
+	"github.com/yelaco/simple-bank/webhook"
 )
 
 var (
-	isValidUsername = regexp.MustCompile(`^[a-z0-9_]+$`).MatchString
-	isValidFullName = regexp.MustCompile(`^[a-zA-Z\s]+$`).MatchString
+	isValidUsername       = regexp.MustCompile(`^[a-z0-9_]+$`).MatchString
+	isValidFullName       = regexp.MustCompile(`^[a-zA-Z\s]+$`).MatchString
+	isValidIdempotencyKey = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`).MatchString
+	isValidRole           = regexp.MustCompile(`^[a-z_]+$`).MatchString
+	isValidPermission     = regexp.MustCompile(`^[a-z_]+:[a-z_]+:(own|any)$`).MatchString
 )
 
 func ValidateString(value string, minLength int, maxLength int) error {
@@ -70,3 +80,182 @@ func ValidateEmailId(value int64) error {
 func ValidateSecretCode(value string) error {
 	return ValidateString(value, 32, 128)
 }
+
+func ValidateCurrency(value string) error {
+	if !util.IsSupportedCurrency(value) {
+		return fmt.Errorf("is not a supported currency")
+	}
+
+	return nil
+}
+
+func ValidateID(value int64) error {
+	if value <= 0 {
+		return fmt.Errorf("must be a positive integer")
+	}
+
+	return nil
+}
+
+func ValidatePageID(value int32) error {
+	if value < 1 {
+		return fmt.Errorf("must be at least 1")
+	}
+
+	return nil
+}
+
+func ValidatePageSize(value int32) error {
+	if value < 5 || value > 10 {
+		return fmt.Errorf("must be from 5-10")
+	}
+
+	return nil
+}
+
+func ValidateAmount(value int64) error {
+	if value <= 0 {
+		return fmt.Errorf("must be a positive integer")
+	}
+
+	return nil
+}
+
+func ValidateIdempotencyKey(value string) error {
+	if err := ValidateString(value, 8, 128); err != nil {
+		return err
+	}
+
+	if !isValidIdempotencyKey(value) {
+		return fmt.Errorf("must contain only letters, digits, underscore or dash")
+	}
+	return nil
+}
+
+func ValidateOverdraftLimit(value int64) error {
+	if value < 0 {
+		return fmt.Errorf("must not be negative")
+	}
+
+	return nil
+}
+
+func ValidateFrequency(value string) error {
+	if !util.IsSupportedFrequency(value) {
+		return fmt.Errorf("must be one of once, daily, weekly or monthly")
+	}
+
+	return nil
+}
+
+func ValidateSessionID(value string) error {
+	if _, err := uuid.Parse(value); err != nil {
+		return fmt.Errorf("must be a valid UUID")
+	}
+
+	return nil
+}
+
+func ValidateMFACode(value string) error {
+	return ValidateString(value, 6, 11)
+}
+
+func ValidateRole(value string) error {
+	if err := ValidateString(value, 3, 50); err != nil {
+		return err
+	}
+
+	if !isValidRole(value) {
+		return fmt.Errorf("must contain only lowercase letters or underscore")
+	}
+	return nil
+}
+
+func ValidatePermission(value string) error {
+	if !isValidPermission(value) {
+		return fmt.Errorf("must be in the form <resource>:<action>:<own|any>")
+	}
+
+	return nil
+}
+
+func ValidateAPIKeyName(value string) error {
+	return ValidateString(value, 3, 100)
+}
+
+func ValidateAPIKeyID(value string) error {
+	if _, err := uuid.Parse(value); err != nil {
+		return fmt.Errorf("must be a valid UUID")
+	}
+
+	return nil
+}
+
+func ValidateExpiresInDays(value int32) error {
+	if value < 1 || value > 365 {
+		return fmt.Errorf("must be from 1-365")
+	}
+
+	return nil
+}
+
+func ValidateOAuthClientName(value string) error {
+	return ValidateString(value, 3, 100)
+}
+
+// ValidateRedirectURI accepts absolute http(s) URIs without a fragment, as
+// required for OAuth2 redirection endpoints
+func ValidateRedirectURI(value string) error {
+	if err := ValidateString(value, 1, 2000); err != nil {
+		return err
+	}
+
+	uri, err := url.Parse(value)
+	if err != nil || !uri.IsAbs() || uri.Host == "" {
+		return fmt.Errorf("is not an absolute URI")
+	}
+
+	if uri.Scheme != "https" && uri.Scheme != "http" {
+		return fmt.Errorf("must use http or https")
+	}
+
+	if uri.Fragment != "" {
+		return fmt.Errorf("must not contain a fragment")
+	}
+
+	return nil
+}
+
+// ValidateWebhookURL accepts absolute https URLs of public hosts, so that the
+// events are never sent in clear text nor to the internal network
+func ValidateWebhookURL(value string) error {
+	if err := ValidateString(value, 1, 2000); err != nil {
+		return err
+	}
+
+	uri, err := url.Parse(value)
+	if err != nil || !uri.IsAbs() || uri.Host == "" {
+		return fmt.Errorf("is not an absolute URL")
+	}
+
+	if uri.Scheme != "https" {
+		return fmt.Errorf("must use https")
+	}
+
+	if uri.User != nil {
+		return fmt.Errorf("must not contain credentials")
+	}
+
+	// The sender checks the resolved address too, this only rejects the
+	// hosts that are known to be internal without resolving them
+	host := strings.ToLower(uri.Hostname())
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return fmt.Errorf("must not be a local host")
+	}
+
+	if addr, err := netip.ParseAddr(host); err == nil && !webhook.IsPublicAddr(addr) {
+		return fmt.Errorf("must not be a loopback, private or link-local address")
+	}
+
+	return nil
+}
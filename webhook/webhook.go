@@ -0,0 +1,226 @@
+// Package webhook signs and sends the events delivered to the webhook
+// endpoints registered by users.
+//
+// Every delivery is a POST of the JSON event with the X-Webhook-Signature
+// header "t=<unix time>,v1=<hex HMAC-SHA256 of "<unix time>.<body>">", keyed
+// with the secret of the endpoint. Receivers should check the signature and
+// reject old timestamps to prevent replays.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/yelaco/simple-bank/util"
+)
+
+// Headers of a delivery
+const (
+	SignatureHeader = "X-Webhook-Signature"
+	EventIDHeader   = "X-Webhook-Id"
+	EventTypeHeader = "X-Webhook-Event"
+)
+
+const (
+	signatureVersion = "v1"
+	secretPrefix     = "whsec_"
+	secretSize       = 32
+	// maxResponseSize is how much of the response body is read, to reuse the connection
+	maxResponseSize = 4096
+)
+
+var (
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrSignatureExpired = errors.New("webhook signature has expired")
+	ErrForbiddenAddress = errors.New("webhook endpoint resolves to a forbidden address")
+)
+
+// reservedPrefixes are the special purpose ranges not covered by the netip
+// helpers, that can still route to internal hosts
+var reservedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("100.64.0.0/10"), // shared address space (carrier-grade NAT)
+	netip.MustParsePrefix("192.0.0.0/24"),  // IETF protocol assignments
+	netip.MustParsePrefix("198.18.0.0/15"), // benchmarking
+	netip.MustParsePrefix("240.0.0.0/4"),   // reserved, including the broadcast address
+}
+
+// IsPublicAddr tells if deliveries may be sent to the address. Loopback,
+// private, link-local, multicast, unspecified and reserved addresses are
+// refused, so that endpoints cannot be used to reach the internal network.
+func IsPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() ||
+		addr.IsLoopback() ||
+		addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() ||
+		addr.IsMulticast() ||
+		addr.IsUnspecified() {
+		return false
+	}
+
+	for _, prefix := range reservedPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// NewSecret generates the secret an endpoint uses to check the deliveries
+func NewSecret() (string, error) {
+	secret, err := util.GenerateSecretCode(secretSize)
+	if err != nil {
+		return "", fmt.Errorf("webhook.NewSecret: %w", err)
+	}
+
+	return secretPrefix + secret, nil
+}
+
+// Sign returns the signature header of a body sent at the given time
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	unix := strconv.FormatInt(timestamp.Unix(), 10)
+	return fmt.Sprintf("t=%s,%s=%s", unix, signatureVersion, computeSignature(secret, unix, body))
+}
+
+func computeSignature(secret string, unix string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unix))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature checks the signature header of a delivery received at now.
+// Signatures older than tolerance are rejected.
+func VerifySignature(secret string, header string, body []byte, tolerance time.Duration, now time.Time) error {
+	var unix, signature string
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(part, "=")
+		switch key {
+		case "t":
+			unix = value
+		case signatureVersion:
+			signature = value
+		}
+	}
+
+	timestamp, err := strconv.ParseInt(unix, 10, 64)
+	if err != nil || signature == "" {
+		return ErrInvalidSignature
+	}
+
+	expected := computeSignature(secret, unix, body)
+	if !hmac.Equal([]byte(signature), []byte(expected)) {
+		return ErrInvalidSignature
+	}
+
+	if now.Sub(time.Unix(timestamp, 0)) > tolerance {
+		return ErrSignatureExpired
+	}
+
+	return nil
+}
+
+// Request is a delivery of an event to an endpoint
+type Request struct {
+	URL       string
+	Secret    string
+	EventID   string
+	EventType string
+	Body      []byte
+}
+
+// Sender sends the deliveries to the endpoints
+type Sender interface {
+	// Send posts the signed delivery and returns the status code of the
+	// response, which is zero when no response was received. It fails
+	// unless the endpoint responds with a 2xx status.
+	Send(ctx context.Context, req Request) (int, error)
+}
+
+type HTTPSender struct {
+	client *http.Client
+}
+
+// NewHTTPSender creates a sender giving up on endpoints that take longer than timeout to respond.
+// It only connects to public addresses.
+func NewHTTPSender(timeout time.Duration) Sender {
+	return newHTTPSender(timeout, IsPublicAddr)
+}
+
+func newHTTPSender(timeout time.Duration, allowAddr func(netip.Addr) bool) *HTTPSender {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		// The address is checked once resolved, right before connecting, so
+		// that the host of an endpoint cannot be pointed to an internal
+		// address after it was validated
+		Control: func(network, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return fmt.Errorf("invalid address %q: %w", address, err)
+			}
+			if !allowAddr(addrPort.Addr()) {
+				return fmt.Errorf("%w: %s", ErrForbiddenAddress, addrPort.Addr())
+			}
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// A proxy would be dialed instead of the endpoint, bypassing the check
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &HTTPSender{
+		client: &http.Client{
+			Timeout:   timeout,
+			Transport: transport,
+			// A redirect could send the event to a URL the user did not register
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+func (sender *HTTPSender) Send(ctx context.Context, req Request) (int, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL, bytes.NewReader(req.Body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("User-Agent", "simple-bank-webhook")
+	httpReq.Header.Set(EventIDHeader, req.EventID)
+	httpReq.Header.Set(EventTypeHeader, req.EventType)
+	httpReq.Header.Set(SignatureHeader, Sign(req.Secret, time.Now(), req.Body))
+
+	res, err := sender.client.Do(httpReq)
+	if err != nil {
+		return 0, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, maxResponseSize))
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return res.StatusCode, fmt.Errorf("endpoint responded with status %d", res.StatusCode)
+	}
+
+	return res.StatusCode, nil
+}
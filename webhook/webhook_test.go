@@ -0,0 +1,170 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignature(t *testing.T) {
+	secret, err := NewSecret()
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(secret, secretPrefix))
+
+	body := []byte(`{"type":"transfer.created"}`)
+	now := time.Now()
+	header := Sign(secret, now, body)
+
+	require.NoError(t, VerifySignature(secret, header, body, time.Minute, now))
+	require.ErrorIs(t, VerifySignature("whsec_other", header, body, time.Minute, now), ErrInvalidSignature)
+	require.ErrorIs(t, VerifySignature(secret, header, []byte(`{"type":"account.created"}`), time.Minute, now), ErrInvalidSignature)
+	require.ErrorIs(t, VerifySignature(secret, header, body, time.Minute, now.Add(2*time.Minute)), ErrSignatureExpired)
+	require.ErrorIs(t, VerifySignature(secret, "v1=abc", body, time.Minute, now), ErrInvalidSignature)
+}
+
+func TestHTTPSender(t *testing.T) {
+	secret, err := NewSecret()
+	require.NoError(t, err)
+	body := []byte(`{"id":"1"}`)
+
+	testCases := []struct {
+		name          string
+		statusCode    int
+		checkResponse func(t *testing.T, statusCode int, err error)
+	}{
+		{
+			name:       "OK",
+			statusCode: http.StatusNoContent,
+			checkResponse: func(t *testing.T, statusCode int, err error) {
+				require.NoError(t, err)
+				require.Equal(t, http.StatusNoContent, statusCode)
+			},
+		},
+		{
+			name:       "ServerError",
+			statusCode: http.StatusInternalServerError,
+			checkResponse: func(t *testing.T, statusCode int, err error) {
+				require.Error(t, err)
+				require.Equal(t, http.StatusInternalServerError, statusCode)
+			},
+		},
+		{
+			name:       "Redirect",
+			statusCode: http.StatusFound,
+			checkResponse: func(t *testing.T, statusCode int, err error) {
+				require.Error(t, err)
+				require.Equal(t, http.StatusFound, statusCode)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+				received, err := io.ReadAll(req.Body)
+				require.NoError(t, err)
+				require.Equal(t, body, received)
+				require.Equal(t, "1", req.Header.Get(EventIDHeader))
+				require.Equal(t, "transfer.created", req.Header.Get(EventTypeHeader))
+				require.NoError(t, VerifySignature(secret, req.Header.Get(SignatureHeader), received, time.Minute, time.Now()))
+
+				if tc.statusCode == http.StatusFound {
+					res.Header().Set("Location", "/elsewhere")
+				}
+				res.WriteHeader(tc.statusCode)
+			}))
+			defer server.Close()
+
+			// the test server listens on the loopback address
+			sender := newHTTPSender(time.Second, allowAnyAddr)
+			statusCode, err := sender.Send(context.Background(), Request{
+				URL:       server.URL,
+				Secret:    secret,
+				EventID:   "1",
+				EventType: "transfer.created",
+				Body:      body,
+			})
+			tc.checkResponse(t, statusCode, err)
+		})
+	}
+}
+
+func TestHTTPSenderUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	sender := newHTTPSender(time.Second, allowAnyAddr)
+	statusCode, err := sender.Send(context.Background(), Request{URL: server.URL, Body: []byte("{}")})
+	require.Error(t, err)
+	require.Zero(t, statusCode)
+}
+
+func allowAnyAddr(netip.Addr) bool {
+	return true
+}
+
+func TestHTTPSenderForbiddenAddress(t *testing.T) {
+	requested := false
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		requested = true
+	}))
+	defer server.Close()
+
+	// localhost resolves to a loopback address, which is only known once dialing
+	url := strings.Replace(server.URL, "127.0.0.1", "localhost", 1)
+
+	sender := NewHTTPSender(time.Second)
+	for _, endpoint := range []string{server.URL, url} {
+		statusCode, err := sender.Send(context.Background(), Request{URL: endpoint, Body: []byte("{}")})
+		require.ErrorIs(t, err, ErrForbiddenAddress)
+		require.Zero(t, statusCode)
+	}
+	require.False(t, requested)
+}
+
+func TestIsPublicAddr(t *testing.T) {
+	testCases := []struct {
+		addr   string
+		public bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.0.0.5", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"fd00::1", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"0.0.0.0", false},
+		{"::", false},
+		{"224.0.0.1", false},
+		{"::ffff:127.0.0.1", false},
+		{"::ffff:10.0.0.5", false},
+		{"100.64.0.1", false},
+		{"100.127.255.254", false},
+		{"100.128.0.1", true},
+		{"192.0.0.8", false},
+		{"192.0.1.1", true},
+		{"198.18.0.1", false},
+		{"198.19.255.254", false},
+		{"198.20.0.1", true},
+		{"240.0.0.1", false},
+		{"255.255.255.255", false},
+		{"::ffff:100.64.0.1", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.addr, func(t *testing.T) {
+			require.Equal(t, tc.public, IsPublicAddr(netip.MustParseAddr(tc.addr)))
+		})
+	}
+}
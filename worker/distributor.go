@@ -4,10 +4,19 @@ import (
 	"context"
 
 	"github.com/hibiken/asynq"
+	db "github.com/yelaco/simple-bank/db/sqlc"
 )
 
 type TaskDistributor interface {
 	DistributeTaskSendVerifyEmail(ctx context.Context, payload *PayloadSendVerifyEmail, opts ...asynq.Option) error
+	DistributeTaskSendPasswordResetEmail(ctx context.Context, payload *PayloadSendPasswordResetEmail, opts ...asynq.Option) error
+	DistributeTaskSendAccountLockedEmail(ctx context.Context, payload *PayloadSendAccountLockedEmail, opts ...asynq.Option) error
+	DistributeTaskExpireHold(ctx context.Context, payload *PayloadExpireHold, opts ...asynq.Option) error
+	DistributeTaskExecuteScheduledTransfer(ctx context.Context, payload *PayloadExecuteScheduledTransfer, opts ...asynq.Option) error
+	DistributeTaskSendScheduledTransferFailedEmail(ctx context.Context, payload *PayloadSendScheduledTransferFailedEmail, opts ...asynq.Option) error
+	DistributeTaskSendStatement(ctx context.Context, payload *PayloadSendStatement, opts ...asynq.Option) error
+	// DistributeOutboxMessage enqueues the task of an outbox message, it does nothing if the task was already enqueued
+	DistributeOutboxMessage(ctx context.Context, message db.OutboxMessage) error
 }
 
 type RedisTaskDistributor struct {
@@ -0,0 +1,20 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/yelaco/simple-bank/metrics"
+)
+
+// taskMetrics records the outcome and duration of every processed task
+func taskMetrics(handler asynq.Handler) asynq.Handler {
+	return asynq.HandlerFunc(func(ctx context.Context, task *asynq.Task) error {
+		startTime := time.Now()
+		err := handler.ProcessTask(ctx, task)
+		metrics.ObserveTask(task.Type(), err, time.Since(startTime))
+
+		return err
+	})
+}
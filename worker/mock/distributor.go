@@ -14,6 +14,7 @@ import (
 	reflect "reflect"
 
 	asynq "github.com/hibiken/asynq"
+	db "github.com/yelaco/simple-bank/db/sqlc"
 	worker "github.com/yelaco/simple-bank/worker"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -42,6 +43,134 @@ func (m *MockTaskDistributor) EXPECT() *MockTaskDistributorMockRecorder {
 	return m.recorder
 }
 
+// DistributeOutboxMessage mocks base method.
+func (m *MockTaskDistributor) DistributeOutboxMessage(ctx context.Context, message db.OutboxMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DistributeOutboxMessage", ctx, message)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeOutboxMessage indicates an expected call of DistributeOutboxMessage.
+func (mr *MockTaskDistributorMockRecorder) DistributeOutboxMessage(ctx, message any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeOutboxMessage", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeOutboxMessage), ctx, message)
+}
+
+// DistributeTaskExecuteScheduledTransfer mocks base method.
+func (m *MockTaskDistributor) DistributeTaskExecuteScheduledTransfer(ctx context.Context, payload *worker.PayloadExecuteScheduledTransfer, opts ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, payload}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskExecuteScheduledTransfer", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskExecuteScheduledTransfer indicates an expected call of DistributeTaskExecuteScheduledTransfer.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskExecuteScheduledTransfer(ctx, payload any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, payload}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskExecuteScheduledTransfer", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskExecuteScheduledTransfer), varargs...)
+}
+
+// DistributeTaskExpireHold mocks base method.
+func (m *MockTaskDistributor) DistributeTaskExpireHold(ctx context.Context, payload *worker.PayloadExpireHold, opts ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, payload}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskExpireHold", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskExpireHold indicates an expected call of DistributeTaskExpireHold.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskExpireHold(ctx, payload any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, payload}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskExpireHold", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskExpireHold), varargs...)
+}
+
+// DistributeTaskSendAccountLockedEmail mocks base method.
+func (m *MockTaskDistributor) DistributeTaskSendAccountLockedEmail(ctx context.Context, payload *worker.PayloadSendAccountLockedEmail, opts ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, payload}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskSendAccountLockedEmail", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskSendAccountLockedEmail indicates an expected call of DistributeTaskSendAccountLockedEmail.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskSendAccountLockedEmail(ctx, payload any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, payload}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskSendAccountLockedEmail", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskSendAccountLockedEmail), varargs...)
+}
+
+// DistributeTaskSendPasswordResetEmail mocks base method.
+func (m *MockTaskDistributor) DistributeTaskSendPasswordResetEmail(ctx context.Context, payload *worker.PayloadSendPasswordResetEmail, opts ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, payload}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskSendPasswordResetEmail", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskSendPasswordResetEmail indicates an expected call of DistributeTaskSendPasswordResetEmail.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskSendPasswordResetEmail(ctx, payload any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, payload}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskSendPasswordResetEmail", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskSendPasswordResetEmail), varargs...)
+}
+
+// DistributeTaskSendScheduledTransferFailedEmail mocks base method.
+func (m *MockTaskDistributor) DistributeTaskSendScheduledTransferFailedEmail(ctx context.Context, payload *worker.PayloadSendScheduledTransferFailedEmail, opts ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, payload}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskSendScheduledTransferFailedEmail", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskSendScheduledTransferFailedEmail indicates an expected call of DistributeTaskSendScheduledTransferFailedEmail.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskSendScheduledTransferFailedEmail(ctx, payload any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, payload}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskSendScheduledTransferFailedEmail", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskSendScheduledTransferFailedEmail), varargs...)
+}
+
+// DistributeTaskSendStatement mocks base method.
+func (m *MockTaskDistributor) DistributeTaskSendStatement(ctx context.Context, payload *worker.PayloadSendStatement, opts ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, payload}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskSendStatement", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskSendStatement indicates an expected call of DistributeTaskSendStatement.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskSendStatement(ctx, payload any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, payload}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskSendStatement", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskSendStatement), varargs...)
+}
+
 // DistributeTaskSendVerifyEmail mocks base method.
 func (m *MockTaskDistributor) DistributeTaskSendVerifyEmail(ctx context.Context, payload *worker.PayloadSendVerifyEmail, opts ...asynq.Option) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,84 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+	db "github.com/yelaco/simple-bank/db/sqlc"
+)
+
+const (
+	// outboxDefaultMaxRetry matches the default of asynq
+	outboxDefaultMaxRetry = 25
+	// outboxTaskRetention keeps processed outbox tasks in Redis, so that a
+	// message published again within this time is not processed twice
+	outboxTaskRetention = 24 * time.Hour
+)
+
+// NewOutboxTask prepares a task to be written to the outbox by a transaction,
+// instead of enqueuing it right away. Only the queue, max retry, process at
+// and process in options are supported.
+func NewOutboxTask(ctx context.Context, taskType string, payload any, opts ...asynq.Option) (db.OutboxTask, error) {
+	jsonPayload, err := marshalPayload(ctx, payload)
+	if err != nil {
+		return db.OutboxTask{}, fmt.Errorf("failed to marshal task payload: %w", err)
+	}
+
+	task := db.OutboxTask{
+		TaskType: taskType,
+		Payload:  jsonPayload,
+		Queue:    QueueDefault,
+		MaxRetry: outboxDefaultMaxRetry,
+	}
+
+	for _, opt := range opts {
+		switch opt.Type() {
+		case asynq.QueueOpt:
+			task.Queue = opt.Value().(string)
+		case asynq.MaxRetryOpt:
+			task.MaxRetry = int32(opt.Value().(int))
+		case asynq.ProcessAtOpt:
+			task.ProcessAt = opt.Value().(time.Time)
+		case asynq.ProcessInOpt:
+			task.ProcessAt = time.Now().Add(opt.Value().(time.Duration))
+		default:
+			return db.OutboxTask{}, fmt.Errorf("unsupported outbox task option: %s", opt)
+		}
+	}
+
+	return task, nil
+}
+
+// outboxTaskID identifies the task of an outbox message in asynq, so that it
+// is enqueued at most once
+func outboxTaskID(messageID int64) string {
+	return fmt.Sprintf("outbox:%d", messageID)
+}
+
+func (distributor *RedisTaskDistributor) DistributeOutboxMessage(ctx context.Context, message db.OutboxMessage) error {
+	task := asynq.NewTask(message.TaskType, message.Payload)
+	info, err := distributor.client.EnqueueContext(ctx, task,
+		asynq.TaskID(outboxTaskID(message.ID)),
+		asynq.Queue(message.Queue),
+		asynq.MaxRetry(int(message.MaxRetry)),
+		asynq.ProcessAt(message.ProcessAt),
+		asynq.Retention(outboxTaskRetention),
+	)
+	if errors.Is(err, asynq.ErrTaskIDConflict) {
+		// The message was enqueued before but could not be marked sent
+		log.Info().Str("type", task.Type()).Int64("outbox_id", message.ID).Msg("outbox task already enqueued")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).Int64("outbox_id", message.ID).
+		Str("queue", info.Queue).Int("max_retry", info.MaxRetry).Msg("enqueued task")
+
+	return nil
+}
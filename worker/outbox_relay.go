@@ -0,0 +1,89 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	db "github.com/yelaco/simple-bank/db/sqlc"
+	"github.com/yelaco/simple-bank/metrics"
+)
+
+// OutboxRelay enqueues the tasks written to the outbox by committed
+// transactions. A task is enqueued again if the relay stops before marking
+// it sent, the task ID makes asynq ignore the duplicate.
+type OutboxRelay struct {
+	store       db.Store
+	distributor TaskDistributor
+	interval    time.Duration
+	batchSize   int32
+	maxAttempts int32
+}
+
+// NewOutboxRelay creates a relay that looks for pending messages every
+// interval and publishes them in batches of batchSize. A message that fails
+// to be published is retried with a backoff starting at interval, and given
+// up after maxAttempts failures.
+func NewOutboxRelay(store db.Store, distributor TaskDistributor, interval time.Duration, batchSize int32, maxAttempts int32) *OutboxRelay {
+	return &OutboxRelay{
+		store:       store,
+		distributor: distributor,
+		interval:    interval,
+		batchSize:   batchSize,
+		maxAttempts: maxAttempts,
+	}
+}
+
+// Start relays the pending messages until ctx is done
+func (relay *OutboxRelay) Start(ctx context.Context) {
+	ticker := time.NewTicker(relay.interval)
+	defer ticker.Stop()
+
+	for {
+		relay.RelayPending(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RelayPending publishes pending messages batch by batch, until none is left
+// or a message fails to be published. It returns the number of sent messages.
+func (relay *OutboxRelay) RelayPending(ctx context.Context) int {
+	sent := 0
+
+	for ctx.Err() == nil {
+		result, err := relay.store.RelayOutboxTx(ctx, db.RelayOutboxTxParams{
+			Limit:       relay.batchSize,
+			MaxAttempts: relay.maxAttempts,
+			RetryDelay:  relay.interval,
+			Publish: func(message db.OutboxMessage) error {
+				return relay.distributor.DistributeOutboxMessage(ctx, message)
+			},
+		})
+		if err != nil {
+			log.Error().Err(err).Msg("failed to relay outbox messages")
+			return sent
+		}
+
+		sent += result.Sent
+		for _, message := range result.Abandoned {
+			metrics.ObserveOutboxAbandoned(message.TaskType)
+			log.Error().Int64("outbox_id", message.ID).Str("type", message.TaskType).
+				Int32("attempts", message.Attempts).Str("last_error", message.LastError.String).
+				Msg("gave up on outbox message")
+		}
+		if result.Failed > 0 {
+			log.Error().Int("failed", result.Failed).Msg("failed to publish outbox messages")
+			return sent
+		}
+		if result.Sent < int(relay.batchSize) {
+			return sent
+		}
+	}
+
+	return sent
+}
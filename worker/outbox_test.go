@@ -0,0 +1,177 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/yelaco/simple-bank/db/mock"
+	db "github.com/yelaco/simple-bank/db/sqlc"
+	"go.uber.org/mock/gomock"
+)
+
+func TestNewOutboxTask(t *testing.T) {
+	ctx := context.Background()
+	payload := &PayloadExpireHold{HoldID: 1}
+
+	task, err := NewOutboxTask(ctx, TaskExpireHold, payload)
+	require.NoError(t, err)
+	require.Equal(t, TaskExpireHold, task.TaskType)
+	require.Equal(t, QueueDefault, task.Queue)
+	require.Equal(t, int32(outboxDefaultMaxRetry), task.MaxRetry)
+	require.True(t, task.ProcessAt.IsZero())
+
+	var decoded PayloadExpireHold
+	require.NoError(t, json.Unmarshal(task.Payload, &decoded))
+	require.Equal(t, *payload, decoded)
+
+	processAt := time.Now().Add(time.Hour)
+	task, err = NewOutboxTask(ctx, TaskExpireHold, payload,
+		asynq.Queue(QueueCritical),
+		asynq.MaxRetry(3),
+		asynq.ProcessAt(processAt),
+	)
+	require.NoError(t, err)
+	require.Equal(t, QueueCritical, task.Queue)
+	require.Equal(t, int32(3), task.MaxRetry)
+	require.Equal(t, processAt, task.ProcessAt)
+
+	task, err = NewOutboxTask(ctx, TaskExpireHold, payload, asynq.ProcessIn(time.Minute))
+	require.NoError(t, err)
+	require.WithinDuration(t, time.Now().Add(time.Minute), task.ProcessAt, time.Second)
+
+	_, err = NewOutboxTask(ctx, TaskExpireHold, payload, asynq.Unique(time.Minute))
+	require.Error(t, err)
+}
+
+// fakeOutboxDistributor records the published outbox messages and fails for
+// the given message IDs
+type fakeOutboxDistributor struct {
+	TaskDistributor
+	failing   map[int64]bool
+	published []int64
+}
+
+func (distributor *fakeOutboxDistributor) DistributeOutboxMessage(ctx context.Context, message db.OutboxMessage) error {
+	if distributor.failing[message.ID] {
+		return errors.New("redis is down")
+	}
+	distributor.published = append(distributor.published, message.ID)
+	return nil
+}
+
+// relayOutboxTx runs the relay transaction on the given messages, as the
+// store does
+func relayOutboxTx(messages []db.OutboxMessage) func(ctx context.Context, arg db.RelayOutboxTxParams) (db.RelayOutboxTxResult, error) {
+	return func(ctx context.Context, arg db.RelayOutboxTxParams) (db.RelayOutboxTxResult, error) {
+		var result db.RelayOutboxTxResult
+		for _, message := range messages[:min(len(messages), int(arg.Limit))] {
+			if err := arg.Publish(message); err != nil {
+				result.Failed++
+				continue
+			}
+			result.Sent++
+		}
+		return result, nil
+	}
+}
+
+func TestOutboxRelay(t *testing.T) {
+	messages := make([]db.OutboxMessage, 5)
+	for i := range messages {
+		messages[i] = db.OutboxMessage{ID: int64(i + 1), TaskType: TaskSendVerifyEmail}
+	}
+
+	testCases := []struct {
+		name          string
+		failing       map[int64]bool
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, sent int, distributor *fakeOutboxDistributor)
+	}{
+		{
+			name: "RelayAllBatches",
+			buildStubs: func(store *mockdb.MockStore) {
+				gomock.InOrder(
+					store.EXPECT().
+						RelayOutboxTx(gomock.Any(), gomock.Any()).
+						Times(1).
+						DoAndReturn(relayOutboxTx(messages[:3])),
+					store.EXPECT().
+						RelayOutboxTx(gomock.Any(), gomock.Any()).
+						Times(1).
+						DoAndReturn(relayOutboxTx(messages[3:])),
+				)
+			},
+			checkResponse: func(t *testing.T, sent int, distributor *fakeOutboxDistributor) {
+				require.Equal(t, 5, sent)
+				require.Equal(t, []int64{1, 2, 3, 4, 5}, distributor.published)
+			},
+		},
+		{
+			name:    "StopOnPublishFailure",
+			failing: map[int64]bool{2: true},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					RelayOutboxTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(relayOutboxTx(messages))
+			},
+			checkResponse: func(t *testing.T, sent int, distributor *fakeOutboxDistributor) {
+				require.Equal(t, 2, sent)
+				require.Equal(t, []int64{1, 3}, distributor.published)
+			},
+		},
+		{
+			name: "GiveUpOnMessage",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					RelayOutboxTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(ctx context.Context, arg db.RelayOutboxTxParams) (db.RelayOutboxTxResult, error) {
+						require.Equal(t, int32(5), arg.MaxAttempts)
+						require.Equal(t, time.Minute, arg.RetryDelay)
+
+						abandoned := messages[0]
+						abandoned.Attempts = arg.MaxAttempts
+						return db.RelayOutboxTxResult{Failed: 1, Abandoned: []db.OutboxMessage{abandoned}}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, sent int, distributor *fakeOutboxDistributor) {
+				require.Zero(t, sent)
+			},
+		},
+		{
+			name: "StoreError",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					RelayOutboxTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RelayOutboxTxResult{}, db.ErrRecordNotFound)
+			},
+			checkResponse: func(t *testing.T, sent int, distributor *fakeOutboxDistributor) {
+				require.Zero(t, sent)
+				require.Empty(t, distributor.published)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+
+			tc.buildStubs(store)
+
+			distributor := &fakeOutboxDistributor{failing: tc.failing}
+			relay := NewOutboxRelay(store, distributor, time.Minute, 3, 5)
+
+			sent := relay.RelayPending(context.Background())
+			tc.checkResponse(t, sent, distributor)
+		})
+	}
+}
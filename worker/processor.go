@@ -4,12 +4,14 @@ package worker
 
 import (
 	"context"
+	"time"
 
 	"github.com/hibiken/asynq"
 	"github.com/redis/go-redis/v9"
 	"github.com/rs/zerolog/log"
 	db "github.com/yelaco/simple-bank/db/sqlc"
 	"github.com/yelaco/simple-bank/mail"
+	"github.com/yelaco/simple-bank/webhook"
 )
 
 const (
@@ -26,19 +28,56 @@ type TaskProcessor interface {
 
 	// ProcessTaskSendVerifyEmail processes the "send verify email" task.
 	ProcessTaskSendVerifyEmail(ctx context.Context, task *asynq.Task) error
+
+	// ProcessTaskSendPasswordResetEmail processes the "send password reset email" task.
+	ProcessTaskSendPasswordResetEmail(ctx context.Context, task *asynq.Task) error
+
+	// ProcessTaskSendAccountLockedEmail processes the "send account locked email" task.
+	ProcessTaskSendAccountLockedEmail(ctx context.Context, task *asynq.Task) error
+
+	// ProcessTaskExpireHold processes the "expire hold" task.
+	ProcessTaskExpireHold(ctx context.Context, task *asynq.Task) error
+
+	// ProcessTaskDispatchScheduledTransfers processes the periodic "dispatch scheduled transfers" task.
+	ProcessTaskDispatchScheduledTransfers(ctx context.Context, task *asynq.Task) error
+
+	// ProcessTaskExecuteScheduledTransfer processes the "execute scheduled transfer" task.
+	ProcessTaskExecuteScheduledTransfer(ctx context.Context, task *asynq.Task) error
+
+	// ProcessTaskSendScheduledTransferFailedEmail processes the "send scheduled transfer failed email" task.
+	ProcessTaskSendScheduledTransferFailedEmail(ctx context.Context, task *asynq.Task) error
+
+	// ProcessTaskDispatchMonthlyStatements processes the periodic "dispatch monthly statements" task.
+	ProcessTaskDispatchMonthlyStatements(ctx context.Context, task *asynq.Task) error
+
+	// ProcessTaskSendStatement processes the "send statement" task.
+	ProcessTaskSendStatement(ctx context.Context, task *asynq.Task) error
+
+	// ProcessTaskDeliverWebhook processes the "deliver webhook" task.
+	ProcessTaskDeliverWebhook(ctx context.Context, task *asynq.Task) error
 }
 
 // RedisTaskProcessor implements the TaskProcessor interface using Redis
 // as the message broker for asynchronous task processing.
 type RedisTaskProcessor struct {
-	server *asynq.Server // Redis server instance for task processing
-	store  db.Store      // Database store for data operations
-	mailer mail.EmailSender
+	server        *asynq.Server // Redis server instance for task processing
+	store         db.Store      // Database store for data operations
+	mailer        mail.EmailSender
+	distributor   TaskDistributor // Distributor for the follow-up tasks enqueued while processing
+	webhookSender webhook.Sender
+	frontendURL   string // Base URL of the links to the frontend sent in emails
 }
 
 // NewRedisTaskProcessor creates a new instance of RedisTaskProcessor
 // with the provided Redis client options and database store.
-func NewRedisTaskProcessor(redisOpt asynq.RedisClientOpt, store db.Store, mailer mail.EmailSender) TaskProcessor {
+func NewRedisTaskProcessor(
+	redisOpt asynq.RedisClientOpt,
+	store db.Store,
+	mailer mail.EmailSender,
+	distributor TaskDistributor,
+	webhookSender webhook.Sender,
+	frontendURL string,
+) TaskProcessor {
 	logger := NewLogger()
 	redis.SetLogger(logger)
 
@@ -51,22 +90,45 @@ func NewRedisTaskProcessor(redisOpt asynq.RedisClientOpt, store db.Store, mailer
 			log.Error().Err(err).Str("type", task.Type()).
 				Bytes("payload", task.Payload()).Msg("process task failed")
 		}),
-		Logger: logger,
+		RetryDelayFunc: retryDelay,
+		Logger:         logger,
 	})
 
 	return &RedisTaskProcessor{
-		server: server,
-		store:  store,
-		mailer: mailer,
+		server:        server,
+		store:         store,
+		mailer:        mailer,
+		distributor:   distributor,
+		webhookSender: webhookSender,
+		frontendURL:   frontendURL,
+	}
+}
+
+// retryDelay backs off exponentially for webhook deliveries, other tasks use
+// the default delay of asynq
+func retryDelay(retried int, err error, task *asynq.Task) time.Duration {
+	if task.Type() == TaskDeliverWebhook {
+		return webhookRetryDelay(retried)
 	}
+	return asynq.DefaultRetryDelayFunc(retried, err, task)
 }
 
 // Start initializes the Redis task processor and begins listening for tasks.
 // It registers the task handlers and starts the Redis server.
 func (processor *RedisTaskProcessor) Start() error {
 	mux := asynq.NewServeMux()
+	mux.Use(taskTracing, taskMetrics)
 
 	mux.HandleFunc(TaskSendVerifyEmail, processor.ProcessTaskSendVerifyEmail)
+	mux.HandleFunc(TaskSendPasswordResetEmail, processor.ProcessTaskSendPasswordResetEmail)
+	mux.HandleFunc(TaskSendAccountLockedEmail, processor.ProcessTaskSendAccountLockedEmail)
+	mux.HandleFunc(TaskExpireHold, processor.ProcessTaskExpireHold)
+	mux.HandleFunc(TaskDispatchScheduledTransfers, processor.ProcessTaskDispatchScheduledTransfers)
+	mux.HandleFunc(TaskExecuteScheduledTransfer, processor.ProcessTaskExecuteScheduledTransfer)
+	mux.HandleFunc(TaskSendScheduledTransferFailedEmail, processor.ProcessTaskSendScheduledTransferFailedEmail)
+	mux.HandleFunc(TaskDispatchMonthlyStatements, processor.ProcessTaskDispatchMonthlyStatements)
+	mux.HandleFunc(TaskSendStatement, processor.ProcessTaskSendStatement)
+	mux.HandleFunc(TaskDeliverWebhook, processor.ProcessTaskDeliverWebhook)
 
 	processor.server.Start(mux)
 
@@ -0,0 +1,77 @@
+package worker
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// dispatchScheduledTransfersSpec is how often due scheduled transfers are looked for
+	dispatchScheduledTransfersSpec = "@every 1m"
+	// dispatchMonthlyStatementsSpec sends the statements of the previous month early on the first day of each month, in UTC
+	dispatchMonthlyStatementsSpec = "0 6 1 * *"
+)
+
+// TaskScheduler defines the interface for enqueuing periodic tasks.
+type TaskScheduler interface {
+	// Start registers the periodic tasks and begins enqueuing them.
+	Start() error
+
+	Shutdown()
+}
+
+// RedisTaskScheduler implements the TaskScheduler interface using Redis
+// as the message broker. The enqueued tasks are processed by the TaskProcessor.
+type RedisTaskScheduler struct {
+	scheduler *asynq.Scheduler
+}
+
+// NewRedisTaskScheduler creates a new instance of RedisTaskScheduler
+// with the provided Redis client options.
+func NewRedisTaskScheduler(redisOpt asynq.RedisClientOpt) TaskScheduler {
+	scheduler := asynq.NewScheduler(redisOpt, &asynq.SchedulerOpts{
+		Logger: NewLogger(),
+		EnqueueErrorHandler: func(task *asynq.Task, opts []asynq.Option, err error) {
+			log.Error().Err(err).Str("type", task.Type()).Msg("enqueue periodic task failed")
+		},
+	})
+
+	return &RedisTaskScheduler{
+		scheduler: scheduler,
+	}
+}
+
+// Start registers the periodic tasks and starts the scheduler.
+func (scheduler *RedisTaskScheduler) Start() error {
+	// a run is skipped if the previous one is still queued, so a slow worker doesn't pile up dispatches
+	_, err := scheduler.scheduler.Register(
+		dispatchScheduledTransfersSpec,
+		asynq.NewTask(TaskDispatchScheduledTransfers, nil),
+		asynq.Queue(QueueCritical),
+		asynq.MaxRetry(0),
+		asynq.Unique(time.Minute),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to register task to dispatch scheduled transfers: %w", err)
+	}
+
+	_, err = scheduler.scheduler.Register(
+		dispatchMonthlyStatementsSpec,
+		asynq.NewTask(TaskDispatchMonthlyStatements, nil),
+		asynq.Queue(QueueDefault),
+		asynq.MaxRetry(5),
+		asynq.Unique(time.Hour),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to register task to dispatch monthly statements: %w", err)
+	}
+
+	return scheduler.scheduler.Start()
+}
+
+func (scheduler *RedisTaskScheduler) Shutdown() {
+	scheduler.scheduler.Shutdown()
+}
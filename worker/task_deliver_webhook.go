@@ -0,0 +1,129 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog/log"
+	db "github.com/yelaco/simple-bank/db/sqlc"
+	"github.com/yelaco/simple-bank/webhook"
+)
+
+// TaskDeliverWebhook is only enqueued through the outbox, by the transactions
+// emitting webhook events
+const TaskDeliverWebhook = "task:deliver_webhook"
+
+const webhookDeliveryMaxRetry = 10
+
+type PayloadDeliverWebhook struct {
+	DeliveryID int64 `json:"delivery_id"`
+}
+
+// NewDeliverWebhookTask returns the task sending a webhook delivery, the
+// store writes it to the outbox along with the delivery
+func NewDeliverWebhookTask(ctx context.Context, delivery db.WebhookDelivery) (db.OutboxTask, error) {
+	return NewOutboxTask(ctx, TaskDeliverWebhook, PayloadDeliverWebhook{DeliveryID: delivery.ID},
+		asynq.MaxRetry(webhookDeliveryMaxRetry),
+		asynq.Queue(QueueDefault),
+	)
+}
+
+const (
+	webhookRetryBaseDelay = 30 * time.Second
+	webhookRetryMaxDelay  = 6 * time.Hour
+)
+
+// webhookRetryDelay doubles the delay after every failed delivery, so that
+// endpoints that are down for a while are not flooded
+func webhookRetryDelay(retried int) time.Duration {
+	if retried >= 16 {
+		return webhookRetryMaxDelay
+	}
+	return min(webhookRetryBaseDelay<<retried, webhookRetryMaxDelay)
+}
+
+func (processor *RedisTaskProcessor) ProcessTaskDeliverWebhook(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadDeliverWebhook
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("%w: failed to unmarshal task payload: %w", asynq.SkipRetry, err)
+	}
+
+	delivery, err := processor.store.GetWebhookDelivery(ctx, payload.DeliveryID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return fmt.Errorf("%w: webhook delivery doesn't exist", asynq.SkipRetry)
+		}
+		return fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+
+	if delivery.Status != db.WebhookDeliveryStatusPending {
+		log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).
+			Str("status", delivery.Status).Msg("webhook delivery is already done")
+		return nil
+	}
+
+	endpoint, err := processor.store.GetWebhookEndpoint(ctx, delivery.EndpointID)
+	if err != nil {
+		return fmt.Errorf("failed to get webhook endpoint: %w", err)
+	}
+
+	if endpoint.DisabledAt.Valid {
+		_, err = processor.store.UpdateWebhookDeliveryAttempt(ctx, db.UpdateWebhookDeliveryAttemptParams{
+			ID:        delivery.ID,
+			Status:    db.WebhookDeliveryStatusFailed,
+			LastError: pgtype.Text{String: "endpoint is deleted", Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update webhook delivery: %w", err)
+		}
+		return fmt.Errorf("%w: webhook endpoint is deleted", asynq.SkipRetry)
+	}
+
+	statusCode, sendErr := processor.webhookSender.Send(ctx, webhook.Request{
+		URL:       endpoint.Url,
+		Secret:    endpoint.Secret,
+		EventID:   delivery.EventID.String(),
+		EventType: delivery.EventType,
+		Body:      delivery.Payload,
+	})
+
+	arg := db.UpdateWebhookDeliveryAttemptParams{
+		ID:     delivery.ID,
+		Status: db.WebhookDeliveryStatusSucceeded,
+		ResponseStatus: pgtype.Int4{
+			Int32: int32(statusCode),
+			Valid: statusCode != 0,
+		},
+	}
+	if sendErr == nil {
+		arg.DeliveredAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+	} else {
+		arg.Status = db.WebhookDeliveryStatusPending
+		arg.LastError = pgtype.Text{String: sendErr.Error(), Valid: true}
+
+		retried, _ := asynq.GetRetryCount(ctx)
+		maxRetry, ok := asynq.GetMaxRetry(ctx)
+		if ok && retried >= maxRetry {
+			arg.Status = db.WebhookDeliveryStatusFailed
+		}
+	}
+
+	_, err = processor.store.UpdateWebhookDeliveryAttempt(ctx, arg)
+	if err != nil {
+		return fmt.Errorf("failed to update webhook delivery: %w", err)
+	}
+
+	if sendErr != nil {
+		return fmt.Errorf("failed to deliver webhook: %w", sendErr)
+	}
+
+	log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).
+		Int64("endpoint_id", endpoint.ID).Int("status_code", statusCode).Msg("processed task")
+
+	return nil
+}
@@ -0,0 +1,188 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/yelaco/simple-bank/db/mock"
+	db "github.com/yelaco/simple-bank/db/sqlc"
+	"github.com/yelaco/simple-bank/webhook"
+	"go.uber.org/mock/gomock"
+)
+
+type fakeWebhookSender struct {
+	statusCode int
+	err        error
+	requests   []webhook.Request
+}
+
+func (sender *fakeWebhookSender) Send(ctx context.Context, req webhook.Request) (int, error) {
+	sender.requests = append(sender.requests, req)
+	return sender.statusCode, sender.err
+}
+
+func TestWebhookRetryDelay(t *testing.T) {
+	require.Equal(t, 30*time.Second, webhookRetryDelay(0))
+	require.Equal(t, time.Minute, webhookRetryDelay(1))
+	require.Equal(t, 8*time.Minute, webhookRetryDelay(4))
+	require.Equal(t, webhookRetryMaxDelay, webhookRetryDelay(10))
+	require.Equal(t, webhookRetryMaxDelay, webhookRetryDelay(100))
+}
+
+func TestNewDeliverWebhookTask(t *testing.T) {
+	task, err := NewDeliverWebhookTask(context.Background(), db.WebhookDelivery{ID: 7})
+	require.NoError(t, err)
+	require.Equal(t, TaskDeliverWebhook, task.TaskType)
+	require.Equal(t, QueueDefault, task.Queue)
+	require.Equal(t, int32(webhookDeliveryMaxRetry), task.MaxRetry)
+
+	var payload PayloadDeliverWebhook
+	require.NoError(t, json.Unmarshal(task.Payload, &payload))
+	require.Equal(t, int64(7), payload.DeliveryID)
+}
+
+func TestProcessTaskDeliverWebhook(t *testing.T) {
+	endpoint := db.WebhookEndpoint{
+		ID:         1,
+		Owner:      "alice",
+		Url:        "https://example.com/webhook",
+		Secret:     "whsec_secret",
+		EventTypes: []string{db.WebhookEventTransferCreated},
+	}
+	delivery := db.WebhookDelivery{
+		ID:         2,
+		EndpointID: endpoint.ID,
+		EventID:    uuid.New(),
+		EventType:  db.WebhookEventTransferCreated,
+		Payload:    []byte(`{"type":"transfer.created"}`),
+		Status:     db.WebhookDeliveryStatusPending,
+	}
+
+	disabledEndpoint := endpoint
+	disabledEndpoint.DisabledAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+
+	succeededDelivery := delivery
+	succeededDelivery.Status = db.WebhookDeliveryStatusSucceeded
+
+	testCases := []struct {
+		name          string
+		sender        *fakeWebhookSender
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, sender *fakeWebhookSender, err error)
+	}{
+		{
+			name:   "OK",
+			sender: &fakeWebhookSender{statusCode: 200},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetWebhookDelivery(gomock.Any(), delivery.ID).Times(1).Return(delivery, nil)
+				store.EXPECT().GetWebhookEndpoint(gomock.Any(), endpoint.ID).Times(1).Return(endpoint, nil)
+				store.EXPECT().
+					UpdateWebhookDeliveryAttempt(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(ctx context.Context, arg db.UpdateWebhookDeliveryAttemptParams) (db.WebhookDelivery, error) {
+						require.Equal(t, db.WebhookDeliveryStatusSucceeded, arg.Status)
+						require.Equal(t, int32(200), arg.ResponseStatus.Int32)
+						require.True(t, arg.DeliveredAt.Valid)
+						require.False(t, arg.LastError.Valid)
+						return delivery, nil
+					})
+			},
+			checkResponse: func(t *testing.T, sender *fakeWebhookSender, err error) {
+				require.NoError(t, err)
+				require.Len(t, sender.requests, 1)
+				require.Equal(t, endpoint.Url, sender.requests[0].URL)
+				require.Equal(t, endpoint.Secret, sender.requests[0].Secret)
+				require.Equal(t, delivery.EventID.String(), sender.requests[0].EventID)
+				require.Equal(t, delivery.Payload, sender.requests[0].Body)
+			},
+		},
+		{
+			name:   "EndpointFailing",
+			sender: &fakeWebhookSender{statusCode: 503, err: errors.New("endpoint responded with status 503")},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetWebhookDelivery(gomock.Any(), delivery.ID).Times(1).Return(delivery, nil)
+				store.EXPECT().GetWebhookEndpoint(gomock.Any(), endpoint.ID).Times(1).Return(endpoint, nil)
+				store.EXPECT().
+					UpdateWebhookDeliveryAttempt(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(ctx context.Context, arg db.UpdateWebhookDeliveryAttemptParams) (db.WebhookDelivery, error) {
+						require.Equal(t, db.WebhookDeliveryStatusPending, arg.Status)
+						require.Equal(t, int32(503), arg.ResponseStatus.Int32)
+						require.False(t, arg.DeliveredAt.Valid)
+						require.True(t, arg.LastError.Valid)
+						return delivery, nil
+					})
+			},
+			checkResponse: func(t *testing.T, sender *fakeWebhookSender, err error) {
+				require.Error(t, err)
+				require.NotErrorIs(t, err, asynq.SkipRetry)
+			},
+		},
+		{
+			name:   "EndpointDeleted",
+			sender: &fakeWebhookSender{},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetWebhookDelivery(gomock.Any(), delivery.ID).Times(1).Return(delivery, nil)
+				store.EXPECT().GetWebhookEndpoint(gomock.Any(), endpoint.ID).Times(1).Return(disabledEndpoint, nil)
+				store.EXPECT().
+					UpdateWebhookDeliveryAttempt(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(ctx context.Context, arg db.UpdateWebhookDeliveryAttemptParams) (db.WebhookDelivery, error) {
+						require.Equal(t, db.WebhookDeliveryStatusFailed, arg.Status)
+						return delivery, nil
+					})
+			},
+			checkResponse: func(t *testing.T, sender *fakeWebhookSender, err error) {
+				require.ErrorIs(t, err, asynq.SkipRetry)
+				require.Empty(t, sender.requests)
+			},
+		},
+		{
+			name:   "AlreadyDelivered",
+			sender: &fakeWebhookSender{},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetWebhookDelivery(gomock.Any(), delivery.ID).Times(1).Return(succeededDelivery, nil)
+				store.EXPECT().GetWebhookEndpoint(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, sender *fakeWebhookSender, err error) {
+				require.NoError(t, err)
+				require.Empty(t, sender.requests)
+			},
+		},
+		{
+			name:   "DeliveryNotFound",
+			sender: &fakeWebhookSender{},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetWebhookDelivery(gomock.Any(), delivery.ID).Times(1).Return(db.WebhookDelivery{}, db.ErrRecordNotFound)
+			},
+			checkResponse: func(t *testing.T, sender *fakeWebhookSender, err error) {
+				require.ErrorIs(t, err, asynq.SkipRetry)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+
+			tc.buildStubs(store)
+
+			processor := &RedisTaskProcessor{store: store, webhookSender: tc.sender}
+
+			payload, err := json.Marshal(PayloadDeliverWebhook{DeliveryID: delivery.ID})
+			require.NoError(t, err)
+
+			err = processor.ProcessTaskDeliverWebhook(context.Background(), asynq.NewTask(TaskDeliverWebhook, payload))
+			tc.checkResponse(t, tc.sender, err)
+		})
+	}
+}
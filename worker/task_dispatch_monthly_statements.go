@@ -0,0 +1,72 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+	db "github.com/yelaco/simple-bank/db/sqlc"
+)
+
+// TaskDispatchMonthlyStatements is enqueued by the TaskScheduler at the start of every month
+// to send the statement of the previous month for every open account.
+const TaskDispatchMonthlyStatements = "task:dispatch_monthly_statements"
+
+func (processor *RedisTaskProcessor) ProcessTaskDispatchMonthlyStatements(ctx context.Context, task *asynq.Task) error {
+	now := time.Now().UTC()
+	toTime := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	fromTime := toTime.AddDate(0, -1, 0)
+
+	dispatched := 0
+	for offset := int32(0); ; offset += dispatchBatchSize {
+		accounts, err := processor.store.ListAllAccounts(ctx, db.ListAllAccountsParams{
+			Limit:  dispatchBatchSize,
+			Offset: offset,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list accounts: %w", err)
+		}
+
+		for _, account := range accounts {
+			if account.IsClosed {
+				continue
+			}
+
+			// the task ID, kept for a day after the statement is sent,
+			// makes a retried dispatch skip the statements already queued or sent
+			taskID := fmt.Sprintf("statement:%d:%s", account.ID, fromTime.Format("2006-01"))
+
+			err := processor.distributor.DistributeTaskSendStatement(
+				ctx,
+				&PayloadSendStatement{
+					AccountID: account.ID,
+					FromTime:  fromTime,
+					ToTime:    toTime,
+				},
+				asynq.TaskID(taskID),
+				asynq.Retention(24*time.Hour),
+				asynq.MaxRetry(10),
+				asynq.Queue(QueueDefault),
+			)
+			if err != nil {
+				if errors.Is(err, asynq.ErrTaskIDConflict) {
+					continue
+				}
+				return fmt.Errorf("failed to distribute task to send statement: %w", err)
+			}
+			dispatched++
+		}
+
+		if len(accounts) < dispatchBatchSize {
+			break
+		}
+	}
+
+	log.Info().Str("type", task.Type()).Time("from_time", fromTime).
+		Int("dispatched", dispatched).Msg("processed task")
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+	db "github.com/yelaco/simple-bank/db/sqlc"
+)
+
+// TaskDispatchScheduledTransfers is enqueued periodically by the TaskScheduler
+// to look for due scheduled transfers and hand each of them to a TaskExecuteScheduledTransfer.
+const TaskDispatchScheduledTransfers = "task:dispatch_scheduled_transfers"
+
+// dispatchBatchSize is the maximum number of due scheduled transfers dispatched in one run.
+// The rest are picked up by the next run.
+const dispatchBatchSize = 100
+
+func (processor *RedisTaskProcessor) ProcessTaskDispatchScheduledTransfers(ctx context.Context, task *asynq.Task) error {
+	schedules, err := processor.store.ListDueScheduledTransfers(ctx, db.ListDueScheduledTransfersParams{
+		Now:        time.Now(),
+		LimitCount: dispatchBatchSize,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list due scheduled transfers: %w", err)
+	}
+
+	dispatched := 0
+	for _, schedule := range schedules {
+		// the task ID is unique per run, so a run that is still queued or being retried is not dispatched twice
+		taskID := fmt.Sprintf("scheduled_transfer:%d:%d", schedule.ID, schedule.NextRunAt.Unix())
+
+		err := processor.distributor.DistributeTaskExecuteScheduledTransfer(
+			ctx,
+			&PayloadExecuteScheduledTransfer{ScheduledTransferID: schedule.ID},
+			asynq.TaskID(taskID),
+			asynq.MaxRetry(5),
+			asynq.Queue(QueueCritical),
+		)
+		if err != nil {
+			if errors.Is(err, asynq.ErrTaskIDConflict) {
+				continue
+			}
+			return fmt.Errorf("failed to distribute task to execute scheduled transfer: %w", err)
+		}
+		dispatched++
+	}
+
+	log.Info().Str("type", task.Type()).Int("due", len(schedules)).
+		Int("dispatched", dispatched).Msg("processed task")
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+	db "github.com/yelaco/simple-bank/db/sqlc"
+	"github.com/yelaco/simple-bank/metrics"
+)
+
+const TaskExecuteScheduledTransfer = "task:execute_scheduled_transfer"
+
+type PayloadExecuteScheduledTransfer struct {
+	ScheduledTransferID int64 `json:"scheduled_transfer_id"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskExecuteScheduledTransfer(ctx context.Context, payload *PayloadExecuteScheduledTransfer, opts ...asynq.Option) error {
+	jsonPayload, err := marshalPayload(ctx, payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task payload: %w", err)
+	}
+
+	task := asynq.NewTask(TaskExecuteScheduledTransfer, jsonPayload, opts...)
+	info, err := distributor.client.EnqueueContext(ctx, task, opts...)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).
+		Str("queue", info.Queue).Int("max_retry", info.MaxRetry).Msg("enqueued task")
+
+	return nil
+}
+
+func (processor *RedisTaskProcessor) ProcessTaskExecuteScheduledTransfer(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadExecuteScheduledTransfer
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("%w: failed to unmarshal task payload: %w", asynq.SkipRetry, err)
+	}
+
+	result, err := processor.store.ExecuteScheduledTransferTx(ctx, payload.ScheduledTransferID)
+	if err != nil {
+		switch {
+		case errors.Is(err, db.ErrScheduledTransferNotDue):
+			// the run was already executed, or the schedule was cancelled in the meantime
+			log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).
+				Msg("scheduled transfer is not due")
+			return nil
+		case errors.Is(err, db.ErrRecordNotFound):
+			return fmt.Errorf("%w: scheduled transfer doesn't exist", asynq.SkipRetry)
+		case errors.Is(err, db.ErrInsufficientFunds), errors.Is(err, db.ErrAccountClosed):
+			// retrying won't help, the run is recorded as failed and the owner is told about it
+			return processor.failScheduledTransfer(ctx, task, payload.ScheduledTransferID, err)
+		}
+		return fmt.Errorf("failed to execute scheduled transfer: %w", err)
+	}
+
+	metrics.ObserveTransfer(result.FromAccount.Currency, result.Transfer.Amount)
+
+	log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).
+		Int64("transfer_id", result.Transfer.ID).Str("status", result.ScheduledTransfer.Status).
+		Msg("processed task")
+
+	return nil
+}
+
+func (processor *RedisTaskProcessor) failScheduledTransfer(ctx context.Context, task *asynq.Task, scheduledTransferID int64, reason error) error {
+	schedule, err := processor.store.FailScheduledTransferTx(ctx, db.FailScheduledTransferTxParams{
+		ID:     scheduledTransferID,
+		Reason: reason.Error(),
+		AfterFail: func(schedule db.ScheduledTransfer) ([]db.OutboxTask, error) {
+			task, err := NewOutboxTask(
+				ctx,
+				TaskSendScheduledTransferFailedEmail,
+				&PayloadSendScheduledTransferFailedEmail{ScheduledTransferID: schedule.ID},
+				asynq.MaxRetry(10),
+				asynq.Queue(QueueDefault),
+			)
+			if err != nil {
+				return nil, err
+			}
+			return []db.OutboxTask{task}, nil
+		},
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrScheduledTransferNotDue) {
+			return nil
+		}
+		return fmt.Errorf("failed to record scheduled transfer failure: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).
+		Str("reason", reason.Error()).Str("status", schedule.Status).Msg("scheduled transfer failed")
+
+	return nil
+}
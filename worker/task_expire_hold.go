@@ -0,0 +1,62 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+	db "github.com/yelaco/simple-bank/db/sqlc"
+)
+
+const TaskExpireHold = "task:expire_hold"
+
+type PayloadExpireHold struct {
+	HoldID int64 `json:"hold_id"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskExpireHold(ctx context.Context, payload *PayloadExpireHold, opts ...asynq.Option) error {
+	jsonPayload, err := marshalPayload(ctx, payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task payload: %w", err)
+	}
+
+	task := asynq.NewTask(TaskExpireHold, jsonPayload, opts...)
+	info, err := distributor.client.EnqueueContext(ctx, task, opts...)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).
+		Str("queue", info.Queue).Int("max_retry", info.MaxRetry).Msg("enqueued task")
+
+	return nil
+}
+
+func (processor *RedisTaskProcessor) ProcessTaskExpireHold(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadExpireHold
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("%w: failed to unmarshal task payload: %w", asynq.SkipRetry, err)
+	}
+
+	result, err := processor.store.ExpireHoldTx(ctx, payload.HoldID)
+	if err != nil {
+		if errors.Is(err, db.ErrHoldNotPending) {
+			// the hold was captured or voided before it expired
+			log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).
+				Msg("hold is already settled")
+			return nil
+		}
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return fmt.Errorf("%w: hold doesn't exist", asynq.SkipRetry)
+		}
+		return fmt.Errorf("failed to expire hold: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).
+		Int64("account_id", result.Account.ID).Msg("processed task")
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskSendAccountLockedEmail = "task:send_account_locked_email"
+
+type PayloadSendAccountLockedEmail struct {
+	Username string `json:"username"`
+	ClientIP string `json:"client_ip"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskSendAccountLockedEmail(ctx context.Context, payload *PayloadSendAccountLockedEmail, opts ...asynq.Option) error {
+	jsonPayload, err := marshalPayload(ctx, payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task payload: %w", err)
+	}
+
+	task := asynq.NewTask(TaskSendAccountLockedEmail, jsonPayload, opts...)
+	info, err := distributor.client.EnqueueContext(ctx, task, opts...)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).
+		Str("queue", info.Queue).Int("max_retry", info.MaxRetry).Msg("enqueued task")
+
+	return nil
+}
+
+func (processor *RedisTaskProcessor) ProcessTaskSendAccountLockedEmail(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadSendAccountLockedEmail
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("%w: failed to unmarshal task payload: %w", asynq.SkipRetry, err)
+	}
+
+	user, err := processor.store.GetUser(ctx, payload.Username)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	// The lock may have been lifted by a banker before the email is sent
+	if !user.LockedUntil.Valid {
+		return nil
+	}
+
+	subject := "Your Simple Bank account has been locked"
+	content := fmt.Sprintf(`Hello %s,<br/>
+	We locked your account after too many failed login attempts, the last one from %s.<br/>
+	You can try again after %s.<br/>
+	If this was not you, please reset your password and contact us.<br/>
+	`, user.FullName, payload.ClientIP, user.LockedUntil.Time.Format("2006-01-02 15:04 MST"))
+	to := []string{user.Email}
+
+	err = processor.mailer.SendEmail(subject, content, to, nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to send account locked email: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).
+		Str("email", user.Email).Msg("processed task")
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+	db "github.com/yelaco/simple-bank/db/sqlc"
+)
+
+const TaskSendPasswordResetEmail = "task:send_password_reset_email"
+
+type PayloadSendPasswordResetEmail struct {
+	PasswordResetID int64 `json:"password_reset_id"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskSendPasswordResetEmail(ctx context.Context, payload *PayloadSendPasswordResetEmail, opts ...asynq.Option) error {
+	jsonPayload, err := marshalPayload(ctx, payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task payload: %w", err)
+	}
+
+	task := asynq.NewTask(TaskSendPasswordResetEmail, jsonPayload, opts...)
+	info, err := distributor.client.EnqueueContext(ctx, task, opts...)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).
+		Str("queue", info.Queue).Int("max_retry", info.MaxRetry).Msg("enqueued task")
+
+	return nil
+}
+
+func (processor *RedisTaskProcessor) ProcessTaskSendPasswordResetEmail(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadSendPasswordResetEmail
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("%w: failed to unmarshal task payload: %w", asynq.SkipRetry, err)
+	}
+
+	passwordReset, err := processor.store.GetPasswordReset(ctx, payload.PasswordResetID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return fmt.Errorf("%w: password reset doesn't exist", asynq.SkipRetry)
+		}
+		return fmt.Errorf("failed to get password reset: %w", err)
+	}
+
+	user, err := processor.store.GetUser(ctx, passwordReset.Username)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	subject := "Reset your Simple Bank password"
+	resetURL, err := passwordResetURL(processor.frontendURL, passwordReset)
+	if err != nil {
+		return fmt.Errorf("%w: failed to build password reset link: %w", asynq.SkipRetry, err)
+	}
+	content := fmt.Sprintf(`Hello %s,<br/>
+	We received a request to reset your password.<br/>
+	Please <a href="%s">click here</a> to choose a new one. The link expires in 15 minutes.<br/>
+	If you did not request this, you can ignore this email.<br/>
+	`, user.FullName, resetURL)
+	to := []string{passwordReset.Email}
+
+	err = processor.mailer.SendEmail(subject, content, to, nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to send password reset email: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).
+		Str("email", passwordReset.Email).Msg("processed task")
+
+	return nil
+}
+
+// passwordResetURL returns the link to the page of the frontend choosing the
+// new password of a password reset
+func passwordResetURL(frontendURL string, passwordReset db.PasswordReset) (string, error) {
+	resetURL, err := url.JoinPath(frontendURL, "reset_password")
+	if err != nil {
+		return "", err
+	}
+
+	query := url.Values{}
+	query.Set("reset_id", strconv.FormatInt(passwordReset.ID, 10))
+	query.Set("secret_code", passwordReset.SecretCode)
+
+	return resetURL + "?" + query.Encode(), nil
+}
@@ -0,0 +1,19 @@
+package worker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	db "github.com/yelaco/simple-bank/db/sqlc"
+)
+
+func TestPasswordResetURL(t *testing.T) {
+	passwordReset := db.PasswordReset{ID: 42, SecretCode: "abc+def"}
+
+	resetURL, err := passwordResetURL("https://simplebank.com/app/", passwordReset)
+	require.NoError(t, err)
+	require.Equal(t, "https://simplebank.com/app/reset_password?reset_id=42&secret_code=abc%2Bdef", resetURL)
+
+	_, err = passwordResetURL("://missing-scheme", passwordReset)
+	require.Error(t, err)
+}
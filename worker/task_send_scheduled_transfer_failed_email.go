@@ -0,0 +1,78 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+	db "github.com/yelaco/simple-bank/db/sqlc"
+)
+
+const TaskSendScheduledTransferFailedEmail = "task:send_scheduled_transfer_failed_email"
+
+type PayloadSendScheduledTransferFailedEmail struct {
+	ScheduledTransferID int64 `json:"scheduled_transfer_id"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskSendScheduledTransferFailedEmail(ctx context.Context, payload *PayloadSendScheduledTransferFailedEmail, opts ...asynq.Option) error {
+	jsonPayload, err := marshalPayload(ctx, payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task payload: %w", err)
+	}
+
+	task := asynq.NewTask(TaskSendScheduledTransferFailedEmail, jsonPayload, opts...)
+	info, err := distributor.client.EnqueueContext(ctx, task, opts...)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).
+		Str("queue", info.Queue).Int("max_retry", info.MaxRetry).Msg("enqueued task")
+
+	return nil
+}
+
+func (processor *RedisTaskProcessor) ProcessTaskSendScheduledTransferFailedEmail(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadSendScheduledTransferFailedEmail
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("%w: failed to unmarshal task payload: %w", asynq.SkipRetry, err)
+	}
+
+	schedule, err := processor.store.GetScheduledTransfer(ctx, payload.ScheduledTransferID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return fmt.Errorf("%w: scheduled transfer doesn't exist", asynq.SkipRetry)
+		}
+		return fmt.Errorf("failed to get scheduled transfer: %w", err)
+	}
+
+	user, err := processor.store.GetUser(ctx, schedule.Owner)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	next := fmt.Sprintf("The next transfer is scheduled at %s.", schedule.NextRunAt.Format("2006-01-02 15:04 MST"))
+	if schedule.Status == db.ScheduledTransferStatusFailed {
+		next = "The scheduled transfer has been stopped, please create a new one once the issue is solved."
+	}
+
+	subject := "Your scheduled transfer failed"
+	content := fmt.Sprintf(`Hello %s,<br/>
+	We could not transfer %d from account #%d to account #%d as scheduled: %s.<br/>
+	%s<br/>
+	`, user.FullName, schedule.Amount, schedule.FromAccountID, schedule.ToAccountID, schedule.LastError.String, next)
+	to := []string{user.Email}
+
+	err = processor.mailer.SendEmail(subject, content, to, nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to send scheduled transfer failed email: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).
+		Str("email", user.Email).Msg("processed task")
+
+	return nil
+}
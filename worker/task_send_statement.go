@@ -0,0 +1,119 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+	db "github.com/yelaco/simple-bank/db/sqlc"
+	"github.com/yelaco/simple-bank/statement"
+)
+
+const TaskSendStatement = "task:send_statement"
+
+type PayloadSendStatement struct {
+	AccountID int64     `json:"account_id"`
+	FromTime  time.Time `json:"from_time"`
+	ToTime    time.Time `json:"to_time"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskSendStatement(ctx context.Context, payload *PayloadSendStatement, opts ...asynq.Option) error {
+	jsonPayload, err := marshalPayload(ctx, payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task payload: %w", err)
+	}
+
+	task := asynq.NewTask(TaskSendStatement, jsonPayload, opts...)
+	info, err := distributor.client.EnqueueContext(ctx, task, opts...)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).
+		Str("queue", info.Queue).Int("max_retry", info.MaxRetry).Msg("enqueued task")
+
+	return nil
+}
+
+func (processor *RedisTaskProcessor) ProcessTaskSendStatement(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadSendStatement
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("%w: failed to unmarshal task payload: %w", asynq.SkipRetry, err)
+	}
+
+	data, err := processor.store.StatementTx(ctx, db.StatementTxParams{
+		AccountID: payload.AccountID,
+		FromTime:  payload.FromTime,
+		ToTime:    payload.ToTime,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return fmt.Errorf("%w: account doesn't exist", asynq.SkipRetry)
+		}
+		return fmt.Errorf("failed to read statement data: %w", err)
+	}
+
+	user, err := processor.store.GetUser(ctx, data.Account.Owner)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	stmt := statement.New(data, payload.FromTime, payload.ToTime)
+	firstDay, lastDay := stmt.Period()
+
+	// SendEmail attaches files by path, so the rendered statement is written to a temporary directory
+	dir, err := os.MkdirTemp("", "statement-")
+	if err != nil {
+		return fmt.Errorf("failed to create statement directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	baseName := fmt.Sprintf("statement_%d_%s_%s", stmt.Account.ID, firstDay, lastDay)
+	csvFile := filepath.Join(dir, baseName+".csv")
+	pdfFile := filepath.Join(dir, baseName+".pdf")
+
+	if err := writeFile(csvFile, stmt.WriteCSV); err != nil {
+		return fmt.Errorf("failed to write statement csv: %w", err)
+	}
+
+	if err := writeFile(pdfFile, stmt.WritePDF); err != nil {
+		return fmt.Errorf("failed to write statement pdf: %w", err)
+	}
+
+	subject := fmt.Sprintf("Your Simple Bank statement from %s to %s", firstDay, lastDay)
+	content := fmt.Sprintf(`Hello %s,<br/>
+	Please find attached the statement of your account #%d from %s to %s, as PDF and CSV.<br/>
+	`, user.FullName, stmt.Account.ID, firstDay, lastDay)
+	to := []string{user.Email}
+
+	err = processor.mailer.SendEmail(subject, content, to, nil, nil, []string{pdfFile, csvFile})
+	if err != nil {
+		return fmt.Errorf("failed to send statement email: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).
+		Str("email", user.Email).Int("entries", len(stmt.Lines)).Msg("processed task")
+
+	return nil
+}
+
+func writeFile(name string, write func(w io.Writer) error) error {
+	file, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+
+	if err := write(file); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
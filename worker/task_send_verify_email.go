@@ -18,7 +18,7 @@ type PayloadSendVerifyEmail struct {
 }
 
 func (distributor *RedisTaskDistributor) DistributeTaskSendVerifyEmail(ctx context.Context, payload *PayloadSendVerifyEmail, opts ...asynq.Option) error {
-	jsonPayload, err := json.Marshal(payload)
+	jsonPayload, err := marshalPayload(ctx, payload)
 	if err != nil {
 		return fmt.Errorf("failed to marshal task payload: %w", err)
 	}
@@ -0,0 +1,76 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hibiken/asynq"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// traceContextField is the payload field carrying the trace context of the
+// request that enqueued a task
+const traceContextField = "trace_context"
+
+const tracerName = "github.com/yelaco/simple-bank/worker"
+
+// marshalPayload encodes a task payload along with the trace context of the
+// caller, so that processing the task continues the caller's trace
+func marshalPayload(ctx context.Context, payload any) ([]byte, error) {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		return jsonPayload, nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(jsonPayload, &fields); err != nil {
+		return nil, err
+	}
+
+	fields[traceContextField], err = json.Marshal(carrier)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(fields)
+}
+
+// taskTracing processes every task in a span, whose parent is the span that
+// enqueued the task when its payload carries a trace context
+func taskTracing(handler asynq.Handler) asynq.Handler {
+	return asynq.HandlerFunc(func(ctx context.Context, task *asynq.Task) error {
+		var payload struct {
+			TraceContext propagation.MapCarrier `json:"trace_context"`
+		}
+		// Malformed payloads are reported by the task handlers
+		_ = json.Unmarshal(task.Payload(), &payload)
+
+		ctx = otel.GetTextMapPropagator().Extract(ctx, payload.TraceContext)
+		ctx, span := otel.Tracer(tracerName).Start(ctx, task.Type(),
+			trace.WithSpanKind(trace.SpanKindConsumer),
+			trace.WithAttributes(
+				attribute.String("messaging.system", "asynq"),
+				attribute.String("messaging.operation.type", "process"),
+			),
+		)
+		defer span.End()
+
+		err := handler.ProcessTask(ctx, task)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+
+		return err
+	})
+}
@@ -0,0 +1,104 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/require"
+	db "github.com/yelaco/simple-bank/db/sqlc"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func setupTestTracing(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	previousProvider := otel.GetTracerProvider()
+	previousPropagator := otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+
+	return recorder
+}
+
+func TestTaskTraceContext(t *testing.T) {
+	recorder := setupTestTracing(t)
+
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "CreateUser")
+	jsonPayload, err := marshalPayload(ctx, &PayloadSendVerifyEmail{Username: "alice"})
+	require.NoError(t, err)
+	parent.End()
+
+	// The task handlers still decode the payload they were given
+	var payload PayloadSendVerifyEmail
+	require.NoError(t, json.Unmarshal(jsonPayload, &payload))
+	require.Equal(t, "alice", payload.Username)
+
+	var handlerSpan trace.SpanContext
+	handler := taskTracing(asynq.HandlerFunc(func(ctx context.Context, task *asynq.Task) error {
+		handlerSpan = trace.SpanContextFromContext(ctx)
+		return nil
+	}))
+
+	task := asynq.NewTask(TaskSendVerifyEmail, jsonPayload)
+	require.NoError(t, handler.ProcessTask(context.Background(), task))
+
+	require.Equal(t, parent.SpanContext().TraceID(), handlerSpan.TraceID())
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	require.Equal(t, TaskSendVerifyEmail, spans[1].Name())
+	require.Equal(t, trace.SpanKindConsumer, spans[1].SpanKind())
+	require.Equal(t, parent.SpanContext().SpanID(), spans[1].Parent().SpanID())
+}
+
+func TestDeliverWebhookTaskTraceContext(t *testing.T) {
+	setupTestTracing(t)
+
+	// Webhook deliveries are written to the outbox, their task still carries
+	// the trace context of the request emitting the event
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "CreateAccount")
+	task, err := NewDeliverWebhookTask(ctx, db.WebhookDelivery{ID: 1})
+	require.NoError(t, err)
+	parent.End()
+
+	var payload struct {
+		TraceContext map[string]string `json:"trace_context"`
+	}
+	require.NoError(t, json.Unmarshal(task.Payload, &payload))
+	require.Contains(t, payload.TraceContext["traceparent"], parent.SpanContext().TraceID().String())
+}
+
+func TestTaskTraceContextMissing(t *testing.T) {
+	recorder := setupTestTracing(t)
+
+	jsonPayload, err := marshalPayload(context.Background(), &PayloadSendVerifyEmail{Username: "alice"})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"username":"alice"}`, string(jsonPayload))
+
+	handlerErr := errors.New("smtp unavailable")
+	handler := taskTracing(asynq.HandlerFunc(func(ctx context.Context, task *asynq.Task) error {
+		return handlerErr
+	}))
+
+	task := asynq.NewTask(TaskSendVerifyEmail, jsonPayload)
+	require.ErrorIs(t, handler.ProcessTask(context.Background(), task), handlerErr)
+
+	// Tasks enqueued outside of a trace start a new one
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	require.False(t, spans[0].Parent().IsValid())
+	require.Equal(t, codes.Error, spans[0].Status().Code)
+}